@@ -0,0 +1,484 @@
+// Package bpmn decodes BPMN 2.0 process models with the Camunda extension attributes and elements
+// into typed structs, e.g. the BPMN XML returned by GetProcessDefinitionXML:
+//
+//	source, err := camunda.GetProcessDefinitionXMLByKey(ctx, "invoice")
+//	definitions, err := bpmn.Parse(source.Content)
+//
+//	for _, v := range definitions.ExternalTaskTopics() {
+//		...
+//	}
+//
+// Flow nodes are looked up by id and the sequence flows between them are traversed with the
+// methods of Process. Process models are built fluently with NewProcess and written with Encode,
+// which generates the diagram interchange layout. Lint checks process models before they are
+// deployed, CheckTopics and CheckDeployedTopics check that a worker handles all external task
+// topics.
+package bpmn
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+)
+
+// index holds the flow nodes and sequence flows of a process, including those of its sub
+// processes, by id.
+type index struct {
+	nodes    []FlowNode
+	sequence []*SequenceFlow
+	byId     map[string]FlowNode
+	flows    map[string]*SequenceFlow
+	outgoing map[string][]*SequenceFlow
+	incoming map[string][]*SequenceFlow
+	err      error
+}
+
+// Parse decodes the BPMN XML of a process model.
+func Parse(content string) (*Definitions, error) {
+	return Decode(strings.NewReader(content))
+}
+
+// Decode reads and decodes the BPMN XML of a process model.
+func Decode(r io.Reader) (*Definitions, error) {
+	content, err := ioutil.ReadAll(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return decode(content, true)
+}
+
+// decode decodes the BPMN XML of a process model. Unless strict, duplicate ids and sequence flows
+// connecting unknown elements are skipped instead of failing the decoding.
+func decode(content []byte, strict bool) (*Definitions, error) {
+	result := &Definitions{}
+
+	if err := xml.Unmarshal(content, result); err != nil {
+		return nil, err
+	}
+
+	order, duplicates, err := positions(content)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result.duplicates = duplicates
+
+	for _, v := range result.Processes {
+		if err = v.build(order); err != nil && strict {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// Process returns the process with the given id, or nil.
+func (d *Definitions) Process(id string) *Process {
+	for _, v := range d.Processes {
+		if v.Id == id {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// Element returns the flow node with the given id of any process, or nil.
+func (d *Definitions) Element(id string) FlowNode {
+	for _, v := range d.Processes {
+		if e := v.Element(id); e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// Message returns the message with the given id, or nil.
+func (d *Definitions) Message(id string) *Message {
+	for _, v := range d.Messages {
+		if v.Id == id {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// UserTasks returns the user tasks of all processes in document order.
+func (d *Definitions) UserTasks() []*Task {
+	result := make([]*Task, 0)
+
+	for _, v := range d.Processes {
+		result = append(result, v.AllUserTasks()...)
+	}
+
+	return result
+}
+
+// ExternalTasks returns the service, send and business rule tasks implemented by external workers
+// of all processes in document order.
+func (d *Definitions) ExternalTasks() []*Task {
+	result := make([]*Task, 0)
+
+	for _, v := range d.Processes {
+		result = append(result, v.ExternalTasks()...)
+	}
+
+	return result
+}
+
+// ExternalTaskTopics returns the sorted, distinct external task topics of all processes, including
+// those of message throw events implemented as external tasks.
+func (d *Definitions) ExternalTaskTopics() []string {
+	result := make([]string, 0)
+
+	for _, v := range d.Processes {
+		result = append(result, v.ExternalTaskTopics()...)
+	}
+
+	return distinct(result)
+}
+
+// Elements returns the flow nodes of the process and its sub processes in document order.
+func (p *Process) Elements() []FlowNode {
+	return p.indexed().nodes
+}
+
+// Element returns the flow node with the given id, or nil.
+func (p *Process) Element(id string) FlowNode {
+	return p.indexed().byId[id]
+}
+
+// SequenceFlow returns the sequence flow with the given id, or nil.
+func (p *Process) SequenceFlow(id string) *SequenceFlow {
+	return p.indexed().flows[id]
+}
+
+// Outgoing returns the sequence flows leaving the flow node with the given id.
+func (p *Process) Outgoing(id string) []*SequenceFlow {
+	return p.indexed().outgoing[id]
+}
+
+// Incoming returns the sequence flows entering the flow node with the given id.
+func (p *Process) Incoming(id string) []*SequenceFlow {
+	return p.indexed().incoming[id]
+}
+
+// Successors returns the targets of the sequence flows leaving the flow node with the given id.
+func (p *Process) Successors(id string) []FlowNode {
+	x := p.indexed()
+	result := make([]FlowNode, 0)
+
+	for _, v := range x.outgoing[id] {
+		result = append(result, x.byId[v.TargetRef])
+	}
+
+	return result
+}
+
+// Predecessors returns the sources of the sequence flows entering the flow node with the given id.
+func (p *Process) Predecessors(id string) []FlowNode {
+	x := p.indexed()
+	result := make([]FlowNode, 0)
+
+	for _, v := range x.incoming[id] {
+		result = append(result, x.byId[v.SourceRef])
+	}
+
+	return result
+}
+
+// Boundary returns the boundary events attached to the activity with the given id.
+func (p *Process) Boundary(id string) []*Event {
+	result := make([]*Event, 0)
+
+	for _, v := range p.indexed().nodes {
+		if e, ok := v.(*Event); ok && e.Kind == KindBoundaryEvent && e.AttachedToRef == id {
+			result = append(result, e)
+		}
+	}
+
+	return result
+}
+
+// AllUserTasks returns the user tasks of the process and its sub processes in document order.
+func (p *Process) AllUserTasks() []*Task {
+	result := make([]*Task, 0)
+
+	for _, v := range p.indexed().nodes {
+		if t, ok := v.(*Task); ok && t.Kind == KindUserTask {
+			result = append(result, t)
+		}
+	}
+
+	return result
+}
+
+// ExternalTasks returns the tasks of the process and its sub processes implemented by external
+// workers in document order.
+func (p *Process) ExternalTasks() []*Task {
+	result := make([]*Task, 0)
+
+	for _, v := range p.indexed().nodes {
+		if t, ok := v.(*Task); ok && t.External() {
+			result = append(result, t)
+		}
+	}
+
+	return result
+}
+
+// ExternalTaskTopics returns the sorted, distinct external task topics of the process, including
+// those of message throw events implemented as external tasks.
+func (p *Process) ExternalTaskTopics() []string {
+	result := make([]string, 0)
+
+	for _, v := range p.indexed().nodes {
+		if topic, _ := Topic(v); topic != "" {
+			result = append(result, topic)
+		}
+	}
+
+	return distinct(result)
+}
+
+// sequenceFlows returns the sequence flows of the process and its sub processes in document order.
+func (p *Process) sequenceFlows() []*SequenceFlow {
+	return p.indexed().sequence
+}
+
+// indexed returns the index of the process. Processes which were neither parsed nor built with a
+// Builder are indexed when they are first looked up, in the order of their element kinds.
+func (p *Process) indexed() *index {
+	if p.index == nil {
+		// the index skips invalid elements, which Lint reports.
+		_ = p.build(nil)
+	}
+
+	return p.index
+}
+
+// reindex indexes the process again after its elements were changed, keeping the order of the
+// elements which were already indexed.
+func (p *Process) reindex() error {
+	order := make(map[string]int)
+
+	if p.index != nil {
+		for i, v := range p.index.nodes {
+			order[v.Base().Id] = i
+		}
+
+		for i, v := range p.index.sequence {
+			order[v.Id] = i
+		}
+	}
+
+	return p.build(order)
+}
+
+// build indexes the flow nodes and sequence flows of the process in the given document order.
+func (p *Process) build(order map[string]int) error {
+	p.index = &index{
+		nodes:    make([]FlowNode, 0),
+		sequence: make([]*SequenceFlow, 0),
+		byId:     make(map[string]FlowNode),
+		flows:    make(map[string]*SequenceFlow),
+		outgoing: make(map[string][]*SequenceFlow),
+		incoming: make(map[string][]*SequenceFlow),
+	}
+
+	p.index.add(&p.FlowElements, "")
+
+	position := func(id string) int {
+		if v, ok := order[id]; ok {
+			return v
+		}
+
+		return len(order)
+	}
+
+	sort.SliceStable(p.index.nodes, func(i, j int) bool {
+		return position(p.index.nodes[i].Base().Id) < position(p.index.nodes[j].Base().Id)
+	})
+
+	sort.SliceStable(p.index.sequence, func(i, j int) bool {
+		return position(p.index.sequence[i].Id) < position(p.index.sequence[j].Id)
+	})
+
+	for _, v := range p.index.sequence {
+		if _, ok := p.index.byId[v.SourceRef]; !ok {
+			p.index.fail("bpmn: sequence flow %s of process %s has unknown source %s", v.Id, p.Id, v.SourceRef)
+			continue
+		}
+
+		if _, ok := p.index.byId[v.TargetRef]; !ok {
+			p.index.fail("bpmn: sequence flow %s of process %s has unknown target %s", v.Id, p.Id, v.TargetRef)
+			continue
+		}
+
+		p.index.outgoing[v.SourceRef] = append(p.index.outgoing[v.SourceRef], v)
+		p.index.incoming[v.TargetRef] = append(p.index.incoming[v.TargetRef], v)
+	}
+
+	return p.index.err
+}
+
+// add indexes the given flow elements of the process or the sub process with the given id.
+func (x *index) add(f *FlowElements, parent string) {
+	events := []struct {
+		kind   string
+		values []*Event
+	}{
+		{KindStartEvent, f.StartEvents},
+		{KindEndEvent, f.EndEvents},
+		{KindIntermediateCatchEvent, f.IntermediateCatchEvents},
+		{KindIntermediateThrowEvent, f.IntermediateThrowEvents},
+		{KindBoundaryEvent, f.BoundaryEvents},
+	}
+
+	tasks := []struct {
+		kind   string
+		values []*Task
+	}{
+		{KindTask, f.Tasks},
+		{KindUserTask, f.UserTasks},
+		{KindServiceTask, f.ServiceTasks},
+		{KindSendTask, f.SendTasks},
+		{KindReceiveTask, f.ReceiveTasks},
+		{KindScriptTask, f.ScriptTasks},
+		{KindBusinessRuleTask, f.BusinessRuleTasks},
+		{KindManualTask, f.ManualTasks},
+	}
+
+	gateways := []struct {
+		kind   string
+		values []*Gateway
+	}{
+		{KindExclusiveGateway, f.ExclusiveGateways},
+		{KindParallelGateway, f.ParallelGateways},
+		{KindInclusiveGateway, f.InclusiveGateways},
+		{KindEventBasedGateway, f.EventBasedGateways},
+		{KindComplexGateway, f.ComplexGateways},
+	}
+
+	for _, group := range events {
+		for _, v := range group.values {
+			x.node(v, group.kind, parent)
+		}
+	}
+
+	for _, group := range tasks {
+		for _, v := range group.values {
+			x.node(v, group.kind, parent)
+		}
+	}
+
+	for _, group := range gateways {
+		for _, v := range group.values {
+			x.node(v, group.kind, parent)
+		}
+	}
+
+	for _, v := range f.CallActivities {
+		x.node(v, KindCallActivity, parent)
+	}
+
+	for _, v := range f.SubProcesses {
+		x.node(v, KindSubProcess, parent)
+		x.add(&v.FlowElements, v.Id)
+	}
+
+	for _, v := range f.SequenceFlows {
+		if _, ok := x.flows[v.Id]; ok || v.Id == "" {
+			x.fail("bpmn: duplicate or missing sequence flow id %q", v.Id)
+			continue
+		}
+
+		x.flows[v.Id] = v
+		x.sequence = append(x.sequence, v)
+	}
+}
+
+// node indexes a single flow node. Flow nodes with a duplicate or missing id are skipped.
+func (x *index) node(n FlowNode, kind, parent string) {
+	base := n.Base()
+
+	base.Kind = kind
+	base.Parent = parent
+
+	if _, ok := x.byId[base.Id]; ok || base.Id == "" {
+		x.fail("bpmn: duplicate or missing %s id %q", kind, base.Id)
+		return
+	}
+
+	x.nodes = append(x.nodes, n)
+	x.byId[base.Id] = n
+}
+
+// fail records the first error of the indexing.
+func (x *index) fail(format string, a ...interface{}) {
+	if x.err == nil {
+		x.err = fmt.Errorf(format, a...)
+	}
+}
+
+// positions returns the position of each element with an id in the document and the ids which
+// occur more than once.
+func positions(content []byte) (map[string]int, []string, error) {
+	result := make(map[string]int)
+	duplicates := make([]string, 0)
+
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+
+	for {
+		token, err := decoder.Token()
+
+		if err == io.EOF {
+			return result, duplicates, nil
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		start, ok := token.(xml.StartElement)
+
+		if !ok {
+			continue
+		}
+
+		for _, v := range start.Attr {
+			if v.Name.Local == "id" && v.Name.Space == "" {
+				if _, ok := result[v.Value]; ok {
+					duplicates = append(duplicates, v.Value)
+				} else {
+					result[v.Value] = len(result)
+				}
+			}
+		}
+	}
+}
+
+func distinct(values []string) []string {
+	result := make([]string, 0, len(values))
+	seen := make(map[string]bool)
+
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+
+	sort.Strings(result)
+
+	return result
+}
@@ -0,0 +1,170 @@
+package bpmn
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const approval = `<?xml version="1.0" encoding="UTF-8"?>
+<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL" xmlns:camunda="http://camunda.org/schema/1.0/bpmn" id="definitions_approval" targetNamespace="http://bpmn.io/schema/bpmn">
+  <bpmn:message id="message_1" name="Approved" />
+  <bpmn:process id="approval" isExecutable="true" camunda:historyTimeToLive="P30D">
+    <bpmn:startEvent id="start" />
+    <bpmn:userTask id="review" camunda:candidateGroups="accounting, management" />
+    <bpmn:boundaryEvent id="reminder" attachedToRef="review" cancelActivity="false">
+      <bpmn:timerEventDefinition>
+        <bpmn:timeDuration>P1D</bpmn:timeDuration>
+      </bpmn:timerEventDefinition>
+    </bpmn:boundaryEvent>
+    <bpmn:exclusiveGateway id="approved" default="rejected-flow" />
+    <bpmn:subProcess id="payment">
+      <bpmn:startEvent id="payment-start" />
+      <bpmn:serviceTask id="pay" camunda:type="external" camunda:topic="payment" />
+      <bpmn:endEvent id="payment-end" />
+      <bpmn:sequenceFlow id="payment-flow-1" sourceRef="payment-start" targetRef="pay" />
+      <bpmn:sequenceFlow id="payment-flow-2" sourceRef="pay" targetRef="payment-end" />
+    </bpmn:subProcess>
+    <bpmn:intermediateThrowEvent id="notify">
+      <bpmn:messageEventDefinition messageRef="message_1" camunda:type="external" camunda:topic="notification" />
+    </bpmn:intermediateThrowEvent>
+    <bpmn:endEvent id="paid" />
+    <bpmn:endEvent id="rejected" />
+    <bpmn:sequenceFlow id="flow-1" sourceRef="start" targetRef="review" />
+    <bpmn:sequenceFlow id="flow-2" sourceRef="review" targetRef="approved" />
+    <bpmn:sequenceFlow id="approved-flow" sourceRef="approved" targetRef="payment">
+      <bpmn:conditionExpression> ${approved} </bpmn:conditionExpression>
+    </bpmn:sequenceFlow>
+    <bpmn:sequenceFlow id="rejected-flow" sourceRef="approved" targetRef="rejected" />
+    <bpmn:sequenceFlow id="flow-3" sourceRef="payment" targetRef="notify" />
+    <bpmn:sequenceFlow id="flow-4" sourceRef="notify" targetRef="paid" />
+  </bpmn:process>
+</bpmn:definitions>`
+
+// ids returns the ids of the flow nodes.
+func ids(nodes []FlowNode) []string {
+	result := make([]string, 0, len(nodes))
+
+	for _, v := range nodes {
+		result = append(result, v.Base().Id)
+	}
+
+	return result
+}
+
+// flows returns the ids of the sequence flows.
+func flows(values []*SequenceFlow) []string {
+	result := make([]string, 0, len(values))
+
+	for _, v := range values {
+		result = append(result, v.Id)
+	}
+
+	return result
+}
+
+func TestParse(t *testing.T) {
+	definitions, err := Parse(approval)
+
+	require.NoError(t, err)
+
+	p := definitions.Process("approval")
+
+	require.NotNil(t, p)
+	require.True(t, p.Executable())
+	require.Nil(t, definitions.Process("unknown"))
+
+	// the elements keep the document order across their kinds.
+	require.Equal(t, []string{
+		"start", "review", "reminder", "approved", "payment", "payment-start", "pay", "payment-end", "notify", "paid", "rejected",
+	}, ids(p.Elements()))
+
+	review := p.Element("review").(*Task)
+
+	require.Equal(t, KindUserTask, review.Kind)
+	require.Equal(t, []string{"accounting", "management"}, review.CandidateGroupList())
+	require.Nil(t, p.Element("unknown"))
+
+	pay := definitions.Element("pay").(*Task)
+
+	require.Equal(t, KindServiceTask, pay.Kind)
+	require.Equal(t, "payment", pay.Parent)
+	require.True(t, pay.External())
+	require.Nil(t, definitions.Element("unknown"))
+
+	require.Equal(t, "${approved}", p.SequenceFlow("approved-flow").Condition())
+	require.Equal(t, "rejected-flow", p.Element("approved").(*Gateway).Default)
+	require.Equal(t, "Approved", definitions.Message("message_1").Name)
+	require.Nil(t, definitions.Message("message_2"))
+}
+
+func TestTraversal(t *testing.T) {
+	definitions, err := Parse(approval)
+
+	require.NoError(t, err)
+
+	p := definitions.Process("approval")
+
+	require.Equal(t, []string{"approved-flow", "rejected-flow"}, flows(p.Outgoing("approved")))
+	require.Equal(t, []string{"flow-2"}, flows(p.Incoming("approved")))
+	require.Empty(t, p.Incoming("start"))
+	require.Empty(t, p.Outgoing("paid"))
+
+	require.Equal(t, []string{"payment", "rejected"}, ids(p.Successors("approved")))
+	require.Equal(t, []string{"approved"}, ids(p.Predecessors("payment")))
+	require.Equal(t, []string{"payment-start"}, ids(p.Predecessors("pay")))
+
+	boundary := p.Boundary("review")
+
+	require.Len(t, boundary, 1)
+	require.Equal(t, "reminder", boundary[0].Id)
+	require.False(t, boundary[0].Interrupting())
+	require.Equal(t, "P1D", boundary[0].Timer.TimeDuration.Text)
+	require.Empty(t, p.Boundary("approved"))
+
+	require.Equal(t, []*Task{p.Element("review").(*Task)}, p.AllUserTasks())
+	require.Equal(t, []*Task{p.Element("pay").(*Task)}, p.ExternalTasks())
+	require.Equal(t, p.AllUserTasks(), definitions.UserTasks())
+	require.Equal(t, p.ExternalTasks(), definitions.ExternalTasks())
+	require.Equal(t, []string{"notification", "payment"}, p.ExternalTaskTopics())
+	require.Equal(t, []string{"notification", "payment"}, definitions.ExternalTaskTopics())
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := Parse("<bpmn:definitions")
+
+	require.Error(t, err)
+
+	duplicate := strings.Replace(approval, `id="paid"`, `id="rejected"`, 1)
+
+	_, err = Parse(duplicate)
+
+	require.EqualError(t, err, `bpmn: duplicate or missing endEvent id "rejected"`)
+
+	unknown := strings.Replace(approval, `targetRef="paid"`, `targetRef="archived"`, 1)
+
+	_, err = Parse(unknown)
+
+	require.EqualError(t, err, "bpmn: sequence flow flow-4 of process approval has unknown target archived")
+}
+
+func TestProcessBuiltByHand(t *testing.T) {
+	p := &Process{Id: "manual"}
+
+	require.Empty(t, p.Elements())
+	require.Nil(t, p.Element("start"))
+	require.Empty(t, p.Outgoing("start"))
+	require.Empty(t, p.ExternalTaskTopics())
+
+	p = &Process{Id: "manual", FlowElements: FlowElements{
+		StartEvents:   []*Event{{BaseElement: BaseElement{Id: "start"}}},
+		ServiceTasks:  []*Task{{BaseElement: BaseElement{Id: "pay"}, Type: "external", Topic: "payment"}},
+		SequenceFlows: []*SequenceFlow{{Id: "flow", SourceRef: "start", TargetRef: "pay"}},
+	}}
+
+	require.Equal(t, []string{"start", "pay"}, ids(p.Elements()))
+	require.Equal(t, KindServiceTask, p.Element("pay").Base().Kind)
+	require.Equal(t, []string{"pay"}, ids(p.Successors("start")))
+	require.Equal(t, []string{"payment"}, p.ExternalTaskTopics())
+}
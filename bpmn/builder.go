@@ -0,0 +1,620 @@
+package bpmn
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Builder builds an executable process fluently. Each element is connected to the element added
+// before it with a sequence flow, the setters apply to the element added last:
+//
+//	content, err := bpmn.NewProcess("approval").HistoryTimeToLive("P30D").
+//		StartEvent().
+//		UserTask("review").CandidateGroups("accounting").
+//		ExclusiveGateway("approved").
+//		Condition("${approved}").ServiceTask("pay").Topic("payment").EndEvent("paid").
+//		MoveTo("approved").Default().EndEvent("rejected").
+//		XML()
+//
+// The ids of elements are generated when omitted. Errors are collected and returned by Build and
+// XML.
+type Builder struct {
+	definitions *Definitions
+	process     *Process
+	current     FlowNode
+	condition   string
+	isDefault   bool
+	order       map[string]int
+	counts      map[string]int
+	err         error
+}
+
+// NewProcess starts building an executable process with the given id.
+func NewProcess(id string) *Builder {
+	b := &Builder{order: make(map[string]int), counts: make(map[string]int)}
+
+	executable := true
+
+	b.process = &Process{Id: id, IsExecutable: &executable}
+
+	b.definitions = &Definitions{
+		Id:              "definitions_" + id,
+		TargetNamespace: "http://bpmn.io/schema/bpmn",
+		Processes:       []*Process{b.process},
+	}
+
+	return b
+}
+
+// Name sets the name of the element added last, or of the process when no element was added yet.
+func (b *Builder) Name(name string) *Builder {
+	if b.current == nil {
+		b.process.Name = name
+	} else {
+		b.current.Base().Name = name
+	}
+
+	return b
+}
+
+// Documentation sets the documentation of the element added last, or of the process when no element
+// was added yet.
+func (b *Builder) Documentation(text string) *Builder {
+	if b.current == nil {
+		b.process.Documentation = text
+	} else {
+		b.current.Base().Documentation = text
+	}
+
+	return b
+}
+
+// HistoryTimeToLive sets the history time to live of the process, e.g. P30D or 180.
+func (b *Builder) HistoryTimeToLive(ttl string) *Builder {
+	b.process.HistoryTimeToLive = ttl
+	return b
+}
+
+// VersionTag sets the version tag of the process.
+func (b *Builder) VersionTag(tag string) *Builder {
+	b.process.VersionTag = tag
+	return b
+}
+
+// StartEvent adds a start event.
+func (b *Builder) StartEvent(id ...string) *Builder {
+	e := &Event{BaseElement: b.base(KindStartEvent, id)}
+	b.process.StartEvents = append(b.process.StartEvents, e)
+
+	return b.add(e)
+}
+
+// EndEvent adds an end event.
+func (b *Builder) EndEvent(id ...string) *Builder {
+	e := &Event{BaseElement: b.base(KindEndEvent, id)}
+	b.process.EndEvents = append(b.process.EndEvents, e)
+
+	return b.add(e)
+}
+
+// IntermediateCatchEvent adds an intermediate catch event, its event definition is set with
+// Message or Timer.
+func (b *Builder) IntermediateCatchEvent(id ...string) *Builder {
+	e := &Event{BaseElement: b.base(KindIntermediateCatchEvent, id)}
+	b.process.IntermediateCatchEvents = append(b.process.IntermediateCatchEvents, e)
+
+	return b.add(e)
+}
+
+// IntermediateThrowEvent adds an intermediate throw event.
+func (b *Builder) IntermediateThrowEvent(id ...string) *Builder {
+	e := &Event{BaseElement: b.base(KindIntermediateThrowEvent, id)}
+	b.process.IntermediateThrowEvents = append(b.process.IntermediateThrowEvents, e)
+
+	return b.add(e)
+}
+
+// Task adds an abstract task.
+func (b *Builder) Task(id ...string) *Builder {
+	t := &Task{BaseElement: b.base(KindTask, id)}
+	b.process.Tasks = append(b.process.Tasks, t)
+
+	return b.add(t)
+}
+
+// UserTask adds a user task.
+func (b *Builder) UserTask(id ...string) *Builder {
+	t := &Task{BaseElement: b.base(KindUserTask, id)}
+	b.process.UserTasks = append(b.process.UserTasks, t)
+
+	return b.add(t)
+}
+
+// ServiceTask adds a service task, its implementation is set with Topic, Class, Expression or
+// DelegateExpression.
+func (b *Builder) ServiceTask(id ...string) *Builder {
+	t := &Task{BaseElement: b.base(KindServiceTask, id)}
+	b.process.ServiceTasks = append(b.process.ServiceTasks, t)
+
+	return b.add(t)
+}
+
+// SendTask adds a send task.
+func (b *Builder) SendTask(id ...string) *Builder {
+	t := &Task{BaseElement: b.base(KindSendTask, id)}
+	b.process.SendTasks = append(b.process.SendTasks, t)
+
+	return b.add(t)
+}
+
+// ReceiveTask adds a receive task, its message is set with Message.
+func (b *Builder) ReceiveTask(id ...string) *Builder {
+	t := &Task{BaseElement: b.base(KindReceiveTask, id)}
+	b.process.ReceiveTasks = append(b.process.ReceiveTasks, t)
+
+	return b.add(t)
+}
+
+// ScriptTask adds a script task with the given script.
+func (b *Builder) ScriptTask(format, script string, id ...string) *Builder {
+	t := &Task{BaseElement: b.base(KindScriptTask, id), ScriptFormat: format, Script: script}
+	b.process.ScriptTasks = append(b.process.ScriptTasks, t)
+
+	return b.add(t)
+}
+
+// BusinessRuleTask adds a business rule task evaluating the decision with the given key.
+func (b *Builder) BusinessRuleTask(decisionRef string, id ...string) *Builder {
+	t := &Task{BaseElement: b.base(KindBusinessRuleTask, id), DecisionRef: decisionRef}
+	b.process.BusinessRuleTasks = append(b.process.BusinessRuleTasks, t)
+
+	return b.add(t)
+}
+
+// CallActivity adds a call activity calling the process with the given key.
+func (b *Builder) CallActivity(calledElement string, id ...string) *Builder {
+	c := &CallActivity{BaseElement: b.base(KindCallActivity, id), CalledElement: calledElement}
+	b.process.CallActivities = append(b.process.CallActivities, c)
+
+	return b.add(c)
+}
+
+// ExclusiveGateway adds an exclusive gateway.
+func (b *Builder) ExclusiveGateway(id ...string) *Builder {
+	g := &Gateway{BaseElement: b.base(KindExclusiveGateway, id)}
+	b.process.ExclusiveGateways = append(b.process.ExclusiveGateways, g)
+
+	return b.add(g)
+}
+
+// ParallelGateway adds a parallel gateway.
+func (b *Builder) ParallelGateway(id ...string) *Builder {
+	g := &Gateway{BaseElement: b.base(KindParallelGateway, id)}
+	b.process.ParallelGateways = append(b.process.ParallelGateways, g)
+
+	return b.add(g)
+}
+
+// InclusiveGateway adds an inclusive gateway.
+func (b *Builder) InclusiveGateway(id ...string) *Builder {
+	g := &Gateway{BaseElement: b.base(KindInclusiveGateway, id)}
+	b.process.InclusiveGateways = append(b.process.InclusiveGateways, g)
+
+	return b.add(g)
+}
+
+// EventBasedGateway adds an event based gateway.
+func (b *Builder) EventBasedGateway(id ...string) *Builder {
+	g := &Gateway{BaseElement: b.base(KindEventBasedGateway, id)}
+	b.process.EventBasedGateways = append(b.process.EventBasedGateways, g)
+
+	return b.add(g)
+}
+
+// Assignee sets the assignee of the user task added last.
+func (b *Builder) Assignee(assignee string) *Builder {
+	if t := b.task("Assignee", KindUserTask); t != nil {
+		t.Assignee = assignee
+	}
+
+	return b
+}
+
+// CandidateUsers sets the candidate users of the user task added last.
+func (b *Builder) CandidateUsers(users ...string) *Builder {
+	if t := b.task("CandidateUsers", KindUserTask); t != nil {
+		t.CandidateUsers = strings.Join(users, ",")
+	}
+
+	return b
+}
+
+// CandidateGroups sets the candidate groups of the user task added last.
+func (b *Builder) CandidateGroups(groups ...string) *Builder {
+	if t := b.task("CandidateGroups", KindUserTask); t != nil {
+		t.CandidateGroups = strings.Join(groups, ",")
+	}
+
+	return b
+}
+
+// FormKey sets the form key of the user task added last.
+func (b *Builder) FormKey(key string) *Builder {
+	if t := b.task("FormKey", KindUserTask); t != nil {
+		t.FormKey = key
+	}
+
+	return b
+}
+
+// DueDate sets the due date expression of the user task added last.
+func (b *Builder) DueDate(date string) *Builder {
+	if t := b.task("DueDate", KindUserTask); t != nil {
+		t.DueDate = date
+	}
+
+	return b
+}
+
+// Topic implements the service, send or business rule task added last as external task with the
+// given topic.
+func (b *Builder) Topic(topic string) *Builder {
+	if t := b.task("Topic", KindServiceTask, KindSendTask, KindBusinessRuleTask); t != nil {
+		t.Type, t.Topic, t.DecisionRef = "external", topic, ""
+	}
+
+	return b
+}
+
+// Class implements the service or send task added last with the given java class.
+func (b *Builder) Class(class string) *Builder {
+	if t := b.task("Class", KindServiceTask, KindSendTask); t != nil {
+		t.Class = class
+	}
+
+	return b
+}
+
+// Expression implements the service or send task added last with the given expression.
+func (b *Builder) Expression(expression string) *Builder {
+	if t := b.task("Expression", KindServiceTask, KindSendTask); t != nil {
+		t.Expression = expression
+	}
+
+	return b
+}
+
+// DelegateExpression implements the service or send task added last with the delegate the given
+// expression resolves to.
+func (b *Builder) DelegateExpression(expression string) *Builder {
+	if t := b.task("DelegateExpression", KindServiceTask, KindSendTask); t != nil {
+		t.DelegateExpression = expression
+	}
+
+	return b
+}
+
+// ResultVariable sets the variable the result of the task added last is stored in.
+func (b *Builder) ResultVariable(name string) *Builder {
+	if t := b.task("ResultVariable", KindServiceTask, KindSendTask, KindScriptTask, KindBusinessRuleTask); t != nil {
+		t.ResultVariable = name
+	}
+
+	return b
+}
+
+// Message sets the message of the receive task or the event added last. The message is added to
+// the definitions when no message with the name exists yet.
+func (b *Builder) Message(name string) *Builder {
+	var ref string
+
+	for _, v := range b.definitions.Messages {
+		if v.Name == name {
+			ref = v.Id
+		}
+	}
+
+	if ref == "" {
+		ref = fmt.Sprintf("message_%d", len(b.definitions.Messages)+1)
+		b.definitions.Messages = append(b.definitions.Messages, &Message{Id: ref, Name: name})
+	}
+
+	switch e := b.current.(type) {
+	case *Task:
+		if e.Kind == KindReceiveTask || e.Kind == KindSendTask {
+			e.MessageRef = ref
+			return b
+		}
+	case *Event:
+		e.Message = &MessageEventDefinition{MessageRef: ref}
+		return b
+	}
+
+	return b.fail("Message")
+}
+
+// Timer sets a timer event definition firing after the given ISO 8601 duration on the event added
+// last.
+func (b *Builder) Timer(duration string) *Builder {
+	if e, ok := b.current.(*Event); ok {
+		e.Timer = &TimerEventDefinition{TimeDuration: &Expression{Type: "bpmn:tFormalExpression", Text: duration}}
+		return b
+	}
+
+	return b.fail("Timer")
+}
+
+// AsyncBefore lets the engine continue asynchronously before the element added last.
+func (b *Builder) AsyncBefore() *Builder {
+	if b.current == nil {
+		return b.fail("AsyncBefore")
+	}
+
+	b.current.Base().AsyncBefore = true
+
+	return b
+}
+
+// AsyncAfter lets the engine continue asynchronously after the element added last.
+func (b *Builder) AsyncAfter() *Builder {
+	if b.current == nil {
+		return b.fail("AsyncAfter")
+	}
+
+	b.current.Base().AsyncAfter = true
+
+	return b
+}
+
+// InputParameter adds an input parameter to the element added last.
+func (b *Builder) InputParameter(name, value string) *Builder {
+	if io := b.inputOutput("InputParameter"); io != nil {
+		io.InputParameters = append(io.InputParameters, &Parameter{Name: name, Value: value})
+	}
+
+	return b
+}
+
+// OutputParameter adds an output parameter to the element added last.
+func (b *Builder) OutputParameter(name, value string) *Builder {
+	if io := b.inputOutput("OutputParameter"); io != nil {
+		io.OutputParameters = append(io.OutputParameters, &Parameter{Name: name, Value: value})
+	}
+
+	return b
+}
+
+// Property adds a property to the element added last, or to the process when no element was added
+// yet.
+func (b *Builder) Property(name, value string) *Builder {
+	extensions := &b.process.Extensions
+
+	if b.current != nil {
+		extensions = &b.current.Base().Extensions
+	}
+
+	if *extensions == nil {
+		*extensions = &ExtensionElements{}
+	}
+
+	if (*extensions).Properties == nil {
+		(*extensions).Properties = &Properties{}
+	}
+
+	properties := (*extensions).Properties
+	properties.Properties = append(properties.Properties, &Property{Name: name, Value: value})
+
+	return b
+}
+
+// Condition sets the condition of the sequence flow leaving the element added last to the next
+// element, e.g. ${approved}.
+func (b *Builder) Condition(expression string) *Builder {
+	b.condition = expression
+	return b
+}
+
+// Default makes the sequence flow leaving the gateway added last to the next element the default
+// flow of the gateway.
+func (b *Builder) Default() *Builder {
+	if _, ok := b.current.(*Gateway); !ok {
+		return b.fail("Default")
+	}
+
+	b.isDefault = true
+
+	return b
+}
+
+// MoveTo continues building at the element with the given id, e.g. to add another branch to a
+// gateway.
+func (b *Builder) MoveTo(id string) *Builder {
+	if err := b.reindex(); err != nil {
+		b.err = err
+		return b
+	}
+
+	if b.current = b.process.Element(id); b.current == nil && b.err == nil {
+		b.err = fmt.Errorf("bpmn: MoveTo: no element %s", id)
+	}
+
+	return b
+}
+
+// ConnectTo connects the element added last to the existing element with the given id, e.g. to join
+// branches or to loop back, and continues building at that element.
+func (b *Builder) ConnectTo(id string) *Builder {
+	if err := b.reindex(); err != nil {
+		b.err = err
+		return b
+	}
+
+	target := b.process.Element(id)
+
+	if target == nil {
+		if b.err == nil {
+			b.err = fmt.Errorf("bpmn: ConnectTo: no element %s", id)
+		}
+
+		return b
+	}
+
+	return b.connect(target)
+}
+
+// Build returns the definitions with the process.
+func (b *Builder) Build() (*Definitions, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	if err := b.reindex(); err != nil {
+		return nil, err
+	}
+
+	return b.definitions, nil
+}
+
+// XML returns the BPMN XML of the process with a generated diagram interchange layout, ready to be
+// deployed.
+func (b *Builder) XML() (string, error) {
+	definitions, err := b.Build()
+
+	if err != nil {
+		return "", err
+	}
+
+	content, err := Marshal(definitions)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
+
+// base returns the base element of a new element, with the given or a generated id.
+func (b *Builder) base(kind string, id []string) BaseElement {
+	result := BaseElement{Kind: kind}
+
+	if len(id) > 0 && id[0] != "" {
+		result.Id = id[0]
+	} else {
+		b.counts[kind]++
+		result.Id = fmt.Sprintf("%s_%d", kind, b.counts[kind])
+	}
+
+	return result
+}
+
+// add connects the element to the element added last and continues building at it.
+func (b *Builder) add(n FlowNode) *Builder {
+	b.order[n.Base().Id] = len(b.order)
+
+	if b.current == nil {
+		b.current = n
+		return b
+	}
+
+	return b.connect(n)
+}
+
+// connect adds a sequence flow from the element added last to the given element.
+func (b *Builder) connect(target FlowNode) *Builder {
+	source := b.current
+
+	if source == nil {
+		return b.fail("ConnectTo")
+	}
+
+	if source.Base().Kind == KindEndEvent {
+		if b.err == nil {
+			b.err = fmt.Errorf("bpmn: end event %s can not be connected to %s", source.Base().Id, target.Base().Id)
+		}
+
+		return b
+	}
+
+	b.counts["flow"]++
+
+	f := &SequenceFlow{
+		Id:        fmt.Sprintf("flow_%d", b.counts["flow"]),
+		SourceRef: source.Base().Id,
+		TargetRef: target.Base().Id,
+	}
+
+	if b.condition != "" {
+		f.ConditionExpression = &Expression{Type: "bpmn:tFormalExpression", Text: b.condition}
+	}
+
+	if g, ok := source.(*Gateway); ok && b.isDefault {
+		g.Default = f.Id
+	}
+
+	source.Base().Outgoing = append(source.Base().Outgoing, f.Id)
+	target.Base().Incoming = append(target.Base().Incoming, f.Id)
+
+	b.process.SequenceFlows = append(b.process.SequenceFlows, f)
+	b.order[f.Id] = len(b.order)
+
+	b.current, b.condition, b.isDefault = target, "", false
+
+	return b
+}
+
+// task returns the task added last when it is of one of the given kinds, or records an error for
+// the setter.
+func (b *Builder) task(setter string, kinds ...string) *Task {
+	if t, ok := b.current.(*Task); ok {
+		for _, v := range kinds {
+			if t.Kind == v {
+				return t
+			}
+		}
+	}
+
+	b.fail(setter)
+
+	return nil
+}
+
+// inputOutput returns the input and output mappings of the element added last, or records an error
+// for the setter.
+func (b *Builder) inputOutput(setter string) *InputOutput {
+	if b.current == nil {
+		b.fail(setter)
+		return nil
+	}
+
+	base := b.current.Base()
+
+	if base.Extensions == nil {
+		base.Extensions = &ExtensionElements{}
+	}
+
+	if base.Extensions.InputOutput == nil {
+		base.Extensions.InputOutput = &InputOutput{}
+	}
+
+	return base.Extensions.InputOutput
+}
+
+// fail records that the setter does not apply to the element added last.
+func (b *Builder) fail(setter string) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	if b.current == nil {
+		b.err = fmt.Errorf("bpmn: %s: no element added yet", setter)
+	} else {
+		b.err = fmt.Errorf("bpmn: %s does not apply to %s %s", setter, b.current.Base().Kind, b.current.Base().Id)
+	}
+
+	return b
+}
+
+// reindex indexes the process in the order the elements were added.
+func (b *Builder) reindex() error {
+	return b.process.build(b.order)
+}
@@ -0,0 +1,169 @@
+package bpmn
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilderRoundTrip(t *testing.T) {
+	content, err := NewProcess("approval").Name("Approval").HistoryTimeToLive("P30D").
+		StartEvent().
+		UserTask("review").CandidateGroups("accounting").Property("priority", "high").
+		ExclusiveGateway("approved").
+		Condition("${approved}").ServiceTask("pay").Topic("payment").AsyncBefore().
+		IntermediateCatchEvent("wait").Timer("PT1H").
+		EndEvent("paid").
+		MoveTo("approved").Default().ReceiveTask("correct").Message("Corrected").ConnectTo("review").
+		MoveTo("approved").Condition("${cancelled}").EndEvent("rejected").
+		XML()
+
+	require.NoError(t, err)
+
+	definitions, err := Parse(content)
+
+	require.NoError(t, err)
+
+	p := definitions.Process("approval")
+
+	require.Equal(t, "Approval", p.Name)
+	require.Equal(t, "P30D", p.HistoryTimeToLive)
+	require.True(t, p.Executable())
+
+	require.ElementsMatch(t, []string{"startEvent_1", "review", "approved", "pay", "wait", "paid", "correct", "rejected"}, ids(p.Elements()))
+
+	review := p.Element("review").(*Task)
+
+	require.Equal(t, []string{"accounting"}, review.CandidateGroupList())
+	require.Equal(t, "high", review.Property("priority"))
+	require.Equal(t, []string{"startEvent_1", "correct"}, ids(p.Predecessors("review")))
+
+	pay := p.Element("pay").(*Task)
+
+	require.True(t, pay.External())
+	require.True(t, pay.AsyncBefore)
+	require.Equal(t, "payment", pay.Topic)
+	require.Equal(t, "PT1H", p.Element("wait").(*Event).Timer.TimeDuration.Text)
+
+	outgoing := p.Outgoing("approved")
+
+	require.Len(t, outgoing, 3)
+	require.Equal(t, "${approved}", outgoing[0].Condition())
+	require.Equal(t, "", outgoing[1].Condition())
+	require.Equal(t, "${cancelled}", outgoing[2].Condition())
+	require.Equal(t, outgoing[1].Id, p.Element("approved").(*Gateway).Default)
+	require.Equal(t, "correct", outgoing[1].TargetRef)
+
+	correct := p.Element("correct").(*Task)
+
+	require.Equal(t, "Corrected", definitions.Message(correct.MessageRef).Name)
+
+	// each element and sequence flow has a shape or an edge.
+	for _, v := range p.Elements() {
+		require.Contains(t, content, `bpmnElement="`+v.Base().Id+`"`)
+	}
+
+	for _, v := range []string{"flow_1", "flow_8"} {
+		require.Contains(t, content, `<bpmndi:BPMNEdge id="`+v+`_di" bpmnElement="`+v+`">`)
+	}
+
+	// the parsed definitions encode to XML which parses to the same definitions.
+	encoded, err := Marshal(definitions)
+
+	require.NoError(t, err)
+
+	reparsed, err := Parse(string(encoded))
+
+	require.NoError(t, err)
+	require.Equal(t, definitions, reparsed)
+}
+
+func TestBuilderDocExample(t *testing.T) {
+	content, err := NewProcess("approval").HistoryTimeToLive("P30D").
+		StartEvent().
+		UserTask("review").CandidateGroups("accounting").
+		ExclusiveGateway("approved").
+		Condition("${approved}").ServiceTask("pay").Topic("payment").EndEvent("paid").
+		MoveTo("approved").Default().EndEvent("rejected").
+		XML()
+
+	require.NoError(t, err)
+
+	report, err := Lint("approval.bpmn", content, nil)
+
+	require.NoError(t, err)
+	require.Empty(t, report.Findings)
+}
+
+func TestBuilderErrors(t *testing.T) {
+	tests := map[string]struct {
+		builder *Builder
+		message string
+	}{
+		"move to unknown element": {
+			NewProcess("p").StartEvent().MoveTo("unknown").EndEvent(),
+			"bpmn: MoveTo: no element unknown",
+		},
+		"connect to unknown element": {
+			NewProcess("p").StartEvent().ConnectTo("unknown"),
+			"bpmn: ConnectTo: no element unknown",
+		},
+		"connect without element": {
+			NewProcess("p").ConnectTo("unknown"),
+			"bpmn: ConnectTo: no element unknown",
+		},
+		"connect end event": {
+			NewProcess("p").StartEvent("start").EndEvent("end").ConnectTo("start"),
+			"bpmn: end event end can not be connected to start",
+		},
+		"default of task": {
+			NewProcess("p").StartEvent().UserTask("review").Default().EndEvent(),
+			"bpmn: Default does not apply to userTask review",
+		},
+		"default without element": {
+			NewProcess("p").Default().StartEvent(),
+			"bpmn: Default: no element added yet",
+		},
+		"topic of user task": {
+			NewProcess("p").StartEvent().UserTask("review").Topic("payment"),
+			"bpmn: Topic does not apply to userTask review",
+		},
+		"timer of task": {
+			NewProcess("p").StartEvent().ServiceTask("pay").Timer("PT1H"),
+			"bpmn: Timer does not apply to serviceTask pay",
+		},
+		"duplicate id": {
+			NewProcess("p").StartEvent("start").UserTask("review").UserTask("review"),
+			`bpmn: duplicate or missing userTask id "review"`,
+		},
+		"first error is kept": {
+			NewProcess("p").StartEvent().MoveTo("first").MoveTo("second"),
+			"bpmn: MoveTo: no element first",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := test.builder.Build()
+
+			require.EqualError(t, err, test.message)
+
+			_, err = test.builder.XML()
+
+			require.EqualError(t, err, test.message)
+		})
+	}
+}
+
+func TestBuilderGeneratesIds(t *testing.T) {
+	definitions, err := NewProcess("p").StartEvent().UserTask().UserTask().EndEvent().Build()
+
+	require.NoError(t, err)
+
+	p := definitions.Process("p")
+
+	require.Equal(t, []string{"startEvent_1", "userTask_1", "userTask_2", "endEvent_1"}, ids(p.Elements()))
+	require.Equal(t, []string{"flow_1", "flow_2", "flow_3"}, flows(p.sequenceFlows()))
+	require.True(t, strings.HasPrefix(definitions.Id, "definitions_"))
+}
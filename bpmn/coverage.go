@@ -0,0 +1,170 @@
+package bpmn
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	camunda "github.com/equipmegmbh/camunda-go"
+)
+
+// TopicCoverage compares the external task topics of process models with the topics handled by a
+// worker.
+type TopicCoverage struct {
+	// The topics of the process models no handler is registered for.
+	Unhandled []*TopicUsage `json:"unhandled"`
+
+	// The topics of the process models a handler is registered for.
+	Handled []*TopicUsage `json:"handled"`
+
+	// The topics handlers are registered for which no process model uses.
+	Unused []string `json:"unused"`
+}
+
+// TopicUsage lists where an external task topic is used.
+type TopicUsage struct {
+	// The topic.
+	Topic string `json:"topic,omitempty"`
+
+	// The names of the process models using the topic, e.g. resource names or process definition ids.
+	Definitions []string `json:"definitions,omitempty"`
+
+	// The ids of the activities using the topic.
+	ActivityIds []string `json:"activityIds,omitempty"`
+}
+
+// CheckTopics compares the external task topics of the given process models, keyed by a name used
+// in the report, with the topics handled by a worker.
+func CheckTopics(definitions map[string]*Definitions, topics []string) *TopicCoverage {
+	usages := make(map[string]*TopicUsage)
+
+	names := make([]string, 0, len(definitions))
+
+	for k := range definitions {
+		names = append(names, k)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, p := range definitions[name].Processes {
+			for _, v := range p.Elements() {
+				topic, _ := Topic(v)
+
+				if topic == "" {
+					continue
+				}
+
+				usage, ok := usages[topic]
+
+				if !ok {
+					usage = &TopicUsage{Topic: topic, Definitions: make([]string, 0), ActivityIds: make([]string, 0)}
+					usages[topic] = usage
+				}
+
+				if !contains(usage.Definitions, name) {
+					usage.Definitions = append(usage.Definitions, name)
+				}
+
+				if !contains(usage.ActivityIds, v.Base().Id) {
+					usage.ActivityIds = append(usage.ActivityIds, v.Base().Id)
+				}
+			}
+		}
+	}
+
+	handled := make(map[string]bool)
+
+	for _, v := range topics {
+		handled[v] = true
+	}
+
+	result := &TopicCoverage{Unhandled: make([]*TopicUsage, 0), Handled: make([]*TopicUsage, 0), Unused: make([]string, 0)}
+
+	for _, v := range usages {
+		if handled[v.Topic] {
+			result.Handled = append(result.Handled, v)
+		} else {
+			result.Unhandled = append(result.Unhandled, v)
+		}
+	}
+
+	for _, v := range distinct(topics) {
+		if _, ok := usages[v]; !ok {
+			result.Unused = append(result.Unused, v)
+		}
+	}
+
+	sort.Slice(result.Unhandled, func(i, j int) bool { return result.Unhandled[i].Topic < result.Unhandled[j].Topic })
+	sort.Slice(result.Handled, func(i, j int) bool { return result.Handled[i].Topic < result.Handled[j].Topic })
+
+	return result
+}
+
+// CheckDeployedTopics compares the external task topics of the active, i.e. not suspended, process
+// definitions of the tenant with the topics handled by a worker. All versions are checked, as
+// instances of older versions may still be running. The process definition ids are used as names
+// in the report.
+func CheckDeployedTopics(ctx context.Context, tenantId string, topics []string) (*TopicCoverage, error) {
+	processDefinitions, err := camunda.GetProcessDefinitions(ctx, tenantId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := make(map[string]*Definitions)
+
+	for _, v := range processDefinitions {
+		if v.Suspended {
+			continue
+		}
+
+		source, err := camunda.GetProcessDefinitionXML(ctx, v.Id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		d, err := Parse(source.Content)
+
+		if err != nil {
+			return nil, fmt.Errorf("process definition %s: %w", v.Id, err)
+		}
+
+		// the resource may contain further processes, which are definitions of their own.
+		if p := d.Process(v.Key); p != nil {
+			d.Processes = []*Process{p}
+		}
+
+		definitions[v.Id] = d
+	}
+
+	return CheckTopics(definitions, topics), nil
+}
+
+// Err returns an error listing the topics no handler is registered for, or nil. Unused handlers are
+// not considered an error.
+func (c *TopicCoverage) Err() error {
+	if len(c.Unhandled) == 0 {
+		return nil
+	}
+
+	topics := make([]string, 0, len(c.Unhandled))
+
+	for _, v := range c.Unhandled {
+		topics = append(topics, fmt.Sprintf("%s (%s)", v.Topic, strings.Join(v.Definitions, ", ")))
+	}
+
+	return fmt.Errorf("no handler for external task topics %s", strings.Join(topics, ", "))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,136 @@
+package bpmn
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	camunda "github.com/equipmegmbh/camunda-go"
+	"github.com/equipmegmbh/camunda-go/camundatest"
+	"github.com/stretchr/testify/require"
+)
+
+// invoice builds a process with an external service task and a message throw event implemented as
+// external task with the given topics.
+func invoice(t *testing.T, id, task, event string) *Definitions {
+	definitions, err := NewProcess(id).HistoryTimeToLive("P30D").
+		StartEvent().
+		ServiceTask("scan").Topic(task).
+		IntermediateThrowEvent("notified").Message("Notified").
+		ServiceTask("archive").Class("org.example.Archive").
+		EndEvent().
+		Build()
+
+	require.NoError(t, err)
+
+	e := definitions.Process(id).Element("notified").(*Event)
+	e.Message.Type, e.Message.Topic = "external", event
+
+	return definitions
+}
+
+func TestTopic(t *testing.T) {
+	definitions := invoice(t, "invoice", "scan", "notify")
+	p := definitions.Process("invoice")
+
+	topic, ok := Topic(p.Element("scan"))
+
+	require.True(t, ok)
+	require.Equal(t, "scan", topic)
+
+	topic, ok = Topic(p.Element("notified"))
+
+	require.True(t, ok)
+	require.Equal(t, "notify", topic)
+
+	_, ok = Topic(p.Element("archive"))
+
+	require.False(t, ok)
+
+	// an external task without a topic is still external.
+	topic, ok = Topic(&Task{BaseElement: BaseElement{Kind: KindServiceTask}, Type: "external"})
+
+	require.True(t, ok)
+	require.Empty(t, topic)
+
+	require.Equal(t, []string{"notify", "scan"}, p.ExternalTaskTopics())
+	require.Equal(t, []string{"notify", "scan"}, definitions.ExternalTaskTopics())
+}
+
+func TestCheckTopics(t *testing.T) {
+	definitions := map[string]*Definitions{
+		"invoice.bpmn": invoice(t, "invoice", "scan", "notify"),
+		"order.bpmn":   invoice(t, "order", "scan", "ship"),
+	}
+
+	coverage := CheckTopics(definitions, []string{"scan", "notify", "unused", "scan"})
+
+	require.Equal(t, []*TopicUsage{
+		{Topic: "notify", Definitions: []string{"invoice.bpmn"}, ActivityIds: []string{"notified"}},
+		{Topic: "scan", Definitions: []string{"invoice.bpmn", "order.bpmn"}, ActivityIds: []string{"scan"}},
+	}, coverage.Handled)
+	require.Equal(t, []*TopicUsage{
+		{Topic: "ship", Definitions: []string{"order.bpmn"}, ActivityIds: []string{"notified"}},
+	}, coverage.Unhandled)
+	require.Equal(t, []string{"unused"}, coverage.Unused)
+	require.EqualError(t, coverage.Err(), "no handler for external task topics ship (order.bpmn)")
+
+	coverage = CheckTopics(definitions, []string{"scan", "notify", "ship"})
+
+	require.NoError(t, coverage.Err())
+}
+
+func TestCheckDeployedTopics(t *testing.T) {
+	ctx := context.Background()
+
+	s := camundatest.NewServer()
+	defer s.Close()
+
+	s.Configure()
+
+	deploy := func(tenantId string, definitions *Definitions) {
+		content, err := Marshal(definitions)
+
+		require.NoError(t, err)
+
+		_, err = camunda.CreateDeployment(ctx, tenantId, "invoice", "invoice.bpmn", strings.NewReader(string(content)))
+
+		require.NoError(t, err)
+	}
+
+	// the first version is checked too, as its instances may still be running.
+	deploy("acme", invoice(t, "invoice", "scan", "notify"))
+	deploy("acme", invoice(t, "invoice", "scan", "remind"))
+	deploy("acme", invoice(t, "legacy", "fax", "mail"))
+	deploy("other", invoice(t, "order", "ship", "notify"))
+
+	definitions, err := camunda.GetProcessDefinitions(ctx, "acme")
+
+	require.NoError(t, err)
+	require.Len(t, definitions, 3)
+
+	for _, v := range definitions {
+		if v.Key == "legacy" {
+			require.NoError(t, camunda.SuspendProcessDefinition(ctx, v.Id, "", false))
+		}
+	}
+
+	coverage, err := CheckDeployedTopics(ctx, "acme", []string{"scan", "notify"})
+
+	require.NoError(t, err)
+	require.Equal(t, []*TopicUsage{
+		{Topic: "notify", Definitions: []string{"invoice:1:deployment-1"}, ActivityIds: []string{"notified"}},
+		{Topic: "scan", Definitions: []string{"invoice:1:deployment-1", "invoice:2:deployment-2"}, ActivityIds: []string{"scan"}},
+	}, coverage.Handled)
+	require.Equal(t, []*TopicUsage{
+		{Topic: "remind", Definitions: []string{"invoice:2:deployment-2"}, ActivityIds: []string{"notified"}},
+	}, coverage.Unhandled)
+	require.Empty(t, coverage.Unused)
+
+	s.Fail(http.MethodGet, "/process-definition/{}/xml", http.StatusInternalServerError, "database unavailable")
+
+	_, err = CheckDeployedTopics(ctx, "acme", []string{"scan"})
+
+	require.EqualError(t, err, "type RestException, message: database unavailable")
+}
@@ -0,0 +1,328 @@
+package bpmn
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// prefixes are the prefixes the namespaces are written with.
+var prefixes = map[string]string{
+	"":               "bpmn",
+	NamespaceBPMN:    "bpmn",
+	NamespaceCamunda: "camunda",
+	NamespaceXSI:     "xsi",
+}
+
+// encoder writes indented BPMN XML.
+type encoder struct {
+	buf   bytes.Buffer
+	depth int
+}
+
+// Marshal returns the BPMN XML of the definitions, with a generated diagram interchange layout for
+// each process.
+func Marshal(definitions *Definitions) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := Encode(&buf, definitions); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Encode writes the BPMN XML of the definitions, with a generated diagram interchange layout for
+// each process. A diagram interchange of parsed definitions is not retained.
+func Encode(w io.Writer, definitions *Definitions) error {
+	e := &encoder{}
+
+	for _, v := range definitions.Processes {
+		if err := v.reindex(); err != nil {
+			return err
+		}
+	}
+
+	e.buf.WriteString(xml.Header)
+
+	e.open("bpmn:definitions", [][2]string{
+		{"xmlns:bpmn", NamespaceBPMN},
+		{"xmlns:bpmndi", NamespaceBPMNDI},
+		{"xmlns:dc", NamespaceDC},
+		{"xmlns:di", NamespaceDI},
+		{"xmlns:camunda", NamespaceCamunda},
+		{"xmlns:xsi", NamespaceXSI},
+		{"id", definitions.Id},
+		{"name", definitions.Name},
+		{"targetNamespace", definitions.TargetNamespace},
+	})
+
+	e.children(reflect.ValueOf(definitions).Elem())
+
+	for _, v := range definitions.Processes {
+		e.diagram(v)
+	}
+
+	e.close("bpmn:definitions")
+
+	_, err := w.Write(e.buf.Bytes())
+
+	return err
+}
+
+// element writes the element of a struct, a pointer to a struct or a string.
+func (e *encoder) element(name string, v reflect.Value) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() == reflect.String {
+		e.indent()
+		fmt.Fprintf(&e.buf, "<%s>%s</%s>\n", name, escape(v.String()), name)
+		return
+	}
+
+	attrs, text := e.attributes(v)
+
+	if text = strings.TrimSpace(text); text != "" {
+		e.indent()
+		e.buf.WriteString("<" + name + join(attrs) + ">" + escape(text) + "</" + name + ">\n")
+		return
+	}
+
+	var children encoder
+	children.depth = e.depth + 1
+	children.children(v)
+
+	if children.buf.Len() == 0 {
+		e.indent()
+		e.buf.WriteString("<" + name + join(attrs) + " />\n")
+		return
+	}
+
+	e.open(name, attrs)
+	e.buf.Write(children.buf.Bytes())
+	e.close(name)
+}
+
+// attributes returns the attributes and the character data of a struct.
+func (e *encoder) attributes(v reflect.Value) ([][2]string, string) {
+	attrs := make([][2]string, 0)
+	text := ""
+
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		f, value := t.Field(i), v.Field(i)
+
+		if f.Anonymous {
+			a, s := e.attributes(value)
+			attrs = append(attrs, a...)
+			text += s
+			continue
+		}
+
+		name, flags, ok := tag(f)
+
+		if !ok {
+			continue
+		}
+
+		switch {
+		case flags == "attr":
+			if s, ok := format(value); ok {
+				attrs = append(attrs, [2]string{name, s})
+			}
+		case flags == "chardata":
+			text += value.String()
+		}
+	}
+
+	return attrs, text
+}
+
+// children writes the child elements of a struct.
+func (e *encoder) children(v reflect.Value) {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		f, value := t.Field(i), v.Field(i)
+
+		if f.Anonymous {
+			e.children(value)
+			continue
+		}
+
+		name, flags, ok := tag(f)
+
+		if !ok || flags != "" {
+			continue
+		}
+
+		path := strings.Split(name, ">")
+
+		if value.Kind() == reflect.Slice && value.Len() == 0 || value.Kind() == reflect.String && value.String() == "" {
+			continue
+		}
+
+		for _, v := range path[:len(path)-1] {
+			e.open(v, nil)
+		}
+
+		if value.Kind() == reflect.Slice {
+			for j := 0; j < value.Len(); j++ {
+				e.element(path[len(path)-1], value.Index(j))
+			}
+		} else {
+			e.element(path[len(path)-1], value)
+		}
+
+		for j := len(path) - 2; j >= 0; j-- {
+			e.close(path[j])
+		}
+	}
+}
+
+func (e *encoder) open(name string, attrs [][2]string) {
+	e.indent()
+	e.buf.WriteString("<" + name + join(attrs) + ">\n")
+	e.depth++
+}
+
+func (e *encoder) close(name string) {
+	e.depth--
+	e.indent()
+	e.buf.WriteString("</" + name + ">\n")
+}
+
+func (e *encoder) indent() {
+	e.buf.WriteString(strings.Repeat("  ", e.depth))
+}
+
+// diagram writes the diagram interchange of a process.
+func (e *encoder) diagram(p *Process) {
+	shapes, edges := layout(p)
+
+	e.open("bpmndi:BPMNDiagram", [][2]string{{"id", "BPMNDiagram_" + p.Id}})
+	e.open("bpmndi:BPMNPlane", [][2]string{{"id", "BPMNPlane_" + p.Id}, {"bpmnElement", p.Id}})
+
+	for _, v := range p.Elements() {
+		b := shapes[v.Base().Id]
+
+		attrs := [][2]string{{"id", v.Base().Id + "_di"}, {"bpmnElement", v.Base().Id}}
+
+		switch v.Base().Kind {
+		case KindExclusiveGateway:
+			attrs = append(attrs, [2]string{"isMarkerVisible", "true"})
+		case KindSubProcess:
+			attrs = append(attrs, [2]string{"isExpanded", "true"})
+		}
+
+		e.open("bpmndi:BPMNShape", attrs)
+		e.indent()
+		fmt.Fprintf(&e.buf, "<dc:Bounds x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" />\n", b.x, b.y, b.width, b.height)
+		e.close("bpmndi:BPMNShape")
+	}
+
+	for _, v := range p.sequenceFlows() {
+		e.open("bpmndi:BPMNEdge", [][2]string{{"id", v.Id + "_di"}, {"bpmnElement", v.Id}})
+
+		for _, w := range edges[v.Id] {
+			e.indent()
+			fmt.Fprintf(&e.buf, "<di:waypoint x=\"%d\" y=\"%d\" />\n", w.x, w.y)
+		}
+
+		e.close("bpmndi:BPMNEdge")
+	}
+
+	e.close("bpmndi:BPMNPlane")
+	e.close("bpmndi:BPMNDiagram")
+}
+
+// tag returns the prefixed name and the flags of the xml tag of a field, or false when the field is
+// not encoded.
+func tag(f reflect.StructField) (string, string, bool) {
+	value := f.Tag.Get("xml")
+
+	if value == "" || value == "-" || f.PkgPath != "" || f.Name == "XMLName" {
+		return "", "", false
+	}
+
+	parts := strings.Split(value, ",")
+	name, flags := parts[0], ""
+
+	for _, v := range parts[1:] {
+		if v == "attr" || v == "chardata" {
+			flags = v
+		}
+	}
+
+	if flags == "chardata" {
+		return "", flags, true
+	}
+
+	space := ""
+
+	if i := strings.Index(name, " "); i >= 0 {
+		space, name = name[:i], name[i+1:]
+	}
+
+	prefix := prefixes[space]
+
+	if flags == "attr" && space == "" {
+		return name, flags, true
+	}
+
+	names := strings.Split(name, ">")
+
+	for i := range names {
+		names[i] = prefix + ":" + names[i]
+	}
+
+	return strings.Join(names, ">"), flags, true
+}
+
+// format returns the text of an attribute value, or false when the attribute is omitted.
+func format(v reflect.Value) (string, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), v.String() != ""
+	case reflect.Bool:
+		return "true", v.Bool()
+	case reflect.Ptr:
+		if v.IsNil() {
+			return "", false
+		}
+
+		return fmt.Sprint(v.Elem().Interface()), true
+	default:
+		return fmt.Sprint(v.Interface()), true
+	}
+}
+
+func join(attrs [][2]string) string {
+	var b strings.Builder
+
+	for _, v := range attrs {
+		if v[1] != "" {
+			b.WriteString(" " + v[0] + "=\"" + escape(v[1]) + "\"")
+		}
+	}
+
+	return b.String()
+}
+
+func escape(s string) string {
+	var buf bytes.Buffer
+
+	_ = xml.EscapeText(&buf, []byte(s))
+
+	return buf.String()
+}
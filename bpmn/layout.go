@@ -0,0 +1,299 @@
+package bpmn
+
+// The spacing of the generated layout.
+const (
+	originX  = 150
+	originY  = 80
+	spacingX = 50
+	spacingY = 40
+	padding  = 30
+)
+
+// bounds are the bounds of a shape.
+type bounds struct {
+	x, y, width, height int
+}
+
+func (b *bounds) centerX() int {
+	return b.x + b.width/2
+}
+
+func (b *bounds) centerY() int {
+	return b.y + b.height/2
+}
+
+func (b *bounds) right() int {
+	return b.x + b.width
+}
+
+func (b *bounds) bottom() int {
+	return b.y + b.height
+}
+
+// point is a waypoint of an edge.
+type point struct {
+	x, y int
+}
+
+// layout returns the bounds of the flow nodes and the waypoints of the sequence flows of a process.
+// The flow nodes are placed left to right in columns by their distance from the start, branches
+// are placed in rows below.
+func layout(p *Process) (map[string]*bounds, map[string][]point) {
+	shapes := make(map[string]*bounds)
+
+	_, _, ids := place(p, "", shapes)
+
+	for _, v := range ids {
+		shapes[v].x += originX
+		shapes[v].y += originY
+	}
+
+	for _, v := range p.Elements() {
+		base := v.Base()
+
+		if base.Kind == KindBoundaryEvent || shapes[base.Id] == nil {
+			continue
+		}
+
+		a := shapes[base.Id]
+
+		for i, e := range p.Boundary(base.Id) {
+			shapes[e.Id] = &bounds{x: a.right() - 46 - 44*i, y: a.bottom() - 18, width: 36, height: 36}
+		}
+	}
+
+	edges := make(map[string][]point)
+
+	for _, v := range p.sequenceFlows() {
+		edges[v.Id] = route(p, v, shapes)
+	}
+
+	return shapes, edges
+}
+
+// place lays out the flow nodes of the process or of the sub process with the given id relative to
+// the origin. It returns the width and the height of the layout and the ids of the placed nodes,
+// including those of nested sub processes.
+func place(p *Process, scope string, shapes map[string]*bounds) (int, int, []string) {
+	nodes := make([]FlowNode, 0)
+
+	for _, v := range p.Elements() {
+		if v.Base().Parent == scope && v.Base().Kind != KindBoundaryEvent {
+			nodes = append(nodes, v)
+		}
+	}
+
+	// successors follows the outgoing flows of a node and of the boundary events attached to it.
+	successors := func(id string) []string {
+		result := make([]string, 0)
+
+		flows := p.Outgoing(id)
+
+		for _, v := range p.Boundary(id) {
+			flows = append(flows, p.Outgoing(v.Id)...)
+		}
+
+		for _, v := range flows {
+			if t := p.Element(v.TargetRef); t != nil && t.Base().Parent == scope {
+				result = append(result, v.TargetRef)
+			}
+		}
+
+		return result
+	}
+
+	// visit the nodes depth first from the nodes without incoming flows to find the back edges and
+	// the tree parents.
+	predecessors := make(map[string]int)
+
+	for _, v := range nodes {
+		for _, w := range successors(v.Base().Id) {
+			predecessors[w]++
+		}
+	}
+
+	visited := make(map[string]bool)
+	active := make(map[string]bool)
+	back := make(map[[2]string]bool)
+	parent := make(map[string]string)
+	preorder := make([]string, 0, len(nodes))
+	postorder := make([]string, 0, len(nodes))
+
+	var visit func(id string)
+
+	visit = func(id string) {
+		visited[id], active[id] = true, true
+		preorder = append(preorder, id)
+
+		for _, v := range successors(id) {
+			if active[v] {
+				back[[2]string{id, v}] = true
+				continue
+			}
+
+			if !visited[v] {
+				parent[v] = id
+				visit(v)
+			}
+		}
+
+		active[id] = false
+		postorder = append(postorder, id)
+	}
+
+	for _, v := range nodes {
+		if predecessors[v.Base().Id] == 0 && !visited[v.Base().Id] {
+			visit(v.Base().Id)
+		}
+	}
+
+	for _, v := range nodes {
+		if !visited[v.Base().Id] {
+			visit(v.Base().Id)
+		}
+	}
+
+	// rank the nodes by the longest path from a start, ignoring back edges.
+	rank := make(map[string]int)
+
+	for i := len(postorder) - 1; i >= 0; i-- {
+		id := postorder[i]
+
+		for _, v := range successors(id) {
+			if !back[[2]string{id, v}] && rank[v] < rank[id]+1 {
+				rank[v] = rank[id] + 1
+			}
+		}
+	}
+
+	// put each node in the row of its tree parent, or in the next free row below.
+	row := make(map[string]int)
+	occupied := make(map[[2]int]bool)
+
+	for _, id := range preorder {
+		r := 0
+
+		if v, ok := parent[id]; ok {
+			r = row[v]
+		}
+
+		for occupied[[2]int{rank[id], r}] {
+			r++
+		}
+
+		row[id] = r
+		occupied[[2]int{rank[id], r}] = true
+	}
+
+	// size the nodes, sub processes are sized by their own layout.
+	ids := make([]string, 0, len(nodes))
+	nested := make(map[string][]string)
+	widths := make(map[int]int)
+	heights := make(map[int]int)
+
+	for _, v := range nodes {
+		id := v.Base().Id
+		b := &bounds{}
+
+		switch v.Base().Kind {
+		case KindStartEvent, KindEndEvent, KindIntermediateCatchEvent, KindIntermediateThrowEvent:
+			b.width, b.height = 36, 36
+		case KindExclusiveGateway, KindParallelGateway, KindInclusiveGateway, KindEventBasedGateway, KindComplexGateway:
+			b.width, b.height = 50, 50
+		case KindSubProcess:
+			w, h, children := place(p, id, shapes)
+			b.width, b.height = max(w+2*padding, 100), max(h+2*padding, 80)
+			nested[id] = children
+		default:
+			b.width, b.height = 100, 80
+		}
+
+		shapes[id] = b
+		ids = append(ids, id)
+
+		widths[rank[id]] = max(widths[rank[id]], b.width)
+		heights[row[id]] = max(heights[row[id]], b.height)
+	}
+
+	// position the nodes centered in the cells of the grid.
+	columns, rows := offsets(widths, spacingX), offsets(heights, spacingY)
+
+	for _, v := range nodes {
+		id := v.Base().Id
+		b := shapes[id]
+
+		b.x = columns[rank[id]] + (widths[rank[id]]-b.width)/2
+		b.y = rows[row[id]] + (heights[row[id]]-b.height)/2
+
+		for _, w := range nested[id] {
+			shapes[w].x += b.x + padding
+			shapes[w].y += b.y + padding
+		}
+
+		ids = append(ids, nested[id]...)
+	}
+
+	return columns[len(widths)] - spacingX, rows[len(heights)] - spacingY, ids
+}
+
+// offsets returns the offsets of consecutive cells with the given sizes and spacing. The offset
+// after the last cell is included.
+func offsets(sizes map[int]int, spacing int) []int {
+	result := make([]int, len(sizes)+1)
+
+	for i := 0; i < len(sizes); i++ {
+		result[i+1] = result[i] + sizes[i] + spacing
+	}
+
+	return result
+}
+
+// route returns the waypoints of a sequence flow. Forward flows leave a splitting source at the top
+// or the bottom and enter a joining target at the top or the bottom, back flows are routed below.
+func route(p *Process, f *SequenceFlow, shapes map[string]*bounds) []point {
+	s, t := shapes[f.SourceRef], shapes[f.TargetRef]
+
+	if s == nil || t == nil {
+		return nil
+	}
+
+	if e, ok := p.Element(f.SourceRef).(*Event); ok && e.Kind == KindBoundaryEvent && t.x > s.centerX() {
+		return []point{{s.centerX(), s.bottom()}, {s.centerX(), t.centerY()}, {t.x, t.centerY()}}
+	}
+
+	if t.x < s.right() {
+		y := max(s.bottom(), t.bottom()) + spacingY/2
+
+		return []point{{s.centerX(), s.bottom()}, {s.centerX(), y}, {t.centerX(), y}, {t.centerX(), t.bottom()}}
+	}
+
+	if s.centerY() == t.centerY() {
+		return []point{{s.right(), s.centerY()}, {t.x, t.centerY()}}
+	}
+
+	if len(p.Outgoing(f.SourceRef)) > 1 {
+		y := s.y
+
+		if t.centerY() > s.centerY() {
+			y = s.bottom()
+		}
+
+		return []point{{s.centerX(), y}, {s.centerX(), t.centerY()}, {t.x, t.centerY()}}
+	}
+
+	y := t.bottom()
+
+	if s.centerY() < t.centerY() {
+		y = t.y
+	}
+
+	return []point{{s.right(), s.centerY()}, {t.centerX(), s.centerY()}, {t.centerX(), y}}
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,539 @@
+package bpmn
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	camunda "github.com/equipmegmbh/camunda-go"
+)
+
+// Severity is the severity of a finding, configured per rule.
+type Severity string
+
+// The severities of findings. Rules configured with SeverityOff are not run.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+	SeverityOff     Severity = "off"
+)
+
+// The names of the built-in rules.
+const (
+	RuleDuplicateId           = "duplicate-id"
+	RuleFlowReference         = "flow-reference"
+	RuleExternalTaskTopic     = "external-task-topic"
+	RuleUserTaskAssignment    = "user-task-assignment"
+	RuleGatewayDefaultFlow    = "gateway-default-flow"
+	RuleUnreachableElement    = "unreachable-element"
+	RuleTimerExpression       = "timer-expression"
+	RuleHistoryTimeToLive     = "history-time-to-live"
+	RuleExecutableProcess     = "executable-process"
+	RuleMessageReference      = "message-reference"
+	RuleBoundaryEventAttached = "boundary-event-attached"
+)
+
+// Rule is a static check of process models.
+type Rule struct {
+	// The name of the rule, used to configure its severity.
+	Name string
+
+	// A description of what the rule checks.
+	Description string
+
+	// The severity of the findings of the rule unless configured otherwise.
+	Severity Severity
+
+	// Check reports the findings of the rule. The rule name and the severity of the findings are set
+	// by the linter.
+	Check func(definitions *Definitions) []*Finding
+}
+
+// LintOptions configure the rules a lint runs.
+type LintOptions struct {
+	// The rules to run. Defaults to DefaultRules.
+	Rules []*Rule
+
+	// The severities of rules by rule name, overriding the severities of the rules. Rules configured
+	// with SeverityOff are not run.
+	Severities map[string]Severity
+}
+
+// Report is the result of a lint.
+type Report struct {
+	// The name of the linted resource.
+	Resource string `json:"resource,omitempty"`
+
+	// The findings, ordered by rule.
+	Findings []*Finding `json:"findings"`
+}
+
+// Finding is a violation of a rule.
+type Finding struct {
+	// The name of the violated rule.
+	Rule string `json:"rule,omitempty"`
+
+	// The severity of the finding.
+	Severity Severity `json:"severity,omitempty"`
+
+	// The id of the process the finding belongs to.
+	ProcessId string `json:"processId,omitempty"`
+
+	// The id of the element the finding belongs to.
+	ElementId string `json:"elementId,omitempty"`
+
+	// A description of the violation.
+	Message string `json:"message,omitempty"`
+}
+
+// DefaultRules are the built-in rules, checking what the engine rejects on deployment or what leads
+// to incidents or stuck process instances at runtime.
+var DefaultRules = []*Rule{
+	{RuleDuplicateId, "ids are unique", SeverityError, duplicateId},
+	{RuleFlowReference, "sequence flows connect existing elements", SeverityError, flowReference},
+	{RuleExecutableProcess, "the definitions contain an executable process", SeverityError, executableProcess},
+	{RuleHistoryTimeToLive, "executable processes define a history time to live, as required by Camunda 7.20 and later", SeverityError, historyTimeToLive},
+	{RuleExternalTaskTopic, "external tasks define a topic", SeverityError, externalTaskTopic},
+	{RuleUserTaskAssignment, "user tasks define an assignee, candidate users or candidate groups", SeverityWarning, userTaskAssignment},
+	{RuleGatewayDefaultFlow, "exclusive and inclusive gateways with conditional flows define a default flow", SeverityWarning, gatewayDefaultFlow},
+	{RuleUnreachableElement, "all elements are reachable from a start event", SeverityWarning, unreachableElement},
+	{RuleTimerExpression, "timers define a valid ISO 8601 date, duration or cycle, or a cron expression", SeverityError, timerExpression},
+	{RuleMessageReference, "message events, receive and send tasks reference an existing message", SeverityError, messageReference},
+	{RuleBoundaryEventAttached, "boundary events are attached to an existing activity", SeverityError, boundaryEventAttached},
+}
+
+// Lint checks the BPMN XML of a process model with the rules of the options. Only malformed XML
+// fails the lint, all other problems are reported as findings.
+func Lint(resource, content string, options *LintOptions) (*Report, error) {
+	definitions, err := decode([]byte(content), false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rules := DefaultRules
+
+	if options != nil && options.Rules != nil {
+		rules = options.Rules
+	}
+
+	result := &Report{Resource: resource, Findings: make([]*Finding, 0)}
+
+	for _, rule := range rules {
+		severity := rule.Severity
+
+		if options != nil && options.Severities[rule.Name] != "" {
+			severity = options.Severities[rule.Name]
+		}
+
+		if severity == SeverityOff {
+			continue
+		}
+
+		for _, v := range rule.Check(definitions) {
+			v.Rule, v.Severity = rule.Name, severity
+			result.Findings = append(result.Findings, v)
+		}
+	}
+
+	return result, nil
+}
+
+// LintSource checks the BPMN XML of a deployed process definition, as returned by
+// GetProcessDefinitionXML. The id of the process definition is used as resource name.
+func LintSource(source *camunda.ProcessDefinitionSource, options *LintOptions) (*Report, error) {
+	if source == nil {
+		return nil, fmt.Errorf("bpmn: process definition source is nil")
+	}
+
+	return Lint(source.Id, source.Content, options)
+}
+
+// Count returns the number of findings with the given severity.
+func (r *Report) Count(severity Severity) int {
+	result := 0
+
+	for _, v := range r.Findings {
+		if v.Severity == severity {
+			result++
+		}
+	}
+
+	return result
+}
+
+// HasErrors returns whether the report contains findings with severity error.
+func (r *Report) HasErrors() bool {
+	return r.Count(SeverityError) > 0
+}
+
+// String formats the report with one finding per line.
+func (r *Report) String() string {
+	var b strings.Builder
+
+	for _, v := range r.Findings {
+		if r.Resource != "" {
+			b.WriteString(r.Resource + ": ")
+		}
+
+		b.WriteString(v.String() + "\n")
+	}
+
+	return b.String()
+}
+
+func (f *Finding) String() string {
+	element := f.ElementId
+
+	if element == "" {
+		element = f.ProcessId
+	}
+
+	return fmt.Sprintf("%s %s %s: %s", f.Severity, f.Rule, element, f.Message)
+}
+
+func duplicateId(definitions *Definitions) []*Finding {
+	result := make([]*Finding, 0)
+	seen := make(map[string]bool)
+
+	for _, v := range definitions.duplicates {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, &Finding{ElementId: v, Message: fmt.Sprintf("id %s is used more than once", v)})
+		}
+	}
+
+	return result
+}
+
+func flowReference(definitions *Definitions) []*Finding {
+	result := make([]*Finding, 0)
+
+	for _, p := range definitions.Processes {
+		for _, v := range p.sequenceFlows() {
+			for _, ref := range []string{v.SourceRef, v.TargetRef} {
+				if p.Element(ref) == nil {
+					result = append(result, &Finding{ProcessId: p.Id, ElementId: v.Id, Message: fmt.Sprintf("sequence flow references unknown element %q", ref)})
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+func executableProcess(definitions *Definitions) []*Finding {
+	for _, p := range definitions.Processes {
+		if p.Executable() {
+			return nil
+		}
+	}
+
+	return []*Finding{{Message: "no process is executable, nothing is deployed"}}
+}
+
+func historyTimeToLive(definitions *Definitions) []*Finding {
+	result := make([]*Finding, 0)
+
+	for _, p := range definitions.Processes {
+		if p.Executable() && p.HistoryTimeToLive == "" {
+			result = append(result, &Finding{ProcessId: p.Id, Message: "process defines no camunda:historyTimeToLive"})
+		}
+	}
+
+	return result
+}
+
+func externalTaskTopic(definitions *Definitions) []*Finding {
+	result := make([]*Finding, 0)
+
+	for _, p := range definitions.Processes {
+		for _, v := range p.Elements() {
+			if topic, ok := Topic(v); ok && strings.TrimSpace(topic) == "" {
+				result = append(result, &Finding{ProcessId: p.Id, ElementId: v.Base().Id, Message: fmt.Sprintf("external %s defines no topic", v.Base().Kind)})
+			}
+		}
+	}
+
+	return result
+}
+
+func userTaskAssignment(definitions *Definitions) []*Finding {
+	result := make([]*Finding, 0)
+
+	for _, p := range definitions.Processes {
+		for _, v := range p.AllUserTasks() {
+			if v.Assignee == "" && len(v.CandidateUserList()) == 0 && len(v.CandidateGroupList()) == 0 {
+				result = append(result, &Finding{ProcessId: p.Id, ElementId: v.Id, Message: "user task defines no assignee, candidate users or candidate groups"})
+			}
+		}
+	}
+
+	return result
+}
+
+func gatewayDefaultFlow(definitions *Definitions) []*Finding {
+	result := make([]*Finding, 0)
+
+	for _, p := range definitions.Processes {
+		for _, v := range p.Elements() {
+			g, ok := v.(*Gateway)
+
+			if !ok || g.Kind != KindExclusiveGateway && g.Kind != KindInclusiveGateway {
+				continue
+			}
+
+			outgoing := p.Outgoing(g.Id)
+
+			if g.Default != "" {
+				if f := p.SequenceFlow(g.Default); f == nil || f.SourceRef != g.Id {
+					result = append(result, &Finding{ProcessId: p.Id, ElementId: g.Id, Message: fmt.Sprintf("default flow %s is no outgoing flow of the gateway", g.Default)})
+				}
+
+				continue
+			}
+
+			conditional := 0
+
+			for _, f := range outgoing {
+				if f.Condition() != "" {
+					conditional++
+				}
+			}
+
+			if conditional > 0 && conditional == len(outgoing) {
+				result = append(result, &Finding{ProcessId: p.Id, ElementId: g.Id, Message: "gateway has conditional flows but no default flow, an incident is raised when no condition is true"})
+			}
+		}
+	}
+
+	return result
+}
+
+func unreachableElement(definitions *Definitions) []*Finding {
+	result := make([]*Finding, 0)
+
+	for _, p := range definitions.Processes {
+		reachable := make(map[string]bool)
+		queue := scopeStarts(p, "")
+
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+
+			if reachable[id] {
+				continue
+			}
+
+			reachable[id] = true
+
+			for _, v := range p.Outgoing(id) {
+				queue = append(queue, v.TargetRef)
+			}
+
+			for _, v := range p.Boundary(id) {
+				queue = append(queue, v.Id)
+			}
+
+			if e, ok := p.Element(id).(*SubProcess); ok {
+				queue = append(queue, scopeStarts(p, e.Id)...)
+			}
+		}
+
+		for _, v := range p.Elements() {
+			if !reachable[v.Base().Id] {
+				result = append(result, &Finding{ProcessId: p.Id, ElementId: v.Base().Id, Message: fmt.Sprintf("%s is not reachable from a start event", v.Base().Kind)})
+			}
+		}
+	}
+
+	return result
+}
+
+// scopeStarts returns the ids of the start events and the event sub processes of the process or
+// the sub process with the given id.
+func scopeStarts(p *Process, scope string) []string {
+	result := make([]string, 0)
+
+	for _, v := range p.Elements() {
+		if v.Base().Parent != scope {
+			continue
+		}
+
+		if v.Base().Kind == KindStartEvent {
+			result = append(result, v.Base().Id)
+		}
+
+		if e, ok := v.(*SubProcess); ok && e.TriggeredByEvent {
+			result = append(result, e.Id)
+		}
+	}
+
+	return result
+}
+
+func timerExpression(definitions *Definitions) []*Finding {
+	result := make([]*Finding, 0)
+
+	for _, p := range definitions.Processes {
+		for _, v := range p.Elements() {
+			e, ok := v.(*Event)
+
+			if !ok || e.Timer == nil {
+				continue
+			}
+
+			if message := timer(e.Timer); message != "" {
+				result = append(result, &Finding{ProcessId: p.Id, ElementId: e.Id, Message: message})
+			}
+		}
+	}
+
+	return result
+}
+
+var (
+	durationPattern = regexp.MustCompile(`^-?P(\d+Y)?(\d+M)?(\d+W)?(\d+D)?(T(\d+H)?(\d+M)?(\d+([.,]\d+)?S)?)?$`)
+	cronPattern     = regexp.MustCompile(`^[0-9A-Za-z*?/,#-]+$`)
+)
+
+// timer returns why the timer event definition is invalid, or an empty string.
+func timer(t *TimerEventDefinition) string {
+	text := func(e *Expression) string {
+		if e == nil {
+			return ""
+		}
+
+		return strings.TrimSpace(e.Text)
+	}
+
+	date, duration, cycle := text(t.TimeDate), text(t.TimeDuration), text(t.TimeCycle)
+
+	switch {
+	case date == "" && duration == "" && cycle == "":
+		return "timer defines no date, duration or cycle"
+	case expression(date) || expression(duration) || expression(cycle):
+		return ""
+	case date != "" && !isDate(date):
+		return fmt.Sprintf("timer date %q is no ISO 8601 date", date)
+	case duration != "" && !isDuration(duration):
+		return fmt.Sprintf("timer duration %q is no ISO 8601 duration", duration)
+	case cycle != "" && !isCycle(cycle):
+		return fmt.Sprintf("timer cycle %q is no ISO 8601 repeating interval or cron expression", cycle)
+	}
+
+	return ""
+}
+
+// expression returns whether the value is resolved at runtime.
+func expression(value string) bool {
+	return strings.Contains(value, "${") || strings.Contains(value, "#{")
+}
+
+func isDate(value string) bool {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02T15:04:05.999999999", "2006-01-02T15:04"} {
+		if _, err := time.Parse(layout, value); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isDuration(value string) bool {
+	return durationPattern.MatchString(value) && value != "P" && !strings.HasSuffix(value, "T")
+}
+
+// isCycle returns whether the value is an ISO 8601 repeating interval, e.g. R3/PT10M or
+// R/2021-01-01T00:00:00Z/P1D, or a cron expression with six or seven fields.
+func isCycle(value string) bool {
+	if strings.HasPrefix(value, "R") && strings.Contains(value, "/") {
+		parts := strings.Split(value, "/")
+
+		for _, v := range parts[0][1:] {
+			if v < '0' || v > '9' {
+				return false
+			}
+		}
+
+		if len(parts) < 2 || len(parts) > 3 {
+			return false
+		}
+
+		durations := 0
+
+		for _, v := range parts[1:] {
+			switch {
+			case isDuration(v):
+				durations++
+			case !isDate(v):
+				return false
+			}
+		}
+
+		return durations == 1
+	}
+
+	fields := strings.Fields(value)
+
+	if len(fields) < 6 || len(fields) > 7 {
+		return false
+	}
+
+	for _, v := range fields {
+		if !cronPattern.MatchString(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func messageReference(definitions *Definitions) []*Finding {
+	result := make([]*Finding, 0)
+
+	for _, p := range definitions.Processes {
+		for _, v := range p.Elements() {
+			ref, check := "", false
+
+			switch e := v.(type) {
+			case *Task:
+				ref, check = e.MessageRef, e.Kind == KindReceiveTask
+			case *Event:
+				if e.Message != nil {
+					ref, check = e.Message.MessageRef, e.Kind != KindEndEvent && e.Kind != KindIntermediateThrowEvent
+				}
+			}
+
+			if check && definitions.Message(ref) == nil {
+				result = append(result, &Finding{ProcessId: p.Id, ElementId: v.Base().Id, Message: fmt.Sprintf("%s references unknown message %q", v.Base().Kind, ref)})
+			}
+		}
+	}
+
+	return result
+}
+
+func boundaryEventAttached(definitions *Definitions) []*Finding {
+	result := make([]*Finding, 0)
+
+	for _, p := range definitions.Processes {
+		for _, v := range p.Elements() {
+			e, ok := v.(*Event)
+
+			if !ok || e.Kind != KindBoundaryEvent {
+				continue
+			}
+
+			switch p.Element(e.AttachedToRef).(type) {
+			case *Task, *CallActivity, *SubProcess:
+			default:
+				result = append(result, &Finding{ProcessId: p.Id, ElementId: e.Id, Message: fmt.Sprintf("boundary event is attached to unknown activity %q", e.AttachedToRef)})
+			}
+		}
+	}
+
+	return result
+}
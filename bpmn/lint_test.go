@@ -0,0 +1,245 @@
+package bpmn
+
+import (
+	"fmt"
+	"testing"
+
+	camunda "github.com/equipmegmbh/camunda-go"
+	"github.com/stretchr/testify/require"
+)
+
+// model returns the BPMN XML of definitions with a message message_1 and a process with the given
+// attributes and flow elements.
+func model(attributes, elements string) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL" xmlns:camunda="http://camunda.org/schema/1.0/bpmn" id="definitions" targetNamespace="http://bpmn.io/schema/bpmn">
+  <bpmn:message id="message_1" name="Approved" />
+  <bpmn:process id="approval" %s>
+%s
+  </bpmn:process>
+</bpmn:definitions>`, attributes, elements)
+}
+
+// executable returns the BPMN XML of an executable process with the given flow elements.
+func executable(elements string) string {
+	return model(`isExecutable="true" camunda:historyTimeToLive="P30D"`, elements)
+}
+
+// timerModel returns the BPMN XML of a process waiting for a timer with the given definition, e.g.
+// <bpmn:timeDuration>PT1H</bpmn:timeDuration>.
+func timerModel(definition string) string {
+	return executable(fmt.Sprintf(`
+    <bpmn:startEvent id="start" />
+    <bpmn:intermediateCatchEvent id="wait">
+      <bpmn:timerEventDefinition>%s</bpmn:timerEventDefinition>
+    </bpmn:intermediateCatchEvent>
+    <bpmn:endEvent id="end" />
+    <bpmn:sequenceFlow id="flow-1" sourceRef="start" targetRef="wait" />
+    <bpmn:sequenceFlow id="flow-2" sourceRef="wait" targetRef="end" />`, definition))
+}
+
+// rule returns the default rule with the given name.
+func rule(t *testing.T, name string) *Rule {
+	for _, v := range DefaultRules {
+		if v.Name == name {
+			return v
+		}
+	}
+
+	t.Fatalf("no rule %s", name)
+
+	return nil
+}
+
+// lint returns the findings of a single rule, as element id and message.
+func lint(t *testing.T, name, content string) [][2]string {
+	report, err := Lint("approval.bpmn", content, &LintOptions{Rules: []*Rule{rule(t, name)}})
+
+	require.NoError(t, err)
+
+	result := make([][2]string, 0)
+
+	for _, v := range report.Findings {
+		require.Equal(t, name, v.Rule)
+		result = append(result, [2]string{v.ElementId, v.Message})
+	}
+
+	return result
+}
+
+func TestLintRules(t *testing.T) {
+	tests := []struct {
+		rule     string
+		valid    string
+		invalid  string
+		findings [][2]string
+	}{
+		{
+			RuleDuplicateId,
+			executable(`<bpmn:startEvent id="start" />`),
+			executable(`<bpmn:startEvent id="start" /><bpmn:endEvent id="start" /><bpmn:endEvent id="start" />`),
+			[][2]string{{"start", "id start is used more than once"}},
+		},
+		{
+			RuleFlowReference,
+			executable(`<bpmn:startEvent id="start" /><bpmn:endEvent id="end" /><bpmn:sequenceFlow id="flow" sourceRef="start" targetRef="end" />`),
+			executable(`<bpmn:startEvent id="start" /><bpmn:sequenceFlow id="flow" sourceRef="start" targetRef="end" />`),
+			[][2]string{{"flow", `sequence flow references unknown element "end"`}},
+		},
+		{
+			RuleExecutableProcess,
+			model(`isExecutable="true"`, `<bpmn:startEvent id="start" />`),
+			model(`isExecutable="false"`, `<bpmn:startEvent id="start" />`),
+			[][2]string{{"", "no process is executable, nothing is deployed"}},
+		},
+		{
+			RuleHistoryTimeToLive,
+			executable(`<bpmn:startEvent id="start" />`),
+			model(`isExecutable="true"`, `<bpmn:startEvent id="start" />`),
+			[][2]string{{"", "process defines no camunda:historyTimeToLive"}},
+		},
+		{
+			RuleExternalTaskTopic,
+			executable(`<bpmn:serviceTask id="pay" camunda:type="external" camunda:topic="payment" />
+				<bpmn:intermediateThrowEvent id="notify"><bpmn:messageEventDefinition camunda:type="external" camunda:topic="notify" /></bpmn:intermediateThrowEvent>`),
+			executable(`<bpmn:serviceTask id="pay" camunda:type="external" camunda:topic=" " />
+				<bpmn:intermediateThrowEvent id="notify"><bpmn:messageEventDefinition camunda:type="external" /></bpmn:intermediateThrowEvent>`),
+			[][2]string{{"pay", "external serviceTask defines no topic"}, {"notify", "external intermediateThrowEvent defines no topic"}},
+		},
+		{
+			RuleUserTaskAssignment,
+			executable(`<bpmn:userTask id="review" camunda:assignee="demo" /><bpmn:userTask id="approve" camunda:candidateUsers="demo" /><bpmn:userTask id="pay" camunda:candidateGroups="accounting" />`),
+			executable(`<bpmn:userTask id="review" camunda:candidateGroups=" " />`),
+			[][2]string{{"review", "user task defines no assignee, candidate users or candidate groups"}},
+		},
+		{
+			RuleGatewayDefaultFlow,
+			executable(`<bpmn:exclusiveGateway id="approved" default="flow-2" /><bpmn:endEvent id="paid" /><bpmn:endEvent id="rejected" />
+				<bpmn:sequenceFlow id="flow-1" sourceRef="approved" targetRef="paid"><bpmn:conditionExpression>${approved}</bpmn:conditionExpression></bpmn:sequenceFlow>
+				<bpmn:sequenceFlow id="flow-2" sourceRef="approved" targetRef="rejected" />`),
+			executable(`<bpmn:exclusiveGateway id="approved" /><bpmn:inclusiveGateway id="notify" default="flow-1" /><bpmn:endEvent id="paid" /><bpmn:endEvent id="rejected" />
+				<bpmn:sequenceFlow id="flow-1" sourceRef="approved" targetRef="paid"><bpmn:conditionExpression>${approved}</bpmn:conditionExpression></bpmn:sequenceFlow>
+				<bpmn:sequenceFlow id="flow-2" sourceRef="approved" targetRef="rejected"><bpmn:conditionExpression>${!approved}</bpmn:conditionExpression></bpmn:sequenceFlow>`),
+			[][2]string{
+				{"approved", "gateway has conditional flows but no default flow, an incident is raised when no condition is true"},
+				{"notify", "default flow flow-1 is no outgoing flow of the gateway"},
+			},
+		},
+		{
+			RuleUnreachableElement,
+			executable(`<bpmn:startEvent id="start" /><bpmn:userTask id="review" /><bpmn:boundaryEvent id="reminder" attachedToRef="review" />
+				<bpmn:subProcess id="cancel" triggeredByEvent="true"><bpmn:startEvent id="cancelled" /></bpmn:subProcess>
+				<bpmn:subProcess id="payment"><bpmn:startEvent id="payment-start" /></bpmn:subProcess>
+				<bpmn:sequenceFlow id="flow-1" sourceRef="start" targetRef="review" /><bpmn:sequenceFlow id="flow-2" sourceRef="review" targetRef="payment" />`),
+			executable(`<bpmn:startEvent id="start" /><bpmn:userTask id="review" /><bpmn:endEvent id="end" />
+				<bpmn:subProcess id="payment"><bpmn:userTask id="pay" /></bpmn:subProcess>
+				<bpmn:sequenceFlow id="flow-1" sourceRef="start" targetRef="end" />`),
+			[][2]string{{"review", "userTask is not reachable from a start event"}, {"payment", "subProcess is not reachable from a start event"}, {"pay", "userTask is not reachable from a start event"}},
+		},
+		{
+			RuleTimerExpression,
+			timerModel(`<bpmn:timeDuration>PT1H</bpmn:timeDuration>`),
+			timerModel(``),
+			[][2]string{{"wait", "timer defines no date, duration or cycle"}},
+		},
+		{
+			RuleMessageReference,
+			executable(`<bpmn:receiveTask id="receive" messageRef="message_1" /><bpmn:sendTask id="send" />
+				<bpmn:startEvent id="start"><bpmn:messageEventDefinition messageRef="message_1" /></bpmn:startEvent>
+				<bpmn:endEvent id="end"><bpmn:messageEventDefinition /></bpmn:endEvent>`),
+			executable(`<bpmn:receiveTask id="receive" messageRef="message_2" />
+				<bpmn:startEvent id="start"><bpmn:messageEventDefinition /></bpmn:startEvent>`),
+			[][2]string{{"receive", `receiveTask references unknown message "message_2"`}, {"start", `startEvent references unknown message ""`}},
+		},
+		{
+			RuleBoundaryEventAttached,
+			executable(`<bpmn:userTask id="review" /><bpmn:callActivity id="call" /><bpmn:subProcess id="sub" />
+				<bpmn:boundaryEvent id="b1" attachedToRef="review" /><bpmn:boundaryEvent id="b2" attachedToRef="call" /><bpmn:boundaryEvent id="b3" attachedToRef="sub" />`),
+			executable(`<bpmn:startEvent id="start" /><bpmn:boundaryEvent id="b1" attachedToRef="start" /><bpmn:boundaryEvent id="b2" attachedToRef="review" />`),
+			[][2]string{{"b1", `boundary event is attached to unknown activity "start"`}, {"b2", `boundary event is attached to unknown activity "review"`}},
+		},
+	}
+
+	require.Len(t, tests, len(DefaultRules))
+
+	for _, test := range tests {
+		t.Run(test.rule, func(t *testing.T) {
+			require.Empty(t, lint(t, test.rule, test.valid))
+			require.Equal(t, test.findings, lint(t, test.rule, test.invalid))
+		})
+	}
+}
+
+func TestLintTimers(t *testing.T) {
+	valid := []string{
+		`<bpmn:timeDate>2021-01-01T00:00:00Z</bpmn:timeDate>`,
+		`<bpmn:timeDate>2021-01-01T12:30:00.5+02:00</bpmn:timeDate>`,
+		`<bpmn:timeDate>2021-01-01T12:30</bpmn:timeDate>`,
+		`<bpmn:timeDate>${due}</bpmn:timeDate>`,
+		`<bpmn:timeDuration>P1Y2M3W4DT5H6M7.5S</bpmn:timeDuration>`,
+		`<bpmn:timeDuration> PT10M </bpmn:timeDuration>`,
+		`<bpmn:timeDuration>#{reminder}</bpmn:timeDuration>`,
+		`<bpmn:timeCycle>R3/PT10M</bpmn:timeCycle>`,
+		`<bpmn:timeCycle>R/2021-01-01T00:00:00Z/P1D</bpmn:timeCycle>`,
+		`<bpmn:timeCycle>R2/P1D/2021-01-01T00:00:00Z</bpmn:timeCycle>`,
+		`<bpmn:timeCycle>0 0 9 * * ?</bpmn:timeCycle>`,
+		`<bpmn:timeCycle>0 0/5 9-17 ? * MON-FRI 2021</bpmn:timeCycle>`,
+	}
+
+	for _, v := range valid {
+		require.Empty(t, lint(t, RuleTimerExpression, timerModel(v)), v)
+	}
+
+	invalid := map[string]string{
+		`<bpmn:timeDate>tomorrow</bpmn:timeDate>`:             `timer date "tomorrow" is no ISO 8601 date`,
+		`<bpmn:timeDate>2021-13-01T00:00:00Z</bpmn:timeDate>`: `timer date "2021-13-01T00:00:00Z" is no ISO 8601 date`,
+		`<bpmn:timeDuration>1 hour</bpmn:timeDuration>`:       `timer duration "1 hour" is no ISO 8601 duration`,
+		`<bpmn:timeDuration>P</bpmn:timeDuration>`:            `timer duration "P" is no ISO 8601 duration`,
+		`<bpmn:timeDuration>PT</bpmn:timeDuration>`:           `timer duration "PT" is no ISO 8601 duration`,
+		`<bpmn:timeDuration>PT1H30</bpmn:timeDuration>`:       `timer duration "PT1H30" is no ISO 8601 duration`,
+		`<bpmn:timeCycle>R3/tomorrow</bpmn:timeCycle>`:        `timer cycle "R3/tomorrow" is no ISO 8601 repeating interval or cron expression`,
+		`<bpmn:timeCycle>Rx/PT10M</bpmn:timeCycle>`:           `timer cycle "Rx/PT10M" is no ISO 8601 repeating interval or cron expression`,
+		`<bpmn:timeCycle>R3/PT10M/PT5M</bpmn:timeCycle>`:      `timer cycle "R3/PT10M/PT5M" is no ISO 8601 repeating interval or cron expression`,
+		`<bpmn:timeCycle>0 0 9 * *</bpmn:timeCycle>`:          `timer cycle "0 0 9 * *" is no ISO 8601 repeating interval or cron expression`,
+		`<bpmn:timeCycle>0 0 9 * * ? 2021 1</bpmn:timeCycle>`: `timer cycle "0 0 9 * * ? 2021 1" is no ISO 8601 repeating interval or cron expression`,
+		`<bpmn:timeCycle>0 0 9 * * {}</bpmn:timeCycle>`:       `timer cycle "0 0 9 * * {}" is no ISO 8601 repeating interval or cron expression`,
+	}
+
+	for definition, message := range invalid {
+		require.Equal(t, [][2]string{{"wait", message}}, lint(t, RuleTimerExpression, timerModel(definition)), definition)
+	}
+}
+
+func TestLintSeverities(t *testing.T) {
+	content := model(`isExecutable="true"`, `<bpmn:startEvent id="start" /><bpmn:userTask id="review" /><bpmn:sequenceFlow id="flow" sourceRef="start" targetRef="review" />`)
+
+	report, err := Lint("approval.bpmn", content, nil)
+
+	require.NoError(t, err)
+	require.Equal(t, []*Finding{
+		{Rule: RuleHistoryTimeToLive, Severity: SeverityError, ProcessId: "approval", Message: "process defines no camunda:historyTimeToLive"},
+		{Rule: RuleUserTaskAssignment, Severity: SeverityWarning, ProcessId: "approval", ElementId: "review", Message: "user task defines no assignee, candidate users or candidate groups"},
+	}, report.Findings)
+	require.True(t, report.HasErrors())
+	require.Equal(t, 1, report.Count(SeverityWarning))
+	require.Equal(t, "approval.bpmn: error history-time-to-live approval: process defines no camunda:historyTimeToLive\n"+
+		"approval.bpmn: warning user-task-assignment review: user task defines no assignee, candidate users or candidate groups\n", report.String())
+
+	options := &LintOptions{Severities: map[string]Severity{RuleHistoryTimeToLive: SeverityOff, RuleUserTaskAssignment: SeverityInfo}}
+
+	report, err = LintSource(&camunda.ProcessDefinitionSource{Id: "approval:1:1", Content: content}, options)
+
+	require.NoError(t, err)
+	require.Equal(t, "approval:1:1", report.Resource)
+	require.Len(t, report.Findings, 1)
+	require.Equal(t, SeverityInfo, report.Findings[0].Severity)
+	require.False(t, report.HasErrors())
+
+	_, err = LintSource(nil, options)
+
+	require.EqualError(t, err, "bpmn: process definition source is nil")
+
+	_, err = Lint("approval.bpmn", "<bpmn:definitions", nil)
+
+	require.Error(t, err)
+}
@@ -0,0 +1,624 @@
+package bpmn
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// The namespaces of BPMN 2.0, its diagram interchange and the Camunda extensions.
+const (
+	NamespaceBPMN    = "http://www.omg.org/spec/BPMN/20100524/MODEL"
+	NamespaceCamunda = "http://camunda.org/schema/1.0/bpmn"
+	NamespaceBPMNDI  = "http://www.omg.org/spec/BPMN/20100524/DI"
+	NamespaceDC      = "http://www.omg.org/spec/DD/20100524/DC"
+	NamespaceDI      = "http://www.omg.org/spec/DD/20100524/DI"
+	NamespaceXSI     = "http://www.w3.org/2001/XMLSchema-instance"
+)
+
+// The kinds of flow nodes, i.e. the local names of their elements.
+const (
+	KindStartEvent             = "startEvent"
+	KindEndEvent               = "endEvent"
+	KindIntermediateCatchEvent = "intermediateCatchEvent"
+	KindIntermediateThrowEvent = "intermediateThrowEvent"
+	KindBoundaryEvent          = "boundaryEvent"
+	KindTask                   = "task"
+	KindUserTask               = "userTask"
+	KindServiceTask            = "serviceTask"
+	KindSendTask               = "sendTask"
+	KindReceiveTask            = "receiveTask"
+	KindScriptTask             = "scriptTask"
+	KindBusinessRuleTask       = "businessRuleTask"
+	KindManualTask             = "manualTask"
+	KindCallActivity           = "callActivity"
+	KindSubProcess             = "subProcess"
+	KindExclusiveGateway       = "exclusiveGateway"
+	KindParallelGateway        = "parallelGateway"
+	KindInclusiveGateway       = "inclusiveGateway"
+	KindEventBasedGateway      = "eventBasedGateway"
+	KindComplexGateway         = "complexGateway"
+)
+
+type Definitions struct {
+	XMLName xml.Name `xml:"definitions"`
+
+	// The id of the definitions.
+	Id string `xml:"id,attr,omitempty"`
+
+	// The name of the definitions.
+	Name string `xml:"name,attr,omitempty"`
+
+	// The target namespace of the definitions.
+	TargetNamespace string `xml:"targetNamespace,attr,omitempty"`
+
+	// The processes of the definitions.
+	Processes []*Process `xml:"process"`
+
+	// The messages referenced by message event definitions, receive and send tasks.
+	Messages []*Message `xml:"message"`
+
+	// The errors referenced by error event definitions.
+	Errors []*Error `xml:"error"`
+
+	// The signals referenced by signal event definitions.
+	Signals []*Signal `xml:"signal"`
+
+	// The escalations referenced by escalation event definitions.
+	Escalations []*Escalation `xml:"escalation"`
+
+	duplicates []string
+}
+
+type Process struct {
+	// The id of the process, used as key of the process definition.
+	Id string `xml:"id,attr,omitempty"`
+
+	// The name of the process.
+	Name string `xml:"name,attr,omitempty"`
+
+	// Whether the process is executable. The engine deploys processes without the attribute as well.
+	IsExecutable *bool `xml:"isExecutable,attr,omitempty"`
+
+	// The version tag of the process definition.
+	VersionTag string `xml:"http://camunda.org/schema/1.0/bpmn versionTag,attr,omitempty"`
+
+	// The history time to live of the process definition.
+	HistoryTimeToLive string `xml:"http://camunda.org/schema/1.0/bpmn historyTimeToLive,attr,omitempty"`
+
+	// The groups which are allowed to start the process.
+	CandidateStarterGroups string `xml:"http://camunda.org/schema/1.0/bpmn candidateStarterGroups,attr,omitempty"`
+
+	// The users who are allowed to start the process.
+	CandidateStarterUsers string `xml:"http://camunda.org/schema/1.0/bpmn candidateStarterUsers,attr,omitempty"`
+
+	// The documentation of the process.
+	Documentation string `xml:"documentation,omitempty"`
+
+	// The extension elements of the process.
+	Extensions *ExtensionElements `xml:"extensionElements,omitempty"`
+
+	FlowElements
+
+	index *index
+}
+
+// Executable returns whether the engine deploys the process, i.e. isExecutable is not false.
+func (p *Process) Executable() bool {
+	return p.IsExecutable == nil || *p.IsExecutable
+}
+
+// FlowElements are the flow nodes and sequence flows of a process or sub process.
+type FlowElements struct {
+	StartEvents             []*Event        `xml:"startEvent"`
+	EndEvents               []*Event        `xml:"endEvent"`
+	IntermediateCatchEvents []*Event        `xml:"intermediateCatchEvent"`
+	IntermediateThrowEvents []*Event        `xml:"intermediateThrowEvent"`
+	BoundaryEvents          []*Event        `xml:"boundaryEvent"`
+	Tasks                   []*Task         `xml:"task"`
+	UserTasks               []*Task         `xml:"userTask"`
+	ServiceTasks            []*Task         `xml:"serviceTask"`
+	SendTasks               []*Task         `xml:"sendTask"`
+	ReceiveTasks            []*Task         `xml:"receiveTask"`
+	ScriptTasks             []*Task         `xml:"scriptTask"`
+	BusinessRuleTasks       []*Task         `xml:"businessRuleTask"`
+	ManualTasks             []*Task         `xml:"manualTask"`
+	CallActivities          []*CallActivity `xml:"callActivity"`
+	SubProcesses            []*SubProcess   `xml:"subProcess"`
+	ExclusiveGateways       []*Gateway      `xml:"exclusiveGateway"`
+	ParallelGateways        []*Gateway      `xml:"parallelGateway"`
+	InclusiveGateways       []*Gateway      `xml:"inclusiveGateway"`
+	EventBasedGateways      []*Gateway      `xml:"eventBasedGateway"`
+	ComplexGateways         []*Gateway      `xml:"complexGateway"`
+	SequenceFlows           []*SequenceFlow `xml:"sequenceFlow"`
+}
+
+// FlowNode is implemented by all events, activities and gateways.
+type FlowNode interface {
+	Base() *BaseElement
+}
+
+// BaseElement holds the attributes shared by all flow nodes.
+type BaseElement struct {
+	// The id of the element, unique within the definitions.
+	Id string `xml:"id,attr,omitempty"`
+
+	// The name of the element.
+	Name string `xml:"name,attr,omitempty"`
+
+	// The kind of the element, one of the Kind constants. Set when the definitions are parsed or built.
+	Kind string `xml:"-"`
+
+	// The id of the sub process the element is embedded in, empty for elements of the process. Set
+	// when the definitions are parsed or built.
+	Parent string `xml:"-"`
+
+	// Whether the engine continues asynchronously before the element.
+	AsyncBefore bool `xml:"http://camunda.org/schema/1.0/bpmn asyncBefore,attr,omitempty"`
+
+	// Whether the engine continues asynchronously after the element.
+	AsyncAfter bool `xml:"http://camunda.org/schema/1.0/bpmn asyncAfter,attr,omitempty"`
+
+	// The priority of the jobs created for the element.
+	JobPriority string `xml:"http://camunda.org/schema/1.0/bpmn jobPriority,attr,omitempty"`
+
+	// The documentation of the element.
+	Documentation string `xml:"documentation,omitempty"`
+
+	// The extension elements of the element.
+	Extensions *ExtensionElements `xml:"extensionElements,omitempty"`
+
+	// The ids of the incoming sequence flows.
+	Incoming []string `xml:"incoming,omitempty"`
+
+	// The ids of the outgoing sequence flows.
+	Outgoing []string `xml:"outgoing,omitempty"`
+}
+
+// Base returns the base element of a flow node.
+func (b *BaseElement) Base() *BaseElement {
+	return b
+}
+
+// Property returns the value of a camunda:property of the element, or an empty string.
+func (b *BaseElement) Property(name string) string {
+	if b.Extensions == nil || b.Extensions.Properties == nil {
+		return ""
+	}
+
+	for _, v := range b.Extensions.Properties.Properties {
+		if v.Name == name {
+			return v.Value
+		}
+	}
+
+	return ""
+}
+
+// InputParameter returns the input parameter of the element with the given name, or nil.
+func (b *BaseElement) InputParameter(name string) *Parameter {
+	if b.Extensions == nil || b.Extensions.InputOutput == nil {
+		return nil
+	}
+
+	return parameter(b.Extensions.InputOutput.InputParameters, name)
+}
+
+// OutputParameter returns the output parameter of the element with the given name, or nil.
+func (b *BaseElement) OutputParameter(name string) *Parameter {
+	if b.Extensions == nil || b.Extensions.InputOutput == nil {
+		return nil
+	}
+
+	return parameter(b.Extensions.InputOutput.OutputParameters, name)
+}
+
+type Event struct {
+	BaseElement
+
+	// The id of the activity a boundary event is attached to.
+	AttachedToRef string `xml:"attachedToRef,attr,omitempty"`
+
+	// Whether a boundary event interrupts the activity. Defaults to true.
+	CancelActivity *bool `xml:"cancelActivity,attr,omitempty"`
+
+	// Whether a start event of an event sub process interrupts the scope. Defaults to true.
+	IsInterrupting *bool `xml:"isInterrupting,attr,omitempty"`
+
+	// The message event definition of the event.
+	Message *MessageEventDefinition `xml:"messageEventDefinition,omitempty"`
+
+	// The timer event definition of the event.
+	Timer *TimerEventDefinition `xml:"timerEventDefinition,omitempty"`
+
+	// The error event definition of the event.
+	Error *ErrorEventDefinition `xml:"errorEventDefinition,omitempty"`
+
+	// The signal event definition of the event.
+	Signal *SignalEventDefinition `xml:"signalEventDefinition,omitempty"`
+
+	// The escalation event definition of the event.
+	Escalation *EscalationEventDefinition `xml:"escalationEventDefinition,omitempty"`
+
+	// The conditional event definition of the event.
+	Conditional *ConditionalEventDefinition `xml:"conditionalEventDefinition,omitempty"`
+
+	// The terminate event definition of an end event.
+	Terminate *TerminateEventDefinition `xml:"terminateEventDefinition,omitempty"`
+}
+
+// Interrupting returns whether a boundary or event sub process start event interrupts its scope.
+func (e *Event) Interrupting() bool {
+	if e.CancelActivity != nil && !*e.CancelActivity {
+		return false
+	}
+
+	return e.IsInterrupting == nil || *e.IsInterrupting
+}
+
+// None returns whether the event has no event definition.
+func (e *Event) None() bool {
+	return e.Message == nil && e.Timer == nil && e.Error == nil && e.Signal == nil && e.Escalation == nil &&
+		e.Conditional == nil && e.Terminate == nil
+}
+
+type MessageEventDefinition struct {
+	// The id of the referenced message.
+	MessageRef string `xml:"messageRef,attr,omitempty"`
+
+	// The external task topic of a message throw event implemented as external task.
+	Topic string `xml:"http://camunda.org/schema/1.0/bpmn topic,attr,omitempty"`
+
+	// The implementation type of a message throw event, e.g. external.
+	Type string `xml:"http://camunda.org/schema/1.0/bpmn type,attr,omitempty"`
+}
+
+type TimerEventDefinition struct {
+	// An ISO 8601 date the timer fires at.
+	TimeDate *Expression `xml:"timeDate,omitempty"`
+
+	// An ISO 8601 duration after which the timer fires.
+	TimeDuration *Expression `xml:"timeDuration,omitempty"`
+
+	// An ISO 8601 repeating interval or cron expression the timer fires at.
+	TimeCycle *Expression `xml:"timeCycle,omitempty"`
+}
+
+type ErrorEventDefinition struct {
+	// The id of the referenced error.
+	ErrorRef string `xml:"errorRef,attr,omitempty"`
+
+	// The variable the error code is stored in.
+	ErrorCodeVariable string `xml:"http://camunda.org/schema/1.0/bpmn errorCodeVariable,attr,omitempty"`
+
+	// The variable the error message is stored in.
+	ErrorMessageVariable string `xml:"http://camunda.org/schema/1.0/bpmn errorMessageVariable,attr,omitempty"`
+}
+
+type SignalEventDefinition struct {
+	// The id of the referenced signal.
+	SignalRef string `xml:"signalRef,attr,omitempty"`
+}
+
+type EscalationEventDefinition struct {
+	// The id of the referenced escalation.
+	EscalationRef string `xml:"escalationRef,attr,omitempty"`
+}
+
+type ConditionalEventDefinition struct {
+	// The condition of the event.
+	Condition *Expression `xml:"condition,omitempty"`
+
+	// The name of the variable whose changes trigger the evaluation of the condition.
+	VariableName string `xml:"http://camunda.org/schema/1.0/bpmn variableName,attr,omitempty"`
+}
+
+type TerminateEventDefinition struct{}
+
+type Task struct {
+	BaseElement
+
+	// The implementation type of a service task, e.g. external.
+	Type string `xml:"http://camunda.org/schema/1.0/bpmn type,attr,omitempty"`
+
+	// The topic of an external task.
+	Topic string `xml:"http://camunda.org/schema/1.0/bpmn topic,attr,omitempty"`
+
+	// The priority of an external task.
+	TaskPriority string `xml:"http://camunda.org/schema/1.0/bpmn taskPriority,attr,omitempty"`
+
+	// The java class implementing a service task.
+	Class string `xml:"http://camunda.org/schema/1.0/bpmn class,attr,omitempty"`
+
+	// The expression implementing a service task.
+	Expression string `xml:"http://camunda.org/schema/1.0/bpmn expression,attr,omitempty"`
+
+	// The expression resolving to the delegate implementing a service task.
+	DelegateExpression string `xml:"http://camunda.org/schema/1.0/bpmn delegateExpression,attr,omitempty"`
+
+	// The variable the result of an expression, script or decision is stored in.
+	ResultVariable string `xml:"http://camunda.org/schema/1.0/bpmn resultVariable,attr,omitempty"`
+
+	// The key of the decision evaluated by a business rule task.
+	DecisionRef string `xml:"http://camunda.org/schema/1.0/bpmn decisionRef,attr,omitempty"`
+
+	// The assignee of a user task.
+	Assignee string `xml:"http://camunda.org/schema/1.0/bpmn assignee,attr,omitempty"`
+
+	// The comma separated candidate users of a user task.
+	CandidateUsers string `xml:"http://camunda.org/schema/1.0/bpmn candidateUsers,attr,omitempty"`
+
+	// The comma separated candidate groups of a user task.
+	CandidateGroups string `xml:"http://camunda.org/schema/1.0/bpmn candidateGroups,attr,omitempty"`
+
+	// The form key of a user task.
+	FormKey string `xml:"http://camunda.org/schema/1.0/bpmn formKey,attr,omitempty"`
+
+	// The due date of a user task.
+	DueDate string `xml:"http://camunda.org/schema/1.0/bpmn dueDate,attr,omitempty"`
+
+	// The follow-up date of a user task.
+	FollowUpDate string `xml:"http://camunda.org/schema/1.0/bpmn followUpDate,attr,omitempty"`
+
+	// The priority of a user task.
+	Priority string `xml:"http://camunda.org/schema/1.0/bpmn priority,attr,omitempty"`
+
+	// The id of the message referenced by a receive or send task.
+	MessageRef string `xml:"messageRef,attr,omitempty"`
+
+	// The multi instance characteristics of the task.
+	MultiInstance *MultiInstance `xml:"multiInstanceLoopCharacteristics,omitempty"`
+
+	// The language of the script of a script task.
+	ScriptFormat string `xml:"scriptFormat,attr,omitempty"`
+
+	// The inline script of a script task.
+	Script string `xml:"script,omitempty"`
+}
+
+// External returns whether the task is implemented by an external worker.
+func (t *Task) External() bool {
+	return t.Type == "external"
+}
+
+// Topic returns the external task topic of a task implemented by an external worker, or of a
+// message throw event implemented as external task. The second result reports whether the flow
+// node is implemented as external task at all, as its topic may be missing.
+func Topic(node FlowNode) (string, bool) {
+	switch e := node.(type) {
+	case *Task:
+		if e.External() {
+			return e.Topic, true
+		}
+	case *Event:
+		if e.Message != nil && e.Message.Type == "external" {
+			return e.Message.Topic, true
+		}
+	}
+
+	return "", false
+}
+
+// CandidateUserList returns the candidate users of a user task.
+func (t *Task) CandidateUserList() []string {
+	return list(t.CandidateUsers)
+}
+
+// CandidateGroupList returns the candidate groups of a user task.
+func (t *Task) CandidateGroupList() []string {
+	return list(t.CandidateGroups)
+}
+
+type CallActivity struct {
+	BaseElement
+
+	// The key of the called process.
+	CalledElement string `xml:"calledElement,attr,omitempty"`
+
+	// The binding of the called process definition, e.g. latest, deployment or version.
+	CalledElementBinding string `xml:"http://camunda.org/schema/1.0/bpmn calledElementBinding,attr,omitempty"`
+
+	// The version of the called process definition for the version binding.
+	CalledElementVersion string `xml:"http://camunda.org/schema/1.0/bpmn calledElementVersion,attr,omitempty"`
+
+	// The tenant id of the called process definition.
+	CalledElementTenantId string `xml:"http://camunda.org/schema/1.0/bpmn calledElementTenantId,attr,omitempty"`
+
+	// The multi instance characteristics of the call activity.
+	MultiInstance *MultiInstance `xml:"multiInstanceLoopCharacteristics,omitempty"`
+}
+
+type SubProcess struct {
+	BaseElement
+
+	// Whether the sub process is an event sub process.
+	TriggeredByEvent bool `xml:"triggeredByEvent,attr,omitempty"`
+
+	// The multi instance characteristics of the sub process.
+	MultiInstance *MultiInstance `xml:"multiInstanceLoopCharacteristics,omitempty"`
+
+	FlowElements
+}
+
+type Gateway struct {
+	BaseElement
+
+	// The id of the default sequence flow of an exclusive, inclusive or complex gateway.
+	Default string `xml:"default,attr,omitempty"`
+}
+
+type SequenceFlow struct {
+	// The id of the sequence flow.
+	Id string `xml:"id,attr,omitempty"`
+
+	// The name of the sequence flow.
+	Name string `xml:"name,attr,omitempty"`
+
+	// The id of the source element.
+	SourceRef string `xml:"sourceRef,attr,omitempty"`
+
+	// The id of the target element.
+	TargetRef string `xml:"targetRef,attr,omitempty"`
+
+	// The extension elements of the sequence flow.
+	Extensions *ExtensionElements `xml:"extensionElements,omitempty"`
+
+	// The condition of the sequence flow.
+	ConditionExpression *Expression `xml:"conditionExpression,omitempty"`
+}
+
+// Condition returns the trimmed text of the condition expression, or an empty string.
+func (f *SequenceFlow) Condition() string {
+	if f.ConditionExpression == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(f.ConditionExpression.Text)
+}
+
+type Expression struct {
+	// The xsi:type of the expression, usually bpmn:tFormalExpression.
+	Type string `xml:"http://www.w3.org/2001/XMLSchema-instance type,attr,omitempty"`
+
+	// The language of a script condition.
+	Language string `xml:"language,attr,omitempty"`
+
+	// The resource of an external script condition.
+	Resource string `xml:"http://camunda.org/schema/1.0/bpmn resource,attr,omitempty"`
+
+	// The expression.
+	Text string `xml:",chardata"`
+}
+
+type MultiInstance struct {
+	// Whether the instances are executed one after the other.
+	IsSequential bool `xml:"isSequential,attr,omitempty"`
+
+	// The collection the instances are created for.
+	Collection string `xml:"http://camunda.org/schema/1.0/bpmn collection,attr,omitempty"`
+
+	// The variable holding the element of the collection in an instance.
+	ElementVariable string `xml:"http://camunda.org/schema/1.0/bpmn elementVariable,attr,omitempty"`
+
+	// The number of instances.
+	LoopCardinality *Expression `xml:"loopCardinality,omitempty"`
+
+	// The condition which completes the activity early.
+	CompletionCondition *Expression `xml:"completionCondition,omitempty"`
+}
+
+type ExtensionElements struct {
+	// The input and output mappings of the element.
+	InputOutput *InputOutput `xml:"http://camunda.org/schema/1.0/bpmn inputOutput,omitempty"`
+
+	// The properties of the element.
+	Properties *Properties `xml:"http://camunda.org/schema/1.0/bpmn properties,omitempty"`
+}
+
+type InputOutput struct {
+	// The input parameters, mapped into the scope of the element.
+	InputParameters []*Parameter `xml:"http://camunda.org/schema/1.0/bpmn inputParameter"`
+
+	// The output parameters, mapped out of the scope of the element.
+	OutputParameters []*Parameter `xml:"http://camunda.org/schema/1.0/bpmn outputParameter"`
+}
+
+type Parameter struct {
+	// The name of the parameter.
+	Name string `xml:"name,attr"`
+
+	// The text value of the parameter, usually a constant or an expression.
+	Value string `xml:",chardata"`
+
+	// The script value of the parameter.
+	Script *Script `xml:"http://camunda.org/schema/1.0/bpmn script,omitempty"`
+
+	// The list value of the parameter.
+	List []string `xml:"http://camunda.org/schema/1.0/bpmn list>value,omitempty"`
+}
+
+type Script struct {
+	// The language of the script.
+	ScriptFormat string `xml:"scriptFormat,attr,omitempty"`
+
+	// The resource of an external script.
+	Resource string `xml:"resource,attr,omitempty"`
+
+	// The inline script.
+	Text string `xml:",chardata"`
+}
+
+type Properties struct {
+	// The properties.
+	Properties []*Property `xml:"http://camunda.org/schema/1.0/bpmn property"`
+}
+
+type Property struct {
+	// The name of the property.
+	Name string `xml:"name,attr"`
+
+	// The value of the property.
+	Value string `xml:"value,attr"`
+}
+
+type Message struct {
+	// The id of the message.
+	Id string `xml:"id,attr,omitempty"`
+
+	// The name of the message, used for correlation.
+	Name string `xml:"name,attr,omitempty"`
+}
+
+type Error struct {
+	// The id of the error.
+	Id string `xml:"id,attr,omitempty"`
+
+	// The name of the error.
+	Name string `xml:"name,attr,omitempty"`
+
+	// The error code, used to catch the error.
+	ErrorCode string `xml:"errorCode,attr,omitempty"`
+
+	// The error message.
+	ErrorMessage string `xml:"http://camunda.org/schema/1.0/bpmn errorMessage,attr,omitempty"`
+}
+
+type Signal struct {
+	// The id of the signal.
+	Id string `xml:"id,attr,omitempty"`
+
+	// The name of the signal.
+	Name string `xml:"name,attr,omitempty"`
+}
+
+type Escalation struct {
+	// The id of the escalation.
+	Id string `xml:"id,attr,omitempty"`
+
+	// The name of the escalation.
+	Name string `xml:"name,attr,omitempty"`
+
+	// The escalation code, used to catch the escalation.
+	EscalationCode string `xml:"escalationCode,attr,omitempty"`
+}
+
+func parameter(parameters []*Parameter, name string) *Parameter {
+	for _, v := range parameters {
+		if v.Name == name {
+			return v
+		}
+	}
+
+	return nil
+}
+
+func list(value string) []string {
+	result := make([]string, 0)
+
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
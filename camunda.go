@@ -9,6 +9,8 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
+	"sort"
+	"time"
 )
 
 var (
@@ -26,6 +28,12 @@ func Configure(endpoint, context string) {
 	path = context
 }
 
+// ConfigureTransport configures the transport the camunda client sends its requests with. A nil
+// transport restores the default transport of the http package.
+func ConfigureTransport(transport http.RoundTripper) {
+	client.cli.Transport = transport
+}
+
 // CreateDeployment creates a Deployment.
 func CreateDeployment(ctx context.Context, tenant, name, filename string, content io.Reader) (*Deployment, error) {
 	buffer := bytes.NewBuffer(make([]byte, 0))
@@ -464,6 +472,242 @@ func GetHistoricProcessInstance(ctx context.Context, id string) (*HistoricProces
 	return result, err
 }
 
+// GetHistoricActivityInstances queries for historic activity instances that fulfill the given parameters.
+// The size of the result set can be retrieved by using the GetHistoricActivityInstancesCount method.
+func GetHistoricActivityInstances(ctx context.Context, query *HistoricActivityInstanceQuery, firstResult, maxResults int) ([]*HistoricActivityInstance, error) {
+	var uri string
+	var params string
+	var err error
+
+	payload, err := json.Marshal(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	params, err = encode(nil, firstResult, maxResults)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*HistoricActivityInstance, 0)
+
+	uri = fmt.Sprintf("%s/%s/history/activity-instance%s", url, path, params)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetHistoricActivityInstancesCount queries for the number of historic activity instances that fulfill the given parameters.
+func GetHistoricActivityInstancesCount(ctx context.Context, query *HistoricActivityInstanceQuery) (int, error) {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(query)
+
+	if err != nil {
+		return 0, err
+	}
+
+	result := new(Count)
+
+	uri = fmt.Sprintf("%s/%s/history/activity-instance/count", url, path)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), result)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.Count, err
+}
+
+// GetHistoricActivityInstance retrieves a historic activity instance by id, according to the
+// HistoricActivityInstance interface in the engine.
+func GetHistoricActivityInstance(ctx context.Context, id string) (*HistoricActivityInstance, error) {
+	var uri string
+	var err error
+
+	result := new(HistoricActivityInstance)
+
+	uri = fmt.Sprintf("%s/%s/history/activity-instance/%s", url, path, id)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetHistoricVariableInstances queries for historic variable instances that fulfill the given parameters.
+// Object variables are only deserialized on the server side if deserializeValues is true. The size of the
+// result set can be retrieved by using the GetHistoricVariableInstancesCount method.
+func GetHistoricVariableInstances(ctx context.Context, query *HistoricVariableInstanceQuery, deserializeValues bool, firstResult, maxResults int) ([]*HistoricVariableInstance, error) {
+	var uri string
+	var params string
+	var err error
+
+	payload, err := json.Marshal(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	params, err = encode(map[string]bool{"deserializeValues": deserializeValues}, firstResult, maxResults)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*HistoricVariableInstance, 0)
+
+	uri = fmt.Sprintf("%s/%s/history/variable-instance%s", url, path, params)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetHistoricVariableInstancesCount queries for the number of historic variable instances that fulfill the given parameters.
+func GetHistoricVariableInstancesCount(ctx context.Context, query *HistoricVariableInstanceQuery) (int, error) {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(query)
+
+	if err != nil {
+		return 0, err
+	}
+
+	result := new(Count)
+
+	uri = fmt.Sprintf("%s/%s/history/variable-instance/count", url, path)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), result)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.Count, err
+}
+
+// GetHistoricVariableInstance retrieves a historic variable instance by id.
+func GetHistoricVariableInstance(ctx context.Context, id string, deserializeValue bool) (*HistoricVariableInstance, error) {
+	var uri string
+	var err error
+
+	result := new(HistoricVariableInstance)
+
+	uri = fmt.Sprintf("%s/%s/history/variable-instance/%s?deserializeValue=%t", url, path, id, deserializeValue)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetHistoricVariableInstanceData retrieves the content of a historic binary variable by id. Applicable for
+// byte array and file variables. The caller is responsible for closing the returned reader.
+func GetHistoricVariableInstanceData(ctx context.Context, id string) (io.ReadCloser, error) {
+	var uri string
+
+	uri = fmt.Sprintf("%s/%s/history/variable-instance/%s/data", url, path, id)
+
+	return client.stream(ctx, uri, http.MethodGet, "application/octet-stream", nil)
+}
+
+// GetHistoricDetails queries for historic details that fulfill the given parameters. Historic details are
+// variable updates and submitted form fields. Object variables are only deserialized on the server side if
+// deserializeValues is true. The size of the result set can be retrieved by using the GetHistoricDetailsCount method.
+func GetHistoricDetails(ctx context.Context, query *HistoricDetailQuery, deserializeValues bool, firstResult, maxResults int) ([]*HistoricDetail, error) {
+	var uri string
+	var params string
+	var err error
+
+	payload, err := json.Marshal(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	params, err = encode(map[string]bool{"deserializeValues": deserializeValues}, firstResult, maxResults)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*HistoricDetail, 0)
+
+	uri = fmt.Sprintf("%s/%s/history/detail%s", url, path, params)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetHistoricDetailsCount queries for the number of historic details that fulfill the given parameters.
+func GetHistoricDetailsCount(ctx context.Context, query *HistoricDetailQuery) (int, error) {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(query)
+
+	if err != nil {
+		return 0, err
+	}
+
+	result := new(Count)
+
+	uri = fmt.Sprintf("%s/%s/history/detail/count", url, path)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), result)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.Count, err
+}
+
+// GetHistoricDetail retrieves a historic detail by id.
+func GetHistoricDetail(ctx context.Context, id string, deserializeValue bool) (*HistoricDetail, error) {
+	var uri string
+	var err error
+
+	result := new(HistoricDetail)
+
+	uri = fmt.Sprintf("%s/%s/history/detail/%s?deserializeValue=%t", url, path, id, deserializeValue)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetHistoricDetailData retrieves the content of a historic variable update by id. Applicable for
+// byte array and file variables. The caller is responsible for closing the returned reader.
+func GetHistoricDetailData(ctx context.Context, id string) (io.ReadCloser, error) {
+	var uri string
+
+	uri = fmt.Sprintf("%s/%s/history/detail/%s/data", url, path, id)
+
+	return client.stream(ctx, uri, http.MethodGet, "application/octet-stream", nil)
+}
+
 // DeleteProcessInstance deletes a running process instance by id.
 func DeleteProcessInstance(ctx context.Context, id string) error {
 	var uri string
@@ -475,6 +719,86 @@ func DeleteProcessInstance(ctx context.Context, id string) error {
 	return err
 }
 
+// GetProcessInstanceVariables retrieves all variables of a process instance. Object variables are only
+// deserialized on the server side if deserializeValues is true.
+func GetProcessInstanceVariables(ctx context.Context, id string, deserializeValues bool) (map[string]*Variable, error) {
+	return getVariables(ctx, fmt.Sprintf("process-instance/%s/variables", id), deserializeValues)
+}
+
+// GetProcessInstanceVariable retrieves a variable of a process instance by name.
+func GetProcessInstanceVariable(ctx context.Context, id, name string, deserializeValue bool) (*Variable, error) {
+	return getVariable(ctx, fmt.Sprintf("process-instance/%s/variables", id), name, deserializeValue)
+}
+
+// SetProcessInstanceVariable sets a variable of a process instance. An existing variable with the same
+// name is overwritten.
+func SetProcessInstanceVariable(ctx context.Context, id, name string, variable *Variable) error {
+	return setVariable(ctx, fmt.Sprintf("process-instance/%s/variables", id), name, variable)
+}
+
+// DeleteProcessInstanceVariable deletes a variable of a process instance by name.
+func DeleteProcessInstanceVariable(ctx context.Context, id, name string) error {
+	return deleteVariable(ctx, fmt.Sprintf("process-instance/%s/variables", id), name)
+}
+
+// ModifyProcessInstanceVariables updates or deletes the variables of a process instance in one call.
+// Deletion precedes update.
+func ModifyProcessInstanceVariables(ctx context.Context, id string, data *VariableModification) error {
+	return modifyVariables(ctx, fmt.Sprintf("process-instance/%s/variables", id), data)
+}
+
+// GetProcessInstanceVariableData retrieves the content of a binary variable of a process instance. Applicable
+// for byte array and file variables. The caller is responsible for closing the returned reader.
+func GetProcessInstanceVariableData(ctx context.Context, id, name string) (io.ReadCloser, error) {
+	return getVariableData(ctx, fmt.Sprintf("process-instance/%s/variables", id), name)
+}
+
+// SetProcessInstanceVariableData sets the content of a binary variable of a process instance. The value type
+// may be ValueTypeBytes or ValueTypeFile, the content is streamed to the engine.
+func SetProcessInstanceVariableData(ctx context.Context, id, name, valueType string, content io.Reader) error {
+	return setVariableData(ctx, fmt.Sprintf("process-instance/%s/variables", id), name, valueType, content)
+}
+
+// GetExecutionLocalVariables retrieves all variables of an execution in its local scope. Object variables are only
+// deserialized on the server side if deserializeValues is true.
+func GetExecutionLocalVariables(ctx context.Context, id string, deserializeValues bool) (map[string]*Variable, error) {
+	return getVariables(ctx, fmt.Sprintf("execution/%s/localVariables", id), deserializeValues)
+}
+
+// GetExecutionLocalVariable retrieves a variable of an execution in its local scope by name.
+func GetExecutionLocalVariable(ctx context.Context, id, name string, deserializeValue bool) (*Variable, error) {
+	return getVariable(ctx, fmt.Sprintf("execution/%s/localVariables", id), name, deserializeValue)
+}
+
+// SetExecutionLocalVariable sets a variable of an execution in its local scope. An existing variable with the same
+// name is overwritten.
+func SetExecutionLocalVariable(ctx context.Context, id, name string, variable *Variable) error {
+	return setVariable(ctx, fmt.Sprintf("execution/%s/localVariables", id), name, variable)
+}
+
+// DeleteExecutionLocalVariable deletes a variable of an execution in its local scope by name.
+func DeleteExecutionLocalVariable(ctx context.Context, id, name string) error {
+	return deleteVariable(ctx, fmt.Sprintf("execution/%s/localVariables", id), name)
+}
+
+// ModifyExecutionLocalVariables updates or deletes the variables of an execution in its local scope in one call.
+// Deletion precedes update.
+func ModifyExecutionLocalVariables(ctx context.Context, id string, data *VariableModification) error {
+	return modifyVariables(ctx, fmt.Sprintf("execution/%s/localVariables", id), data)
+}
+
+// GetExecutionLocalVariableData retrieves the content of a binary variable of an execution in its local scope. Applicable
+// for byte array and file variables. The caller is responsible for closing the returned reader.
+func GetExecutionLocalVariableData(ctx context.Context, id, name string) (io.ReadCloser, error) {
+	return getVariableData(ctx, fmt.Sprintf("execution/%s/localVariables", id), name)
+}
+
+// SetExecutionLocalVariableData sets the content of a binary variable of an execution in its local scope. The value type
+// may be ValueTypeBytes or ValueTypeFile, the content is streamed to the engine.
+func SetExecutionLocalVariableData(ctx context.Context, id, name, valueType string, content io.Reader) error {
+	return setVariableData(ctx, fmt.Sprintf("execution/%s/localVariables", id), name, valueType, content)
+}
+
 // GetTasks queries for tasks that fulfill a given filter. The size of the result set can be retrieved
 // by using the GetTasksCount method.
 func GetTasks(ctx context.Context, processInstanceId string) ([]*Task, error) {
@@ -556,6 +880,80 @@ func GetTaskVariables(ctx context.Context, id string) (map[string]*Variable, err
 	return result, err
 }
 
+// GetTaskVariable retrieves a variable of a task by name.
+func GetTaskVariable(ctx context.Context, id, name string, deserializeValue bool) (*Variable, error) {
+	return getVariable(ctx, fmt.Sprintf("task/%s/variables", id), name, deserializeValue)
+}
+
+// SetTaskVariable sets a variable of a task. An existing variable with the same
+// name is overwritten.
+func SetTaskVariable(ctx context.Context, id, name string, variable *Variable) error {
+	return setVariable(ctx, fmt.Sprintf("task/%s/variables", id), name, variable)
+}
+
+// DeleteTaskVariable deletes a variable of a task by name.
+func DeleteTaskVariable(ctx context.Context, id, name string) error {
+	return deleteVariable(ctx, fmt.Sprintf("task/%s/variables", id), name)
+}
+
+// ModifyTaskVariables updates or deletes the variables of a task in one call.
+// Deletion precedes update.
+func ModifyTaskVariables(ctx context.Context, id string, data *VariableModification) error {
+	return modifyVariables(ctx, fmt.Sprintf("task/%s/variables", id), data)
+}
+
+// GetTaskVariableData retrieves the content of a binary variable of a task. Applicable
+// for byte array and file variables. The caller is responsible for closing the returned reader.
+func GetTaskVariableData(ctx context.Context, id, name string) (io.ReadCloser, error) {
+	return getVariableData(ctx, fmt.Sprintf("task/%s/variables", id), name)
+}
+
+// SetTaskVariableData sets the content of a binary variable of a task. The value type
+// may be ValueTypeBytes or ValueTypeFile, the content is streamed to the engine.
+func SetTaskVariableData(ctx context.Context, id, name, valueType string, content io.Reader) error {
+	return setVariableData(ctx, fmt.Sprintf("task/%s/variables", id), name, valueType, content)
+}
+
+// GetTaskLocalVariables retrieves all variables of a task in its local scope. Object variables are only
+// deserialized on the server side if deserializeValues is true.
+func GetTaskLocalVariables(ctx context.Context, id string, deserializeValues bool) (map[string]*Variable, error) {
+	return getVariables(ctx, fmt.Sprintf("task/%s/localVariables", id), deserializeValues)
+}
+
+// GetTaskLocalVariable retrieves a variable of a task in its local scope by name.
+func GetTaskLocalVariable(ctx context.Context, id, name string, deserializeValue bool) (*Variable, error) {
+	return getVariable(ctx, fmt.Sprintf("task/%s/localVariables", id), name, deserializeValue)
+}
+
+// SetTaskLocalVariable sets a variable of a task in its local scope. An existing variable with the same
+// name is overwritten.
+func SetTaskLocalVariable(ctx context.Context, id, name string, variable *Variable) error {
+	return setVariable(ctx, fmt.Sprintf("task/%s/localVariables", id), name, variable)
+}
+
+// DeleteTaskLocalVariable deletes a variable of a task in its local scope by name.
+func DeleteTaskLocalVariable(ctx context.Context, id, name string) error {
+	return deleteVariable(ctx, fmt.Sprintf("task/%s/localVariables", id), name)
+}
+
+// ModifyTaskLocalVariables updates or deletes the variables of a task in its local scope in one call.
+// Deletion precedes update.
+func ModifyTaskLocalVariables(ctx context.Context, id string, data *VariableModification) error {
+	return modifyVariables(ctx, fmt.Sprintf("task/%s/localVariables", id), data)
+}
+
+// GetTaskLocalVariableData retrieves the content of a binary variable of a task in its local scope. Applicable
+// for byte array and file variables. The caller is responsible for closing the returned reader.
+func GetTaskLocalVariableData(ctx context.Context, id, name string) (io.ReadCloser, error) {
+	return getVariableData(ctx, fmt.Sprintf("task/%s/localVariables", id), name)
+}
+
+// SetTaskLocalVariableData sets the content of a binary variable of a task in its local scope. The value type
+// may be ValueTypeBytes or ValueTypeFile, the content is streamed to the engine.
+func SetTaskLocalVariableData(ctx context.Context, id, name, valueType string, content io.Reader) error {
+	return setVariableData(ctx, fmt.Sprintf("task/%s/localVariables", id), name, valueType, content)
+}
+
 // ClaimTask claims a task for a specific user.
 func ClaimTask(ctx context.Context, id, userId string) error {
 	var uri string
@@ -658,32 +1056,27 @@ exec:
 	return err
 }
 
-// GetTaskComments gets the comments for a task by id.
-func GetTaskComments(ctx context.Context, id string) ([]*Comment, error) {
+// CompleteTaskWithVariables completes a task and updates process variables. Returns the
+// process variables, which were used by the process instance during execution.
+func CompleteTaskWithVariables(ctx context.Context, id string, variables map[string]*Variable) (map[string]*Variable, error) {
 	var uri string
 	var err error
 
-	result := make([]*Comment, 0)
+	data := make(map[string]interface{})
 
-	uri = fmt.Sprintf("%s/%s/task/%s/comment", url, path, id)
-	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+	data["variables"] = variables
+	data["withVariablesInReturn"] = true
+
+	payload, err := json.Marshal(data)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return result, err
-}
-
-// GetTaskComment retrieves a task comment by task id and comment id.
-func GetTaskComment(ctx context.Context, id, commentId string) (*Comment, error) {
-	var uri string
-	var err error
-
-	result := new(Comment)
+	result := make(map[string]*Variable, 0)
 
-	uri = fmt.Sprintf("%s/%s/task/%s/comment/%s", url, path, id, commentId)
-	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+	uri = fmt.Sprintf("%s/%s/task/%s/complete", url, path, id)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), &result)
 
 	if err != nil {
 		return nil, err
@@ -692,39 +1085,1981 @@ func GetTaskComment(ctx context.Context, id, commentId string) (*Comment, error)
 	return result, err
 }
 
-// CreateTaskComment creates a comment for a task by id.
-func CreateTaskComment(ctx context.Context, id, message string) (*Comment, error) {
+// CreateTask creates a new task which does not belong to a process instance.
+func CreateTask(ctx context.Context, task *TaskUpdate) error {
 	var uri string
 	var err error
 
-	payload, err := json.Marshal(&map[string]string{"message": message})
+	payload, err := json.Marshal(task)
 
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	result := new(Comment)
-
-	uri = fmt.Sprintf("%s/%s/task/%s/comment/create", url, path, id)
-	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), result)
-
-	if err != nil {
-		return nil, err
-	}
+	uri = fmt.Sprintf("%s/%s/task/create", url, path)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), nil)
 
-	return result, err
+	return err
 }
 
-// GetTenants query for a list of tenants using a list of parameters. The size of the result
-// set can be retrieved by using the GetTenantsCount method.
-func GetTenants(ctx context.Context) ([]*Tenant, error) {
+// UpdateTask updates a task by id. All properties of the task are replaced, properties
+// which are not set in the TaskUpdate are cleared.
+func UpdateTask(ctx context.Context, id string, task *TaskUpdate) error {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(task)
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/task/%s", url, path, id)
+	err = client.send(ctx, uri, http.MethodPut, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// SetTaskAssignee changes the assignee of a task to a specific user. The difference with the
+// ClaimTask method is that this method does not check if the task already has a user assigned to it.
+func SetTaskAssignee(ctx context.Context, id, userId string) error {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(&map[string]string{"userId": userId})
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/task/%s/assignee", url, path, id)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// SetTaskOwner changes the owner of a task by id. The task is retrieved and updated with
+// all other properties left unchanged.
+func SetTaskOwner(ctx context.Context, id, userId string) error {
+	task, err := GetTask(ctx, id)
+
+	if err != nil {
+		return err
+	}
+
+	data := taskUpdate(task)
+	data.Owner = userId
+
+	return UpdateTask(ctx, id, data)
+}
+
+// SetTaskPriority changes the priority of a task by id. The task is retrieved and updated with
+// all other properties left unchanged.
+func SetTaskPriority(ctx context.Context, id string, priority int) error {
+	task, err := GetTask(ctx, id)
+
+	if err != nil {
+		return err
+	}
+
+	data := taskUpdate(task)
+	data.Priority = priority
+
+	return UpdateTask(ctx, id, data)
+}
+
+// DeleteTask removes a task by id. Only tasks which do not belong to a process instance can be deleted.
+func DeleteTask(ctx context.Context, id string) error {
+	var uri string
+	var err error
+
+	uri = fmt.Sprintf("%s/%s/task/%s", url, path, id)
+	err = client.send(ctx, uri, http.MethodDelete, "application/json", nil, nil)
+
+	return err
+}
+
+// taskUpdate returns the mutable properties of a task.
+func taskUpdate(task *Task) *TaskUpdate {
+	return &TaskUpdate{
+		Name:            task.Name,
+		Description:     task.Description,
+		Assignee:        task.Assignee,
+		Owner:           task.Owner,
+		DelegationState: task.DelegationState,
+		Due:             task.Due,
+		FollowUp:        task.FollowUp,
+		Priority:        task.Priority,
+		ParentTaskId:    task.ParentTaskId,
+		CaseInstanceId:  task.CaseInstanceId,
+		TenantId:        task.TenantId,
+	}
+}
+
+// GetTaskIdentityLinks gets the identity links for a task by id, which are the users and groups that
+// are in some relation to it (including assignee and owner). If kind is not empty, only links of
+// the given type are returned.
+func GetTaskIdentityLinks(ctx context.Context, id, kind string) ([]*IdentityLink, error) {
+	var uri string
+	var params string
+	var err error
+
+	params, err = encode(&IdentityLink{Type: kind}, 0, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*IdentityLink, 0)
+
+	uri = fmt.Sprintf("%s/%s/task/%s/identity-links%s", url, path, id, params)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// AddTaskIdentityLink adds an identity link to a task by id. Can be used to link any user or group
+// to a task and specify a relation.
+func AddTaskIdentityLink(ctx context.Context, id string, link *IdentityLink) error {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(link)
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/task/%s/identity-links", url, path, id)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// DeleteTaskIdentityLink removes an identity link from a task by id.
+func DeleteTaskIdentityLink(ctx context.Context, id string, link *IdentityLink) error {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(link)
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/task/%s/identity-links/delete", url, path, id)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// SyncTaskCandidates changes the candidate users and groups of a task by id to the given lists.
+// Only the links which differ from the current candidates are added or deleted, users before
+// groups, each sorted by id. All links are added before any is deleted, so a failure leaves the
+// task with at least its previous candidates.
+func SyncTaskCandidates(ctx context.Context, id string, users, groups []string) error {
+	links, err := GetTaskIdentityLinks(ctx, id, IdentityLinkCandidate)
+
+	if err != nil {
+		return err
+	}
+
+	current := make(map[IdentityLink]bool)
+	desired := make(map[IdentityLink]bool)
+
+	for _, v := range links {
+		current[IdentityLink{UserId: v.UserId, GroupId: v.GroupId, Type: IdentityLinkCandidate}] = true
+	}
+
+	for _, v := range users {
+		desired[IdentityLink{UserId: v, Type: IdentityLinkCandidate}] = true
+	}
+
+	for _, v := range groups {
+		desired[IdentityLink{GroupId: v, Type: IdentityLinkCandidate}] = true
+	}
+
+	for _, link := range difference(desired, current) {
+		if err = AddTaskIdentityLink(ctx, id, link); err != nil {
+			return err
+		}
+	}
+
+	for _, link := range difference(current, desired) {
+		if err = DeleteTaskIdentityLink(ctx, id, link); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// difference returns the identity links of a which are not in b, users before groups, each sorted
+// by id.
+func difference(a, b map[IdentityLink]bool) []*IdentityLink {
+	result := make([]*IdentityLink, 0)
+
+	for link := range a {
+		if !b[link] {
+			link := link
+			result = append(result, &link)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		x, y := result[i], result[j]
+
+		if (x.GroupId == "") != (y.GroupId == "") {
+			return x.GroupId == ""
+		}
+
+		return x.UserId+x.GroupId < y.UserId+y.GroupId
+	})
+
+	return result
+}
+
+// GetTaskAttachments gets the attachments for a task by id. Attachments are stored in the history,
+// so the attachments of completed tasks can be retrieved as well.
+func GetTaskAttachments(ctx context.Context, id string) ([]*Attachment, error) {
+	var uri string
+	var err error
+
+	result := make([]*Attachment, 0)
+
+	uri = fmt.Sprintf("%s/%s/task/%s/attachment", url, path, id)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetTaskAttachment retrieves a task attachment by task id and attachment id. Attachments of
+// completed tasks can be retrieved as well.
+func GetTaskAttachment(ctx context.Context, id, attachmentId string) (*Attachment, error) {
+	var uri string
+	var err error
+
+	result := new(Attachment)
+
+	uri = fmt.Sprintf("%s/%s/task/%s/attachment/%s", url, path, id, attachmentId)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetTaskAttachmentData retrieves the binary content of a task attachment by task id and attachment
+// id. Attachments of completed tasks can be retrieved as well. The caller is responsible for closing
+// the returned reader.
+func GetTaskAttachmentData(ctx context.Context, id, attachmentId string) (io.ReadCloser, error) {
+	var uri string
+
+	uri = fmt.Sprintf("%s/%s/task/%s/attachment/%s/data", url, path, id, attachmentId)
+
+	return client.stream(ctx, uri, http.MethodGet, "application/octet-stream", nil)
+}
+
+// CreateTaskAttachment creates an attachment for a task by id. The attachment either references
+// remote content by the url of the given attachment or carries the given content, which is streamed
+// to the engine. Name, description and type of the attachment are taken from the given attachment.
+func CreateTaskAttachment(ctx context.Context, id string, attachment *Attachment, content io.Reader) (*Attachment, error) {
+	var uri string
+	var err error
+
+	if attachment == nil {
+		return nil, fmt.Errorf("attachment is nil")
+	}
+
+	fields := make(map[string]string)
+
+	fields["attachment-name"] = attachment.Name
+	fields["attachment-description"] = attachment.Description
+	fields["attachment-type"] = attachment.Type
+
+	if attachment.Url != "" {
+		fields["url"] = attachment.Url
+	}
+
+	result := new(Attachment)
+
+	uri = fmt.Sprintf("%s/%s/task/%s/attachment/create", url, path, id)
+	err = client.upload(ctx, uri, fields, "content", attachment.Name, content, result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// DeleteTaskAttachment removes an attachment from a task by task id and attachment id.
+func DeleteTaskAttachment(ctx context.Context, id, attachmentId string) error {
+	var uri string
+	var err error
+
+	uri = fmt.Sprintf("%s/%s/task/%s/attachment/%s", url, path, id, attachmentId)
+	err = client.send(ctx, uri, http.MethodDelete, "application/json", nil, nil)
+
+	return err
+}
+
+// GetTaskFormKey retrieves the form key for a task. The form key corresponds to the FormData#formKey
+// property in the engine. This key can be used to do task-specific form rendering in client applications.
+func GetTaskFormKey(ctx context.Context, id string) (*FormKey, error) {
+	var uri string
+	var err error
+
+	result := new(FormKey)
+
+	uri = fmt.Sprintf("%s/%s/task/%s/form", url, path, id)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetDeployedTaskForm retrieves the deployed form that is referenced from a given task, e.g. the
+// JSON of a Camunda Form or an embedded HTML form. The caller is responsible for closing the
+// returned reader.
+func GetDeployedTaskForm(ctx context.Context, id string) (io.ReadCloser, error) {
+	var uri string
+
+	uri = fmt.Sprintf("%s/%s/task/%s/deployed-form", url, path, id)
+
+	return client.stream(ctx, uri, http.MethodGet, "application/json", nil)
+}
+
+// GetRenderedTaskForm retrieves the rendered form for a task. This method can be used to get the HTML
+// rendering of a generated task form. The caller is responsible for closing the returned reader.
+func GetRenderedTaskForm(ctx context.Context, id string) (io.ReadCloser, error) {
+	var uri string
+
+	uri = fmt.Sprintf("%s/%s/task/%s/rendered-form", url, path, id)
+
+	return client.stream(ctx, uri, http.MethodGet, "text/html", nil)
+}
+
+// GetTaskFormVariables retrieves the form variables for a task. The form variables take form data
+// specified on the task into account. If names is not empty, only the variables with the given
+// names are returned.
+func GetTaskFormVariables(ctx context.Context, id string, names []string, deserializeValues bool) (map[string]*Variable, error) {
+	var uri string
+	var params string
+	var err error
+
+	query := make(map[string]interface{})
+
+	query["deserializeValues"] = deserializeValues
+
+	if len(names) > 0 {
+		query["variableNames"] = names
+	}
+
+	params, err = encode(query, 0, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*Variable, 0)
+
+	uri = fmt.Sprintf("%s/%s/task/%s/form-variables%s", url, path, id, params)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// SubmitTaskForm completes a task and updates process variables using a form submit. Returns the
+// process variables, which were used by the process instance during execution.
+func SubmitTaskForm(ctx context.Context, id string, variables map[string]*Variable) (map[string]*Variable, error) {
+	var uri string
+	var err error
+
+	data := make(map[string]interface{})
+
+	data["variables"] = variables
+	data["withVariablesInReturn"] = true
+
+	payload, err := json.Marshal(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*Variable, 0)
+
+	uri = fmt.Sprintf("%s/%s/task/%s/submit-form", url, path, id)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetTaskComments gets the comments for a task by id.
+func GetTaskComments(ctx context.Context, id string) ([]*Comment, error) {
+	var uri string
+	var err error
+
+	result := make([]*Comment, 0)
+
+	uri = fmt.Sprintf("%s/%s/task/%s/comment", url, path, id)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetTaskComment retrieves a task comment by task id and comment id.
+func GetTaskComment(ctx context.Context, id, commentId string) (*Comment, error) {
+	var uri string
+	var err error
+
+	result := new(Comment)
+
+	uri = fmt.Sprintf("%s/%s/task/%s/comment/%s", url, path, id, commentId)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// CreateTaskComment creates a comment for a task by id.
+func CreateTaskComment(ctx context.Context, id, message string) (*Comment, error) {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(&map[string]string{"message": message})
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(Comment)
+
+	uri = fmt.Sprintf("%s/%s/task/%s/comment/create", url, path, id)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// UpdateTaskComment updates the message of a task comment by task id and comment id.
+func UpdateTaskComment(ctx context.Context, id, commentId, message string) error {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(&map[string]string{"id": commentId, "message": message})
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/task/%s/comment", url, path, id)
+	err = client.send(ctx, uri, http.MethodPut, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// DeleteTaskComment deletes a task comment by task id and comment id.
+func DeleteTaskComment(ctx context.Context, id, commentId string) error {
+	var uri string
+	var err error
+
+	uri = fmt.Sprintf("%s/%s/task/%s/comment/%s", url, path, id, commentId)
+	err = client.send(ctx, uri, http.MethodDelete, "application/json", nil, nil)
+
+	return err
+}
+
+// DeleteTaskComments deletes all comments of a task by id.
+func DeleteTaskComments(ctx context.Context, id string) error {
+	var uri string
+	var err error
+
+	uri = fmt.Sprintf("%s/%s/task/%s/comment", url, path, id)
+	err = client.send(ctx, uri, http.MethodDelete, "application/json", nil, nil)
+
+	return err
+}
+
+// GetProcessInstanceComments gets the comments for a process instance by id.
+func GetProcessInstanceComments(ctx context.Context, id string) ([]*Comment, error) {
+	var uri string
+	var err error
+
+	result := make([]*Comment, 0)
+
+	uri = fmt.Sprintf("%s/%s/process-instance/%s/comment", url, path, id)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// UpdateProcessInstanceComment updates the message of a process instance comment by process
+// instance id and comment id.
+func UpdateProcessInstanceComment(ctx context.Context, id, commentId, message string) error {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(&map[string]string{"id": commentId, "message": message})
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/process-instance/%s/comment", url, path, id)
+	err = client.send(ctx, uri, http.MethodPut, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// DeleteProcessInstanceComment deletes a process instance comment by process instance id and comment id.
+func DeleteProcessInstanceComment(ctx context.Context, id, commentId string) error {
+	var uri string
+	var err error
+
+	uri = fmt.Sprintf("%s/%s/process-instance/%s/comment/%s", url, path, id, commentId)
+	err = client.send(ctx, uri, http.MethodDelete, "application/json", nil, nil)
+
+	return err
+}
+
+// DeleteProcessInstanceComments deletes all comments of a process instance by id.
+func DeleteProcessInstanceComments(ctx context.Context, id string) error {
+	var uri string
+	var err error
+
+	uri = fmt.Sprintf("%s/%s/process-instance/%s/comment", url, path, id)
+	err = client.send(ctx, uri, http.MethodDelete, "application/json", nil, nil)
+
+	return err
+}
+
+// GetProcessInstanceTaskComments gets the comments of all tasks of a process instance, including
+// completed tasks. The comments are ordered by the time they were created.
+func GetProcessInstanceTaskComments(ctx context.Context, processInstanceId string) ([]*Comment, error) {
+	tasks, err := GetTasksHistory(ctx, processInstanceId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Comment, 0)
+
+	for _, task := range tasks {
+		comments, err := GetTaskComments(ctx, task.Id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, comments...)
+	}
+
+	times := make(map[*Comment]time.Time, len(result))
+
+	for _, v := range result {
+		if times[v], err = v.Timestamp(); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return times[result[i]].Before(times[result[j]])
+	})
+
+	return result, nil
+}
+
+// GetFilters queries for a list of filters using a list of parameters. If itemCount is true, the
+// number of items matched by each filter is returned as well. The size of the result set can be
+// retrieved by using the GetFiltersCount method.
+func GetFilters(ctx context.Context, query *FilterQuery, itemCount bool, firstResult, maxResults int) ([]*Filter, error) {
+	var uri string
+	var params string
+	var err error
+
+	params, err = encode(query, firstResult, maxResults)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Filter, 0)
+
+	uri = fmt.Sprintf("%s/%s/filter%s", url, path, params)
+
+	if itemCount {
+		uri = fmt.Sprintf("%s%sitemCount=true", uri, separator(params))
+	}
+
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetFiltersCount queries for the number of filters that fulfill given parameters.
+func GetFiltersCount(ctx context.Context, query *FilterQuery) (int, error) {
+	var uri string
+	var params string
+	var err error
+
+	params, err = encode(query, 0, 0)
+
+	if err != nil {
+		return 0, err
+	}
+
+	result := new(Count)
+
+	uri = fmt.Sprintf("%s/%s/filter/count%s", url, path, params)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, result)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.Count, err
+}
+
+// GetFilter retrieves a single filter by id. If itemCount is true, the number of items matched
+// by the filter is returned as well.
+func GetFilter(ctx context.Context, id string, itemCount bool) (*Filter, error) {
+	var uri string
+	var err error
+
+	result := new(Filter)
+
+	uri = fmt.Sprintf("%s/%s/filter/%s?itemCount=%t", url, path, id, itemCount)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// CreateFilter creates a new filter.
+func CreateFilter(ctx context.Context, filter *Filter) (*Filter, error) {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(filter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(Filter)
+
+	uri = fmt.Sprintf("%s/%s/filter/create", url, path)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// UpdateFilter updates an existing filter by id.
+func UpdateFilter(ctx context.Context, id string, filter *Filter) error {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(filter)
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/filter/%s", url, path, id)
+	err = client.send(ctx, uri, http.MethodPut, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// DeleteFilter deletes a filter by id.
+func DeleteFilter(ctx context.Context, id string) error {
+	var uri string
+	var err error
+
+	uri = fmt.Sprintf("%s/%s/filter/%s", url, path, id)
+	err = client.send(ctx, uri, http.MethodDelete, "application/json", nil, nil)
+
+	return err
+}
+
+// ExecuteFilter executes the saved query of a task filter by id and returns the result list. The
+// extra query, e.g. a map of task query properties, is merged into the saved query and may be nil.
+func ExecuteFilter(ctx context.Context, id string, extra interface{}, firstResult, maxResults int) ([]*Task, error) {
+	var uri string
+	var params string
+	var err error
+
+	payload, err := filterQuery(extra)
+
+	if err != nil {
+		return nil, err
+	}
+
+	params, err = encode(nil, firstResult, maxResults)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Task, 0)
+
+	uri = fmt.Sprintf("%s/%s/filter/%s/list%s", url, path, id, params)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// ExecuteFilterSingle executes the saved query of a task filter by id and returns the single result.
+// The extra query is merged into the saved query and may be nil. When the query has no result, the
+// engine responds with no content and the returned task is nil.
+func ExecuteFilterSingle(ctx context.Context, id string, extra interface{}) (*Task, error) {
+	var uri string
+	var err error
+
+	payload, err := filterQuery(extra)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result *Task
+
+	uri = fmt.Sprintf("%s/%s/filter/%s/singleResult", url, path, id)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// ExecuteFilterCount executes the saved query of a task filter by id and returns the number of
+// results. The extra query is merged into the saved query and may be nil.
+func ExecuteFilterCount(ctx context.Context, id string, extra interface{}) (int, error) {
+	var uri string
+	var err error
+
+	payload, err := filterQuery(extra)
+
+	if err != nil {
+		return 0, err
+	}
+
+	result := new(Count)
+
+	uri = fmt.Sprintf("%s/%s/filter/%s/count", url, path, id)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), result)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.Count, err
+}
+
+// filterQuery marshals the extra query of a filter execution. A missing query is sent as an empty object.
+func filterQuery(extra interface{}) ([]byte, error) {
+	if extra == nil {
+		return []byte("{}"), nil
+	}
+
+	return json.Marshal(extra)
+}
+
+// GetTenants query for a list of tenants using a list of parameters. The size of the result
+// set can be retrieved by using the GetTenantsCount method.
+func GetTenants(ctx context.Context) ([]*Tenant, error) {
 	var uri string
 	var err error
 
 	result := make([]*Tenant, 0)
 
-	uri = fmt.Sprintf("%s/%s/tenant", url, path)
-	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+	uri = fmt.Sprintf("%s/%s/tenant", url, path)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetTenantsCount ...
+func GetTenantsCount(ctx context.Context) {
+
+}
+
+// GetTenant retrieves a Tenant.
+func GetTenant(ctx context.Context, id string) (*Tenant, error) {
+	var uri string
+	var err error
+
+	result := new(Tenant)
+
+	uri = fmt.Sprintf("%s/%s/tenant/%s", url, path, id)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// CreateTenant creates a new Tenant.
+func CreateTenant(ctx context.Context, tenant *Tenant) error {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(tenant)
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/tenant/create", url, path)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// UpdateTenant updates a given Tenant.
+func UpdateTenant(ctx context.Context, id string, tenant *Tenant) error {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(tenant)
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/tenant/%s", url, path, id)
+	err = client.send(ctx, uri, http.MethodPut, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// DeleteTenant deletes a tenant by id.
+func DeleteTenant(ctx context.Context, id string) error {
+	var uri string
+	var err error
+
+	uri = fmt.Sprintf("%s/%s/tenant/%s", url, path, id)
+	err = client.send(ctx, uri, http.MethodDelete, "application/json", nil, nil)
+
+	return err
+}
+
+// GetUsers queries for a list of users using a list of parameters. The size of the result
+// set can be retrieved by using the GetUsersCount method.
+func GetUsers(ctx context.Context, query *UserQuery, firstResult, maxResults int) ([]*User, error) {
+	var uri string
+	var params string
+	var err error
+
+	params, err = encode(query, firstResult, maxResults)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*User, 0)
+
+	uri = fmt.Sprintf("%s/%s/user%s", url, path, params)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetUsersCount queries for the number of users that fulfill given parameters.
+func GetUsersCount(ctx context.Context, query *UserQuery) (int, error) {
+	var uri string
+	var params string
+	var err error
+
+	params, err = encode(query, 0, 0)
+
+	if err != nil {
+		return 0, err
+	}
+
+	result := new(Count)
+
+	uri = fmt.Sprintf("%s/%s/user/count%s", url, path, params)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, result)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.Count, err
+}
+
+// GetUserProfile retrieves a user's profile by id.
+func GetUserProfile(ctx context.Context, id string) (*User, error) {
+	var uri string
+	var err error
+
+	result := new(User)
+
+	uri = fmt.Sprintf("%s/%s/user/%s/profile", url, path, id)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// CreateUser creates a new user with the given profile and password.
+func CreateUser(ctx context.Context, user *User, password string) error {
+	var uri string
+	var err error
+
+	data := make(map[string]interface{})
+
+	data["profile"] = user
+	data["credentials"] = &UserCredentials{Password: password}
+
+	payload, err := json.Marshal(data)
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/user/create", url, path)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// UpdateUserProfile updates the profile information of an already existing user by id.
+func UpdateUserProfile(ctx context.Context, id string, user *User) error {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(user)
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/user/%s/profile", url, path, id)
+	err = client.send(ctx, uri, http.MethodPut, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// UpdateUserCredentials updates a user's credentials (password) by id.
+func UpdateUserCredentials(ctx context.Context, id string, credentials *UserCredentials) error {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(credentials)
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/user/%s/credentials", url, path, id)
+	err = client.send(ctx, uri, http.MethodPut, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// DeleteUser deletes a user by id.
+func DeleteUser(ctx context.Context, id string) error {
+	var uri string
+	var err error
+
+	uri = fmt.Sprintf("%s/%s/user/%s", url, path, id)
+	err = client.send(ctx, uri, http.MethodDelete, "application/json", nil, nil)
+
+	return err
+}
+
+// UnlockUser unlocks a user by id, which was locked because of too many failed login attempts.
+func UnlockUser(ctx context.Context, id string) error {
+	var uri string
+	var err error
+
+	uri = fmt.Sprintf("%s/%s/user/%s/unlock", url, path, id)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", nil, nil)
+
+	return err
+}
+
+// GetGroups queries for a list of groups using a list of parameters. The size of the result
+// set can be retrieved by using the GetGroupsCount method.
+func GetGroups(ctx context.Context, query *GroupQuery, firstResult, maxResults int) ([]*Group, error) {
+	var uri string
+	var params string
+	var err error
+
+	params, err = encode(query, firstResult, maxResults)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Group, 0)
+
+	uri = fmt.Sprintf("%s/%s/group%s", url, path, params)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetGroupsCount queries for the number of groups that fulfill given parameters.
+func GetGroupsCount(ctx context.Context, query *GroupQuery) (int, error) {
+	var uri string
+	var params string
+	var err error
+
+	params, err = encode(query, 0, 0)
+
+	if err != nil {
+		return 0, err
+	}
+
+	result := new(Count)
+
+	uri = fmt.Sprintf("%s/%s/group/count%s", url, path, params)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, result)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.Count, err
+}
+
+// GetGroup retrieves a group by id.
+func GetGroup(ctx context.Context, id string) (*Group, error) {
+	var uri string
+	var err error
+
+	result := new(Group)
+
+	uri = fmt.Sprintf("%s/%s/group/%s", url, path, id)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// CreateGroup creates a new group.
+func CreateGroup(ctx context.Context, group *Group) error {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(group)
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/group/create", url, path)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// UpdateGroup updates a given group by id.
+func UpdateGroup(ctx context.Context, id string, group *Group) error {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(group)
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/group/%s", url, path, id)
+	err = client.send(ctx, uri, http.MethodPut, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// DeleteGroup deletes a group by id.
+func DeleteGroup(ctx context.Context, id string) error {
+	var uri string
+	var err error
+
+	uri = fmt.Sprintf("%s/%s/group/%s", url, path, id)
+	err = client.send(ctx, uri, http.MethodDelete, "application/json", nil, nil)
+
+	return err
+}
+
+// AddGroupMember adds a member to a group by group id and user id.
+func AddGroupMember(ctx context.Context, id, userId string) error {
+	var uri string
+	var err error
+
+	uri = fmt.Sprintf("%s/%s/group/%s/members/%s", url, path, id, userId)
+	err = client.send(ctx, uri, http.MethodPut, "application/json", nil, nil)
+
+	return err
+}
+
+// RemoveGroupMember removes a member from a group by group id and user id.
+func RemoveGroupMember(ctx context.Context, id, userId string) error {
+	var uri string
+	var err error
+
+	uri = fmt.Sprintf("%s/%s/group/%s/members/%s", url, path, id, userId)
+	err = client.send(ctx, uri, http.MethodDelete, "application/json", nil, nil)
+
+	return err
+}
+
+// AddTenantUserMember creates a membership between a tenant and a user by tenant id and user id.
+func AddTenantUserMember(ctx context.Context, id, userId string) error {
+	var uri string
+	var err error
+
+	uri = fmt.Sprintf("%s/%s/tenant/%s/user-members/%s", url, path, id, userId)
+	err = client.send(ctx, uri, http.MethodPut, "application/json", nil, nil)
+
+	return err
+}
+
+// RemoveTenantUserMember deletes a membership between a tenant and a user by tenant id and user id.
+func RemoveTenantUserMember(ctx context.Context, id, userId string) error {
+	var uri string
+	var err error
+
+	uri = fmt.Sprintf("%s/%s/tenant/%s/user-members/%s", url, path, id, userId)
+	err = client.send(ctx, uri, http.MethodDelete, "application/json", nil, nil)
+
+	return err
+}
+
+// AddTenantGroupMember creates a membership between a tenant and a group by tenant id and group id.
+func AddTenantGroupMember(ctx context.Context, id, groupId string) error {
+	var uri string
+	var err error
+
+	uri = fmt.Sprintf("%s/%s/tenant/%s/group-members/%s", url, path, id, groupId)
+	err = client.send(ctx, uri, http.MethodPut, "application/json", nil, nil)
+
+	return err
+}
+
+// RemoveTenantGroupMember deletes a membership between a tenant and a group by tenant id and group id.
+func RemoveTenantGroupMember(ctx context.Context, id, groupId string) error {
+	var uri string
+	var err error
+
+	uri = fmt.Sprintf("%s/%s/tenant/%s/group-members/%s", url, path, id, groupId)
+	err = client.send(ctx, uri, http.MethodDelete, "application/json", nil, nil)
+
+	return err
+}
+
+// GetPasswordPolicy retrieves the password policy configured in the engine.
+func GetPasswordPolicy(ctx context.Context) (*PasswordPolicy, error) {
+	var uri string
+	var err error
+
+	result := new(PasswordPolicy)
+
+	uri = fmt.Sprintf("%s/%s/identity/password-policy", url, path)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// ValidatePassword checks a password against the password policy configured in the engine. The
+// profile of the user is optional and used by rules which check the password against user data.
+func ValidatePassword(ctx context.Context, password string, profile *User) (*PasswordPolicyResult, error) {
+	var uri string
+	var err error
+
+	data := make(map[string]interface{})
+
+	data["password"] = password
+
+	if profile != nil {
+		data["profile"] = profile
+	}
+
+	payload, err := json.Marshal(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(PasswordPolicyResult)
+
+	uri = fmt.Sprintf("%s/%s/identity/password-policy", url, path)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetAuthorizations queries for a list of authorizations using a list of parameters. The size of the
+// result set can be retrieved by using the GetAuthorizationsCount method.
+func GetAuthorizations(ctx context.Context, query *AuthorizationQuery, firstResult, maxResults int) ([]*Authorization, error) {
+	var uri string
+	var params string
+	var err error
+
+	params, err = encode(query, firstResult, maxResults)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Authorization, 0)
+
+	uri = fmt.Sprintf("%s/%s/authorization%s", url, path, params)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetAuthorizationsCount queries for the number of authorizations that fulfill given parameters.
+func GetAuthorizationsCount(ctx context.Context, query *AuthorizationQuery) (int, error) {
+	var uri string
+	var params string
+	var err error
+
+	params, err = encode(query, 0, 0)
+
+	if err != nil {
+		return 0, err
+	}
+
+	result := new(Count)
+
+	uri = fmt.Sprintf("%s/%s/authorization/count%s", url, path, params)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, result)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.Count, err
+}
+
+// GetAuthorization retrieves an authorization by id.
+func GetAuthorization(ctx context.Context, id string) (*Authorization, error) {
+	var uri string
+	var err error
+
+	result := new(Authorization)
+
+	uri = fmt.Sprintf("%s/%s/authorization/%s", url, path, id)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// CreateAuthorization creates a new authorization. Either the user id or the group id of the
+// authorization must be set.
+func CreateAuthorization(ctx context.Context, authorization *Authorization) (*Authorization, error) {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(authorization)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(Authorization)
+
+	uri = fmt.Sprintf("%s/%s/authorization/create", url, path)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// UpdateAuthorization updates an authorization by id.
+func UpdateAuthorization(ctx context.Context, id string, authorization *Authorization) error {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(authorization)
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/authorization/%s", url, path, id)
+	err = client.send(ctx, uri, http.MethodPut, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// DeleteAuthorization deletes an authorization by id.
+func DeleteAuthorization(ctx context.Context, id string) error {
+	var uri string
+	var err error
+
+	uri = fmt.Sprintf("%s/%s/authorization/%s", url, path, id)
+	err = client.send(ctx, uri, http.MethodDelete, "application/json", nil, nil)
+
+	return err
+}
+
+// CheckAuthorization performs a permission check for the currently authenticated user or, if
+// userId is not empty, for the given user. The resource id may be empty to check the permission
+// for all instances of the resource type.
+func CheckAuthorization(ctx context.Context, permission, resourceName string, resourceType int, resourceId, userId string) (*AuthorizationCheck, error) {
+	var uri string
+	var params string
+	var err error
+
+	query := make(map[string]interface{})
+
+	query["permissionName"] = permission
+	query["resourceName"] = resourceName
+	query["resourceType"] = resourceType
+
+	if resourceId != "" {
+		query["resourceId"] = resourceId
+	}
+
+	if userId != "" {
+		query["userId"] = userId
+	}
+
+	params, err = encode(query, 0, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(AuthorizationCheck)
+
+	uri = fmt.Sprintf("%s/%s/authorization/check%s", url, path, params)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetUserOperations queries for user operation log entries that fulfill the given parameters. The
+// size of the result set can be retrieved by using the GetUserOperationsCount method.
+// Note that the properties of operation log entries are interpreted as restrictions on the
+// entities they apply to. That means, if a single process instance is updated, the field
+// processInstanceId is populated. If a single operation updates all process instances of the
+// same process definition, the field processInstanceId is null (a null restriction is viewed
+// as a wildcard, i.e., matches a process instance with any id) and the field processDefinitionId
+// is populated. This way, which entities were changed by a user operation can easily be reconstructed.
+func GetUserOperations(ctx context.Context, taskId string) ([]*UserOperationLog, error) {
+	var uri string
+	var err error
+
+	result := make([]*UserOperationLog, 0)
+
+	uri = fmt.Sprintf("%s/%s/history/user-operation?taskId=%s", url, path, taskId)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetUserOperationsCount ...
+func GetUserOperationsCount(ctx context.Context) {
+
+}
+
+// GetUserOperationsByProcessInstance queries for user operation log entries which are restricted
+// to the given process instance. See GetUserOperations for details on the restrictions.
+func GetUserOperationsByProcessInstance(ctx context.Context, processInstanceId string) ([]*UserOperationLog, error) {
+	var uri string
+	var err error
+
+	result := make([]*UserOperationLog, 0)
+
+	uri = fmt.Sprintf("%s/%s/history/user-operation?processInstanceId=%s", url, path, processInstanceId)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetHistoricIncidents queries for historic incidents that fulfill given parameters. The size of the
+// result set can be retrieved by using the GetHistoricIncidentsCount method.
+func GetHistoricIncidents(ctx context.Context, query *HistoricIncidentQuery, firstResult, maxResults int) ([]*HistoricIncident, error) {
+	var uri string
+	var params string
+	var err error
+
+	params, err = encode(query, firstResult, maxResults)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*HistoricIncident, 0)
+
+	uri = fmt.Sprintf("%s/%s/history/incident%s", url, path, params)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetHistoricIncidentsCount queries for the number of historic incidents that fulfill the given parameters.
+func GetHistoricIncidentsCount(ctx context.Context, query *HistoricIncidentQuery) (int, error) {
+	var uri string
+	var params string
+	var err error
+
+	params, err = encode(query, 0, 0)
+
+	if err != nil {
+		return 0, err
+	}
+
+	result := new(Count)
+
+	uri = fmt.Sprintf("%s/%s/history/incident/count%s", url, path, params)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, result)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.Count, err
+}
+
+// GetIncidents queries for incidents that fulfill given parameters. The size of the result set can be
+// retrieved by using the GetIncidentsCount method.
+func GetIncidents(ctx context.Context, query *IncidentQuery, firstResult, maxResults int) ([]*Incident, error) {
+	var uri string
+	var params string
+	var err error
+
+	params, err = encode(query, firstResult, maxResults)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Incident, 0)
+
+	uri = fmt.Sprintf("%s/%s/incident%s", url, path, params)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetIncidentsCount queries for the number of incidents that fulfill given parameters.
+func GetIncidentsCount(ctx context.Context, query *IncidentQuery) (int, error) {
+	var uri string
+	var params string
+	var err error
+
+	params, err = encode(query, 0, 0)
+
+	if err != nil {
+		return 0, err
+	}
+
+	result := new(Count)
+
+	uri = fmt.Sprintf("%s/%s/incident/count%s", url, path, params)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, result)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.Count, err
+}
+
+// GetIncident retrieves an incident by id.
+func GetIncident(ctx context.Context, id string) (*Incident, error) {
+	var uri string
+	var err error
+
+	result := new(Incident)
+
+	uri = fmt.Sprintf("%s/%s/incident/%s", url, path, id)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// ResolveIncident resolves an incident by id. Only custom incidents can be resolved, incidents
+// of type failedJob and failedExternalTask are resolved by setting the retries of the job or
+// external task.
+func ResolveIncident(ctx context.Context, id string) error {
+	var uri string
+	var err error
+
+	uri = fmt.Sprintf("%s/%s/incident/%s", url, path, id)
+	err = client.send(ctx, uri, http.MethodDelete, "application/json", nil, nil)
+
+	return err
+}
+
+// SetIncidentAnnotation sets the annotation of an incident by id.
+func SetIncidentAnnotation(ctx context.Context, id, annotation string) error {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(&map[string]string{"annotation": annotation})
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/incident/%s/annotation", url, path, id)
+	err = client.send(ctx, uri, http.MethodPut, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// ClearIncidentAnnotation clears the annotation of an incident by id.
+func ClearIncidentAnnotation(ctx context.Context, id string) error {
+	var uri string
+	var err error
+
+	uri = fmt.Sprintf("%s/%s/incident/%s/annotation", url, path, id)
+	err = client.send(ctx, uri, http.MethodDelete, "application/json", nil, nil)
+
+	return err
+}
+
+// CreateIncident creates a custom incident with given properties on the execution by id.
+func CreateIncident(ctx context.Context, executionId string, data *IncidentCreate) (*Incident, error) {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(Incident)
+
+	uri = fmt.Sprintf("%s/%s/execution/%s/create-incident", url, path, executionId)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetJobs queries for jobs that fulfill given parameters. The size of the result set can be retrieved
+// by using the GetJobsCount method.
+func GetJobs(ctx context.Context, query *JobQuery, firstResult, maxResults int) ([]*Job, error) {
+	var uri string
+	var params string
+	var err error
+
+	payload, err := json.Marshal(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	params, err = encode(nil, firstResult, maxResults)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Job, 0)
+
+	uri = fmt.Sprintf("%s/%s/job%s", url, path, params)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetJobsCount queries for the number of jobs that fulfill given parameters.
+func GetJobsCount(ctx context.Context, query *JobQuery) (int, error) {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(query)
+
+	if err != nil {
+		return 0, err
+	}
+
+	result := new(Count)
+
+	uri = fmt.Sprintf("%s/%s/job/count", url, path)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), result)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.Count, err
+}
+
+// GetJob retrieves a job by id, according to the Job interface in the engine.
+func GetJob(ctx context.Context, id string) (*Job, error) {
+	var uri string
+	var err error
+
+	result := new(Job)
+
+	uri = fmt.Sprintf("%s/%s/job/%s", url, path, id)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetJobStacktrace retrieves the exception stacktrace corresponding to the passed job id. The caller
+// is responsible for closing the returned reader.
+func GetJobStacktrace(ctx context.Context, id string) (io.ReadCloser, error) {
+	var uri string
+
+	uri = fmt.Sprintf("%s/%s/job/%s/stacktrace", url, path, id)
+
+	return client.stream(ctx, uri, http.MethodGet, "text/plain", nil)
+}
+
+// ExecuteJob executes a job by id. Note: The execution of the job happens synchronously in the same thread.
+func ExecuteJob(ctx context.Context, id string) error {
+	var uri string
+	var err error
+
+	uri = fmt.Sprintf("%s/%s/job/%s/execute", url, path, id)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", nil, nil)
+
+	return err
+}
+
+// SetJobRetries sets the retries of the job to the given number of retries by id.
+func SetJobRetries(ctx context.Context, id string, retries int) error {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(&map[string]int{"retries": retries})
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/job/%s/retries", url, path, id)
+	err = client.send(ctx, uri, http.MethodPut, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// SetJobRetriesAsync creates a batch to set retries of jobs asynchronously. The jobs are selected by
+// a list of ids and/or a job query.
+func SetJobRetriesAsync(ctx context.Context, data *JobRetries) (*Batch, error) {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(Batch)
+
+	uri = fmt.Sprintf("%s/%s/job/retries", url, path)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// SetJobDueDate updates the due date of a job by id. If cascade is true, the due dates of all
+// jobs of the same timer are updated by the same offset.
+func SetJobDueDate(ctx context.Context, id, date string, cascade bool) error {
+	var uri string
+	var err error
+
+	data := make(map[string]interface{})
+
+	data["duedate"] = date
+	data["cascade"] = cascade
+
+	payload, err := json.Marshal(data)
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/job/%s/duedate", url, path, id)
+	err = client.send(ctx, uri, http.MethodPut, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// RecalculateJobDueDate recalculates the due date of a job by id. If creationDateBased is true,
+// the due date is calculated based on the creation date of the job, otherwise on the current date.
+func RecalculateJobDueDate(ctx context.Context, id string, creationDateBased bool) error {
+	var uri string
+	var err error
+
+	uri = fmt.Sprintf("%s/%s/job/%s/duedate/recalculate?creationDateBased=%t", url, path, id, creationDateBased)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", nil, nil)
+
+	return err
+}
+
+// SetJobPriority sets the execution priority of a job by id.
+func SetJobPriority(ctx context.Context, id string, priority int) error {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(&map[string]int{"priority": priority})
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/job/%s/priority", url, path, id)
+	err = client.send(ctx, uri, http.MethodPut, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// ActivateJob activates a given job by id.
+func ActivateJob(ctx context.Context, id string) error {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(&map[string]bool{"suspended": false})
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/job/%s/suspended", url, path, id)
+	err = client.send(ctx, uri, http.MethodPut, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// SuspendJob suspends a given job by id.
+func SuspendJob(ctx context.Context, id string) error {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(&map[string]bool{"suspended": true})
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/job/%s/suspended", url, path, id)
+	err = client.send(ctx, uri, http.MethodPut, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// ActivateJobs activates the jobs of a job definition, process definition or process instance.
+// Exactly one scope of the JobSuspension must be set.
+func ActivateJobs(ctx context.Context, scope *JobSuspension) error {
+	var uri string
+	var err error
+
+	if scope == nil {
+		return fmt.Errorf("job suspension scope is nil")
+	}
+
+	data := *scope
+	data.Suspended = false
+
+	payload, err := json.Marshal(&data)
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/job/suspended", url, path)
+	err = client.send(ctx, uri, http.MethodPut, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// SuspendJobs suspends the jobs of a job definition, process definition or process instance.
+// Exactly one scope of the JobSuspension must be set.
+func SuspendJobs(ctx context.Context, scope *JobSuspension) error {
+	var uri string
+	var err error
+
+	if scope == nil {
+		return fmt.Errorf("job suspension scope is nil")
+	}
+
+	data := *scope
+	data.Suspended = true
+
+	payload, err := json.Marshal(&data)
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/job/suspended", url, path)
+	err = client.send(ctx, uri, http.MethodPut, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// DeleteJob deletes a job by id.
+func DeleteJob(ctx context.Context, id string) error {
+	var uri string
+	var err error
+
+	uri = fmt.Sprintf("%s/%s/job/%s", url, path, id)
+	err = client.send(ctx, uri, http.MethodDelete, "application/json", nil, nil)
+
+	return err
+}
+
+// GetJobDefinitions queries for job definitions that fulfill given parameters. The size of the result
+// set can be retrieved by using the GetJobDefinitionsCount method.
+func GetJobDefinitions(ctx context.Context, query *JobDefinitionQuery, firstResult, maxResults int) ([]*JobDefinition, error) {
+	var uri string
+	var params string
+	var err error
+
+	payload, err := json.Marshal(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	params, err = encode(nil, firstResult, maxResults)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*JobDefinition, 0)
+
+	uri = fmt.Sprintf("%s/%s/job-definition%s", url, path, params)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), &result)
 
 	if err != nil {
 		return nil, err
@@ -733,19 +3068,37 @@ func GetTenants(ctx context.Context) ([]*Tenant, error) {
 	return result, err
 }
 
-// GetTenantsCount ...
-func GetTenantsCount(ctx context.Context) {
+// GetJobDefinitionsCount queries for the number of job definitions that fulfill given parameters.
+func GetJobDefinitionsCount(ctx context.Context, query *JobDefinitionQuery) (int, error) {
+	var uri string
+	var err error
 
+	payload, err := json.Marshal(query)
+
+	if err != nil {
+		return 0, err
+	}
+
+	result := new(Count)
+
+	uri = fmt.Sprintf("%s/%s/job-definition/count", url, path)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), result)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.Count, err
 }
 
-// GetTenant retrieves a Tenant.
-func GetTenant(ctx context.Context, id string) (*Tenant, error) {
+// GetJobDefinition retrieves a job definition by id, according to the JobDefinition interface in the engine.
+func GetJobDefinition(ctx context.Context, id string) (*JobDefinition, error) {
 	var uri string
 	var err error
 
-	result := new(Tenant)
+	result := new(JobDefinition)
 
-	uri = fmt.Sprintf("%s/%s/tenant/%s", url, path, id)
+	uri = fmt.Sprintf("%s/%s/job-definition/%s", url, path, id)
 	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
 
 	if err != nil {
@@ -755,66 +3108,174 @@ func GetTenant(ctx context.Context, id string) (*Tenant, error) {
 	return result, err
 }
 
-// CreateTenant creates a new Tenant.
-func CreateTenant(ctx context.Context, tenant *Tenant) error {
+// SetJobDefinitionPriority overrides the execution priority of the jobs created for a job definition
+// by id. If includeJobs is true, the priority of all existing jobs of the definition is updated as well.
+func SetJobDefinitionPriority(ctx context.Context, id string, priority int, includeJobs bool) error {
 	var uri string
 	var err error
 
-	payload, err := json.Marshal(tenant)
+	data := make(map[string]interface{})
+
+	data["priority"] = priority
+	data["includeJobs"] = includeJobs
+
+	payload, err := json.Marshal(data)
 
 	if err != nil {
 		return err
 	}
 
-	uri = fmt.Sprintf("%s/%s/tenant/create", url, path)
-	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), nil)
+	uri = fmt.Sprintf("%s/%s/job-definition/%s/jobPriority", url, path, id)
+	err = client.send(ctx, uri, http.MethodPut, "application/json", bytes.NewReader(payload), nil)
 
 	return err
 }
 
-// UpdateTenant updates a given Tenant.
-func UpdateTenant(ctx context.Context, id string, tenant *Tenant) error {
+// ClearJobDefinitionPriority clears the overriding execution priority of a job definition by id. New
+// jobs of the definition are created with the priority defined in the process model.
+func ClearJobDefinitionPriority(ctx context.Context, id string) error {
 	var uri string
 	var err error
 
-	payload, err := json.Marshal(tenant)
+	payload, err := json.Marshal(&map[string]interface{}{"priority": nil})
 
 	if err != nil {
 		return err
 	}
 
-	uri = fmt.Sprintf("%s/%s/tenant/%s", url, path, id)
+	uri = fmt.Sprintf("%s/%s/job-definition/%s/jobPriority", url, path, id)
 	err = client.send(ctx, uri, http.MethodPut, "application/json", bytes.NewReader(payload), nil)
 
 	return err
 }
 
-// DeleteTenant deletes a tenant by id.
-func DeleteTenant(ctx context.Context, id string) error {
+// SetJobDefinitionRetries sets the number of retries of all failed jobs associated with the given job definition id.
+func SetJobDefinitionRetries(ctx context.Context, id string, retries int) error {
 	var uri string
 	var err error
 
-	uri = fmt.Sprintf("%s/%s/tenant/%s", url, path, id)
-	err = client.send(ctx, uri, http.MethodDelete, "application/json", nil, nil)
+	payload, err := json.Marshal(&map[string]int{"retries": retries})
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/job-definition/%s/retries", url, path, id)
+	err = client.send(ctx, uri, http.MethodPut, "application/json", bytes.NewReader(payload), nil)
 
 	return err
 }
 
-// GetUserOperations queries for user operation log entries that fulfill the given parameters. The
-// size of the result set can be retrieved by using the GetUserOperationsCount method.
-// Note that the properties of operation log entries are interpreted as restrictions on the
-// entities they apply to. That means, if a single process instance is updated, the field
-// processInstanceId is populated. If a single operation updates all process instances of the
-// same process definition, the field processInstanceId is null (a null restriction is viewed
-// as a wildcard, i.e., matches a process instance with any id) and the field processDefinitionId
-// is populated. This way, which entities were changed by a user operation can easily be reconstructed.
-func GetUserOperations(ctx context.Context, taskId string) ([]*UserOperationLog, error) {
+// ActivateJobDefinition activates a given job definition by id. If includeJobs is true, the
+// jobs of the definition are activated as well.
+func ActivateJobDefinition(ctx context.Context, id, date string, includeJobs bool) error {
 	var uri string
 	var err error
 
-	result := make([]*UserOperationLog, 0)
+	data := make(map[string]interface{})
 
-	uri = fmt.Sprintf("%s/%s/history/user-operation?taskId=%s", url, path, taskId)
+	data["suspended"] = false
+	data["includeJobs"] = includeJobs
+	data["executionDate"] = date
+
+	payload, err := json.Marshal(data)
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/job-definition/%s/suspended", url, path, id)
+	err = client.send(ctx, uri, http.MethodPut, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// SuspendJobDefinition suspends a given job definition by id. If includeJobs is true, the
+// jobs of the definition are suspended as well.
+func SuspendJobDefinition(ctx context.Context, id, date string, includeJobs bool) error {
+	var uri string
+	var err error
+
+	data := make(map[string]interface{})
+
+	data["suspended"] = true
+	data["includeJobs"] = includeJobs
+	data["executionDate"] = date
+
+	payload, err := json.Marshal(data)
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/job-definition/%s/suspended", url, path, id)
+	err = client.send(ctx, uri, http.MethodPut, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// GetExternalTasks queries for the external tasks that fulfill given parameters. The size of the
+// result set can be retrieved by using the GetExternalTasksCount method.
+func GetExternalTasks(ctx context.Context, query *ExternalTaskQuery, firstResult, maxResults int) ([]*ExternalTask, error) {
+	var uri string
+	var params string
+	var err error
+
+	payload, err := json.Marshal(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	params, err = encode(nil, firstResult, maxResults)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*ExternalTask, 0)
+
+	uri = fmt.Sprintf("%s/%s/external-task%s", url, path, params)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetExternalTasksCount queries for the number of external tasks that fulfill given parameters.
+func GetExternalTasksCount(ctx context.Context, query *ExternalTaskQuery) (int, error) {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(query)
+
+	if err != nil {
+		return 0, err
+	}
+
+	result := new(Count)
+
+	uri = fmt.Sprintf("%s/%s/external-task/count", url, path)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), result)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.Count, err
+}
+
+// GetExternalTask retrieves an external task by id, corresponding to the ExternalTask interface in the engine.
+func GetExternalTask(ctx context.Context, id string) (*ExternalTask, error) {
+	var uri string
+	var err error
+
+	result := new(ExternalTask)
+
+	uri = fmt.Sprintf("%s/%s/external-task/%s", url, path, id)
 	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
 
 	if err != nil {
@@ -824,7 +3285,93 @@ func GetUserOperations(ctx context.Context, taskId string) ([]*UserOperationLog,
 	return result, err
 }
 
-// GetUserOperationsCount ...
-func GetUserOperationsCount(ctx context.Context) {
+// SetExternalTaskRetries sets the number of retries left to execute an external task by id. If
+// retries are set to 0, an incident is created.
+func SetExternalTaskRetries(ctx context.Context, id string, retries int) error {
+	var uri string
+	var err error
 
+	payload, err := json.Marshal(&map[string]int{"retries": retries})
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/external-task/%s/retries", url, path, id)
+	err = client.send(ctx, uri, http.MethodPut, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// SetExternalTaskRetriesAsync creates a batch to set retries of external tasks asynchronously. The
+// external tasks are selected by a list of ids and/or queries.
+func SetExternalTaskRetriesAsync(ctx context.Context, data *ExternalTaskRetries) (*Batch, error) {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(Batch)
+
+	uri = fmt.Sprintf("%s/%s/external-task/retries-async", url, path)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// LockExternalTask locks an external task by id for a worker. The lock duration is given in
+// milliseconds.
+func LockExternalTask(ctx context.Context, id, workerId string, lockDuration int) error {
+	var uri string
+	var err error
+
+	data := make(map[string]interface{})
+
+	data["workerId"] = workerId
+	data["lockDuration"] = lockDuration
+
+	payload, err := json.Marshal(data)
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/external-task/%s/lock", url, path, id)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+// CompleteExternalTask completes an external task by id and updates process variables. The task
+// must be locked by the given worker.
+func CompleteExternalTask(ctx context.Context, id, workerId string, variables map[string]*Variable) error {
+	var uri string
+	var err error
+
+	data := make(map[string]interface{})
+
+	data["workerId"] = workerId
+
+	if variables != nil {
+		data["variables"] = variables
+	}
+
+	payload, err := json.Marshal(data)
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/external-task/%s/complete", url, path, id)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), nil)
+
+	return err
 }
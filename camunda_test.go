@@ -1 +1,735 @@
 package camunda
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// serve configures the client for a test server handling the requests with the given handler.
+func serve(t *testing.T, handler http.HandlerFunc) {
+	s := httptest.NewServer(handler)
+
+	t.Cleanup(s.Close)
+
+	Configure(s.URL, "engine-rest")
+}
+
+// parts returns the parts of a multipart form in their order, as name=value for fields and as
+// name=@filename:content for files.
+func parts(content []byte, boundary string) ([]byte, error) {
+	result := make([]string, 0)
+	reader := multipart.NewReader(bytes.NewReader(content), boundary)
+
+	for {
+		part, err := reader.NextPart()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := ioutil.ReadAll(part)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if part.FileName() != "" {
+			result = append(result, fmt.Sprintf("%s=@%s:%s", part.FormName(), part.FileName(), value))
+		} else {
+			result = append(result, fmt.Sprintf("%s=%s", part.FormName(), value))
+		}
+	}
+
+	return []byte(strings.Join(result, " ")), nil
+}
+
+// record serves the given responses by method and path relative to the engine, e.g. "GET /user",
+// and returns a function which returns and resets the recorded requests as method, path with
+// query and body, multipart forms by their parts. Requests without a response are answered with
+// no content.
+func record(t *testing.T, responses map[string]string) func() []string {
+	var mu sync.Mutex
+
+	requests := make([]string, 0)
+
+	serve(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		body, err := ioutil.ReadAll(r.Body)
+
+		if media, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); err == nil && media == "multipart/form-data" {
+			body, err = parts(body, params["boundary"])
+		}
+
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		uri := strings.TrimPrefix(r.URL.RequestURI(), "/engine-rest")
+
+		requests = append(requests, strings.TrimSpace(r.Method+" "+uri+" "+string(body)))
+
+		response, ok := responses[r.Method+" "+strings.TrimPrefix(r.URL.Path, "/engine-rest")]
+
+		if !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	})
+
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		result := requests
+		requests = make([]string, 0)
+
+		return result
+	}
+}
+
+func TestHistoricDetailsCount(t *testing.T) {
+	ctx := context.Background()
+
+	var mu sync.Mutex
+
+	bodies := make(map[string]map[string]interface{})
+
+	serve(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		data := make(map[string]interface{})
+
+		if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(&data) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		bodies[r.URL.Path] = data
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/engine-rest/history/detail/count" {
+			_, _ = w.Write([]byte(`{"count":2}`))
+		} else {
+			_, _ = w.Write([]byte(`[]`))
+		}
+	})
+
+	query := &HistoricDetailQuery{ProcessInstanceId: "process-instance-1", VariableUpdates: true}
+
+	_, err := GetHistoricDetails(ctx, query, false, 0, 0)
+
+	require.NoError(t, err)
+
+	count, err := GetHistoricDetailsCount(ctx, query)
+
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	require.Equal(t, bodies["/engine-rest/history/detail"], bodies["/engine-rest/history/detail/count"])
+	require.Equal(t, "process-instance-1", bodies["/engine-rest/history/detail/count"]["processInstanceId"])
+}
+
+func TestJobSuspension(t *testing.T) {
+	ctx := context.Background()
+
+	var mu sync.Mutex
+
+	bodies := make([]map[string]interface{}, 0)
+
+	serve(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		data := make(map[string]interface{})
+
+		if r.Method != http.MethodPut || r.URL.Path != "/engine-rest/job/suspended" || json.NewDecoder(r.Body).Decode(&data) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		bodies = append(bodies, data)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	scope := &JobSuspension{ProcessDefinitionKey: "invoice"}
+
+	require.NoError(t, SuspendJobs(ctx, scope))
+	require.NoError(t, ActivateJobs(ctx, scope))
+	require.Equal(t, []map[string]interface{}{
+		{"processDefinitionKey": "invoice", "suspended": true},
+		{"processDefinitionKey": "invoice", "suspended": false},
+	}, bodies)
+	require.False(t, scope.Suspended)
+
+	require.EqualError(t, SuspendJobs(ctx, nil), "job suspension scope is nil")
+	require.EqualError(t, ActivateJobs(ctx, nil), "job suspension scope is nil")
+	require.Len(t, bodies, 2)
+}
+
+func TestExecuteRetries(t *testing.T) {
+	ctx := context.Background()
+
+	var mu sync.Mutex
+
+	requests := make(map[string][][]string)
+	failing := ""
+
+	serve(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		data := make(map[string]interface{})
+
+		if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		ids := make([]string, 0)
+
+		for _, key := range []string{"jobIds", "externalTaskIds"} {
+			if values, ok := data[key].([]interface{}); ok {
+				for _, v := range values {
+					ids = append(ids, v.(string))
+				}
+			}
+		}
+
+		if r.URL.Path == failing && len(requests[r.URL.Path]) > 0 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"type":"ProcessEngineException","message":"failed"}`))
+			return
+		}
+
+		requests[r.URL.Path] = append(requests[r.URL.Path], ids)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"batch"}`))
+	})
+
+	plan := &RetryPlan{Candidates: []*RetryCandidate{
+		{Kind: IncidentFailedJob, Id: "job-1", ProcessDefinitionId: "invoice:1"},
+		{Kind: IncidentFailedJob, Id: "job-2", ProcessDefinitionId: "invoice:1"},
+		{Kind: IncidentFailedJob, Id: "job-1", ProcessDefinitionId: "invoice:1"},
+		{Kind: IncidentFailedJob, Id: "job-3", ProcessDefinitionId: "invoice:2"},
+		{Kind: IncidentFailedExternalTask, Id: "task-1", ProcessDefinitionId: "order:1"},
+		{Kind: IncidentFailedExternalTask, Id: "task-1", ProcessDefinitionId: "order:1"},
+		{Kind: "unknown", Id: "other-1", ProcessDefinitionId: "order:1"},
+	}}
+
+	summary, err := ExecuteRetries(ctx, plan, 3, 2)
+
+	require.NoError(t, err)
+	require.Equal(t, 3, summary.Jobs)
+	require.Equal(t, 1, summary.ExternalTasks)
+	require.Len(t, summary.Batches, 3)
+	require.Equal(t, map[string]int{"invoice:1": 2, "invoice:2": 1, "order:1": 1}, summary.Definitions)
+	require.Equal(t, [][]string{{"job-1", "job-2"}, {"job-3"}}, requests["/engine-rest/job/retries"])
+	require.Equal(t, [][]string{{"task-1"}}, requests["/engine-rest/external-task/retries-async"])
+
+	// the failed batch is not counted.
+	requests = make(map[string][][]string)
+	failing = "/engine-rest/job/retries"
+
+	summary, err = ExecuteRetries(ctx, plan, 3, 2)
+
+	require.Error(t, err)
+	require.Equal(t, 2, summary.Jobs)
+	require.Equal(t, map[string]int{"invoice:1": 2}, summary.Definitions)
+
+	_, err = ExecuteRetries(ctx, plan, 3, 0)
+
+	require.EqualError(t, err, "invalid batch size: 0")
+
+	_, err = ExecuteRetries(ctx, nil, 3, 2)
+
+	require.EqualError(t, err, "retry plan is nil")
+}
+
+func TestTaskUpdates(t *testing.T) {
+	ctx := context.Background()
+
+	requests := record(t, map[string]string{
+		"GET /task/task-1": `{"id":"task-1","name":"Review invoice","assignee":"demo","owner":"mary",` +
+			`"created":"2021-01-01T10:00:00.000+0100","due":"2021-01-08T10:00:00.000+0100","followUp":null,` +
+			`"delegationState":"PENDING","description":"Check the amount","executionId":"execution-1",` +
+			`"parentTaskId":null,"priority":50,"processDefinitionId":"invoice:1:deployment-1",` +
+			`"processInstanceId":"process-instance-1","taskDefinitionKey":"review","tenantId":"tenant-1",` +
+			`"suspended":false,"formKey":null}`,
+	})
+
+	require.NoError(t, CreateTask(ctx, &TaskUpdate{Id: "task-2", Name: "Call customer", Assignee: "demo", Priority: 20}))
+	require.NoError(t, UpdateTask(ctx, "task-2", &TaskUpdate{Name: "Call customer", Due: "2021-01-08T10:00:00.000+0100"}))
+	require.NoError(t, SetTaskAssignee(ctx, "task-1", "john"))
+	require.NoError(t, DeleteTask(ctx, "task-2"))
+
+	require.Equal(t, []string{
+		`POST /task/create {"id":"task-2","name":"Call customer","assignee":"demo","priority":20}`,
+		`PUT /task/task-2 {"name":"Call customer","due":"2021-01-08T10:00:00.000+0100"}`,
+		`POST /task/task-1/assignee {"userId":"john"}`,
+		"DELETE /task/task-2",
+	}, requests())
+
+	// the owner and priority are changed by replacing the task with all other properties kept.
+	require.NoError(t, SetTaskOwner(ctx, "task-1", "john"))
+	require.NoError(t, SetTaskPriority(ctx, "task-1", 80))
+
+	require.Equal(t, []string{
+		"GET /task/task-1",
+		`PUT /task/task-1 {"name":"Review invoice","description":"Check the amount","assignee":"demo","owner":"john",` +
+			`"delegationState":"PENDING","due":"2021-01-08T10:00:00.000+0100","priority":50,"tenantId":"tenant-1"}`,
+		"GET /task/task-1",
+		`PUT /task/task-1 {"name":"Review invoice","description":"Check the amount","assignee":"demo","owner":"mary",` +
+			`"delegationState":"PENDING","due":"2021-01-08T10:00:00.000+0100","priority":80,"tenantId":"tenant-1"}`,
+	}, requests())
+}
+
+func TestSyncTaskCandidateRequests(t *testing.T) {
+	ctx := context.Background()
+
+	// changes syncs the candidates of a task with the given current candidates and returns the
+	// links which were added or deleted.
+	changes := func(current string, users, groups []string) []string {
+		requests := record(t, map[string]string{"GET /task/task-1/identity-links": current})
+
+		require.NoError(t, SyncTaskCandidates(ctx, "task-1", users, groups))
+
+		result := requests()
+
+		require.Equal(t, "GET /task/task-1/identity-links?type=candidate", result[0])
+
+		return result[1:]
+	}
+
+	all := `[{"userId":"demo","groupId":null,"type":"candidate"},{"userId":"mary","groupId":null,"type":"candidate"},` +
+		`{"userId":null,"groupId":"accounting","type":"candidate"},{"userId":null,"groupId":"sales","type":"candidate"}]`
+
+	// add only, users before groups, each sorted.
+	require.Equal(t, []string{
+		`POST /task/task-1/identity-links {"userId":"demo","type":"candidate"}`,
+		`POST /task/task-1/identity-links {"userId":"mary","type":"candidate"}`,
+		`POST /task/task-1/identity-links {"groupId":"accounting","type":"candidate"}`,
+		`POST /task/task-1/identity-links {"groupId":"sales","type":"candidate"}`,
+	}, changes(`[]`, []string{"mary", "demo"}, []string{"sales", "accounting"}))
+
+	// no changes, no calls.
+	require.Empty(t, changes(all, []string{"demo", "mary", "demo"}, []string{"accounting", "sales"}))
+
+	// delete only.
+	require.Equal(t, []string{
+		`POST /task/task-1/identity-links/delete {"userId":"demo","type":"candidate"}`,
+		`POST /task/task-1/identity-links/delete {"groupId":"accounting","type":"candidate"}`,
+	}, changes(all, []string{"mary"}, []string{"sales"}))
+
+	// links are added before others are deleted.
+	require.Equal(t, []string{
+		`POST /task/task-1/identity-links {"userId":"demo","type":"candidate"}`,
+		`POST /task/task-1/identity-links/delete {"userId":"mary","type":"candidate"}`,
+	}, changes(`[{"userId":"mary","type":"candidate"},{"groupId":"sales","type":"candidate"}]`, []string{"demo"}, []string{"sales"}))
+}
+
+func TestCreateTaskAttachment(t *testing.T) {
+	ctx := context.Background()
+
+	var mu sync.Mutex
+
+	forms := make([]map[string]string, 0)
+
+	serve(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Method != http.MethodPost || r.URL.Path != "/engine-rest/task/task-1/attachment/create" || r.ParseMultipartForm(1<<20) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		form := make(map[string]string)
+
+		for k, v := range r.MultipartForm.Value {
+			form[k] = v[0]
+		}
+
+		for k, v := range r.MultipartForm.File {
+			form[k] = v[0].Filename
+		}
+
+		forms = append(forms, form)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"attachment-1","name":"invoice.pdf"}`))
+	})
+
+	attachment, err := CreateTaskAttachment(ctx, "task-1", &Attachment{Name: "invoice.pdf", Type: "application/pdf"}, strings.NewReader("%PDF"))
+
+	require.NoError(t, err)
+	require.Equal(t, "attachment-1", attachment.Id)
+
+	_, err = CreateTaskAttachment(ctx, "task-1", &Attachment{Name: "invoice", Url: "https://example.com/invoice.pdf"}, nil)
+
+	require.NoError(t, err)
+	require.Equal(t, []map[string]string{
+		{"attachment-name": "invoice.pdf", "attachment-description": "", "attachment-type": "application/pdf", "content": "invoice.pdf"},
+		{"attachment-name": "invoice", "attachment-description": "", "attachment-type": "", "url": "https://example.com/invoice.pdf"},
+	}, forms)
+
+	_, err = CreateTaskAttachment(ctx, "task-1", nil, strings.NewReader("%PDF"))
+
+	require.EqualError(t, err, "attachment is nil")
+	require.Len(t, forms, 2)
+}
+
+func TestGetProcessInstanceTaskComments(t *testing.T) {
+	ctx := context.Background()
+
+	responses := map[string]string{
+		"GET /history/task": `[{"id":"task-1","name":"Review invoice"},{"id":"task-2","name":"Approve invoice"}]`,
+		"GET /task/task-1/comment": `[
+			{"id":"comment-1","userId":"demo","taskId":"task-1","time":"2021-01-01T10:05:00.000+0100","message":"Amount checked"},
+			{"id":"comment-2","userId":"demo","taskId":"task-1","time":"2021-01-01T10:00:00.000+0100","message":"Started"}]`,
+		"GET /task/task-2/comment": `[
+			{"id":"comment-3","userId":"mary","taskId":"task-2","time":"2021-01-01T10:05:00.000+0100","message":"Approved"},
+			{"id":"comment-4","userId":"mary","taskId":"task-2","time":"2021-01-01T10:01:00.000+0100","message":"Claimed"}]`,
+	}
+
+	record(t, responses)
+
+	comments, err := GetProcessInstanceTaskComments(ctx, "process-instance-1")
+
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(comments))
+
+	for _, v := range comments {
+		ids = append(ids, v.Id)
+	}
+
+	// comments with the same time keep the order of their tasks.
+	require.Equal(t, []string{"comment-2", "comment-4", "comment-1", "comment-3"}, ids)
+
+	responses["GET /task/task-2/comment"] = `[{"id":"comment-3","taskId":"task-2","time":"yesterday"}]`
+
+	record(t, responses)
+
+	comments, err = GetProcessInstanceTaskComments(ctx, "process-instance-1")
+
+	require.EqualError(t, err, "parse time failed, value: yesterday")
+	require.Nil(t, comments)
+}
+
+func TestExecuteFilterSingle(t *testing.T) {
+	ctx := context.Background()
+
+	serve(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/engine-rest/filter/empty/singleResult":
+			w.WriteHeader(http.StatusNoContent)
+		case "/engine-rest/filter/mine/singleResult":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":"task-1","name":"Approve invoice"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	task, err := ExecuteFilterSingle(ctx, "empty", nil)
+
+	require.NoError(t, err)
+	require.Nil(t, task)
+
+	task, err = ExecuteFilterSingle(ctx, "mine", nil)
+
+	require.NoError(t, err)
+	require.Equal(t, &Task{Id: "task-1", Name: "Approve invoice"}, task)
+}
+
+func TestUsers(t *testing.T) {
+	ctx := context.Background()
+
+	requests := record(t, map[string]string{
+		"GET /user":                `[{"id":"jonny1","firstName":"John","lastName":"Doe","email":"john@example.com"}]`,
+		"GET /user/count":          `{"count":1}`,
+		"GET /user/jonny1/profile": `{"id":"jonny1","firstName":"John","lastName":"Doe","email":"john@example.com"}`,
+	})
+
+	john := &User{Id: "jonny1", FirstName: "John", LastName: "Doe", Email: "john@example.com"}
+
+	users, err := GetUsers(ctx, &UserQuery{MemberOfGroup: "sales"}, 0, 10)
+
+	require.NoError(t, err)
+	require.Equal(t, []*User{john}, users)
+
+	count, err := GetUsersCount(ctx, &UserQuery{MemberOfGroup: "sales"})
+
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	user, err := GetUserProfile(ctx, "jonny1")
+
+	require.NoError(t, err)
+	require.Equal(t, john, user)
+
+	require.NoError(t, CreateUser(ctx, john, "s3cret"))
+	require.NoError(t, UpdateUserProfile(ctx, "jonny1", &User{Id: "jonny1", FirstName: "Johnny"}))
+	require.NoError(t, UpdateUserCredentials(ctx, "jonny1", &UserCredentials{Password: "n3w", AuthenticatedUserPassword: "demo"}))
+	require.NoError(t, UnlockUser(ctx, "jonny1"))
+	require.NoError(t, DeleteUser(ctx, "jonny1"))
+
+	require.Equal(t, []string{
+		"GET /user?firstResult=0&maxResults=10&memberOfGroup=sales",
+		"GET /user/count?memberOfGroup=sales",
+		"GET /user/jonny1/profile",
+		`POST /user/create {"credentials":{"password":"s3cret"},"profile":{"id":"jonny1","firstName":"John","lastName":"Doe","email":"john@example.com"}}`,
+		`PUT /user/jonny1/profile {"id":"jonny1","firstName":"Johnny"}`,
+		`PUT /user/jonny1/credentials {"password":"n3w","authenticatedUserPassword":"demo"}`,
+		"POST /user/jonny1/unlock",
+		"DELETE /user/jonny1",
+	}, requests())
+}
+
+func TestGroups(t *testing.T) {
+	ctx := context.Background()
+
+	requests := record(t, map[string]string{
+		"GET /group":       `[{"id":"sales","name":"Sales","type":"WORKFLOW"}]`,
+		"GET /group/count": `{"count":1}`,
+		"GET /group/sales": `{"id":"sales","name":"Sales","type":"WORKFLOW"}`,
+	})
+
+	sales := &Group{Id: "sales", Name: "Sales", Type: "WORKFLOW"}
+
+	groups, err := GetGroups(ctx, &GroupQuery{Member: "jonny1"}, 0, 0)
+
+	require.NoError(t, err)
+	require.Equal(t, []*Group{sales}, groups)
+
+	count, err := GetGroupsCount(ctx, &GroupQuery{Member: "jonny1"})
+
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	group, err := GetGroup(ctx, "sales")
+
+	require.NoError(t, err)
+	require.Equal(t, sales, group)
+
+	require.NoError(t, CreateGroup(ctx, sales))
+	require.NoError(t, UpdateGroup(ctx, "sales", &Group{Id: "sales", Name: "Sales and Marketing"}))
+	require.NoError(t, DeleteGroup(ctx, "sales"))
+
+	require.Equal(t, []string{
+		"GET /group?member=jonny1",
+		"GET /group/count?member=jonny1",
+		"GET /group/sales",
+		`POST /group/create {"id":"sales","name":"Sales","type":"WORKFLOW"}`,
+		`PUT /group/sales {"id":"sales","name":"Sales and Marketing"}`,
+		"DELETE /group/sales",
+	}, requests())
+}
+
+func TestMemberships(t *testing.T) {
+	ctx := context.Background()
+
+	requests := record(t, nil)
+
+	require.NoError(t, AddGroupMember(ctx, "sales", "jonny1"))
+	require.NoError(t, RemoveGroupMember(ctx, "sales", "jonny1"))
+	require.NoError(t, AddTenantUserMember(ctx, "tenant-1", "jonny1"))
+	require.NoError(t, RemoveTenantUserMember(ctx, "tenant-1", "jonny1"))
+	require.NoError(t, AddTenantGroupMember(ctx, "tenant-1", "sales"))
+	require.NoError(t, RemoveTenantGroupMember(ctx, "tenant-1", "sales"))
+
+	require.Equal(t, []string{
+		"PUT /group/sales/members/jonny1",
+		"DELETE /group/sales/members/jonny1",
+		"PUT /tenant/tenant-1/user-members/jonny1",
+		"DELETE /tenant/tenant-1/user-members/jonny1",
+		"PUT /tenant/tenant-1/group-members/sales",
+		"DELETE /tenant/tenant-1/group-members/sales",
+	}, requests())
+}
+
+func TestPasswordPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	requests := record(t, map[string]string{
+		"GET /identity/password-policy": `{"rules":[` +
+			`{"placeholder":"PASSWORD_POLICY_USER_DATA","parameter":null},` +
+			`{"placeholder":"PASSWORD_POLICY_LENGTH","parameter":{"minLength":"10"}}]}`,
+		"POST /identity/password-policy": `{"rules":[` +
+			`{"placeholder":"PASSWORD_POLICY_USER_DATA","parameter":null,"valid":true},` +
+			`{"placeholder":"PASSWORD_POLICY_LENGTH","parameter":{"minLength":"10"},"valid":false}],"valid":false}`,
+	})
+
+	policy, err := GetPasswordPolicy(ctx)
+
+	require.NoError(t, err)
+	require.Equal(t, &PasswordPolicy{Rules: []*PasswordPolicyRule{
+		{Placeholder: "PASSWORD_POLICY_USER_DATA"},
+		{Placeholder: "PASSWORD_POLICY_LENGTH", Parameters: map[string]string{"minLength": "10"}},
+	}}, policy)
+
+	result, err := ValidatePassword(ctx, "s3cret", &User{Id: "jonny1"})
+
+	require.NoError(t, err)
+	require.False(t, result.Valid)
+	require.Equal(t, []*PasswordPolicyRule{
+		{Placeholder: "PASSWORD_POLICY_USER_DATA", Valid: true},
+		{Placeholder: "PASSWORD_POLICY_LENGTH", Parameters: map[string]string{"minLength": "10"}},
+	}, result.Rules)
+
+	_, err = ValidatePassword(ctx, "s3cret", nil)
+
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		"GET /identity/password-policy",
+		`POST /identity/password-policy {"password":"s3cret","profile":{"id":"jonny1"}}`,
+		`POST /identity/password-policy {"password":"s3cret"}`,
+	}, requests())
+}
+
+func TestAuthorizations(t *testing.T) {
+	ctx := context.Background()
+
+	grant := `{"id":"authorization-1","type":1,"permissions":["READ","UPDATE"],"userId":"jonny1","groupId":null,` +
+		`"resourceType":7,"resourceId":"*","removalTime":null,"rootProcessInstanceId":null}`
+
+	requests := record(t, map[string]string{
+		"GET /authorization":                 "[" + grant + "]",
+		"GET /authorization/count":           `{"count":1}`,
+		"GET /authorization/authorization-1": grant,
+		"POST /authorization/create": `{"id":"authorization-1","type":1,"permissions":["READ","UPDATE"],"userId":"jonny1",` +
+			`"groupId":null,"resourceType":7,"resourceId":"*","links":[{"method":"GET",` +
+			`"href":"http://localhost:8080/engine-rest/authorization/authorization-1","rel":"self"}]}`,
+	})
+
+	expected := &Authorization{
+		Id:           "authorization-1",
+		Type:         AuthorizationGrant,
+		Permissions:  []string{"READ", "UPDATE"},
+		UserId:       "jonny1",
+		ResourceType: ResourceTask,
+		ResourceId:   "*",
+	}
+
+	resourceType := ResourceTask
+
+	authorizations, err := GetAuthorizations(ctx, &AuthorizationQuery{UserIdIn: []string{"jonny1"}, ResourceType: &resourceType}, 0, 0)
+
+	require.NoError(t, err)
+	require.Equal(t, []*Authorization{expected}, authorizations)
+
+	count, err := GetAuthorizationsCount(ctx, &AuthorizationQuery{UserIdIn: []string{"jonny1"}})
+
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	authorization, err := GetAuthorization(ctx, "authorization-1")
+
+	require.NoError(t, err)
+	require.Equal(t, expected, authorization)
+
+	authorization, err = CreateAuthorization(ctx, &Authorization{
+		Type:         AuthorizationGrant,
+		Permissions:  []string{"READ", "UPDATE"},
+		UserId:       "jonny1",
+		ResourceType: ResourceTask,
+		ResourceId:   "*",
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "authorization-1", authorization.Id)
+	require.Len(t, authorization.Links, 1)
+
+	// the global type and the application resource are zero but sent.
+	require.NoError(t, UpdateAuthorization(ctx, "authorization-1", &Authorization{Permissions: []string{"ALL"}, UserId: "*", ResourceId: "*"}))
+	require.NoError(t, DeleteAuthorization(ctx, "authorization-1"))
+
+	require.Equal(t, []string{
+		"GET /authorization?resourceType=7&userIdIn=jonny1",
+		"GET /authorization/count?userIdIn=jonny1",
+		"GET /authorization/authorization-1",
+		`POST /authorization/create {"type":1,"permissions":["READ","UPDATE"],"userId":"jonny1","resourceType":7,"resourceId":"*"}`,
+		`PUT /authorization/authorization-1 {"type":0,"permissions":["ALL"],"userId":"*","resourceType":0,"resourceId":"*"}`,
+		"DELETE /authorization/authorization-1",
+	}, requests())
+}
+
+func TestCheckAuthorization(t *testing.T) {
+	ctx := context.Background()
+
+	serve(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		if r.Method != http.MethodGet || r.URL.Path != "/engine-rest/authorization/check" || query.Get("resourceType") != "7" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		// only demo may read the task.
+		_, _ = fmt.Fprintf(w, `{"permissionName":%q,"resourceName":%q,"resourceId":%q,"authorized":%t}`,
+			query.Get("permissionName"), query.Get("resourceName"), query.Get("resourceId"), query.Get("userId") == "demo")
+	})
+
+	check, err := CheckAuthorization(ctx, "READ", "Task", ResourceTask, "task-1", "demo")
+
+	require.NoError(t, err)
+	require.Equal(t, &AuthorizationCheck{PermissionName: "READ", ResourceName: "Task", ResourceId: "task-1", Authorized: true}, check)
+
+	check, err = CheckAuthorization(ctx, "READ", "Task", ResourceTask, "task-1", "jonny1")
+
+	require.NoError(t, err)
+	require.Equal(t, &AuthorizationCheck{PermissionName: "READ", ResourceName: "Task", ResourceId: "task-1", Authorized: false}, check)
+
+	// a check without resource id and user id applies to all tasks and the authenticated user.
+	check, err = CheckAuthorization(ctx, "READ", "Task", ResourceTask, "", "")
+
+	require.NoError(t, err)
+	require.False(t, check.Authorized)
+	require.Empty(t, check.ResourceId)
+
+	// false is decoded over a previous value and encoded.
+	check = &AuthorizationCheck{Authorized: true}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"permissionName":"READ","authorized":false}`), check))
+	require.False(t, check.Authorized)
+
+	content, err := json.Marshal(check)
+
+	require.NoError(t, err)
+	require.JSONEq(t, `{"permissionName":"READ","authorized":false}`, string(content))
+}
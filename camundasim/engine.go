@@ -0,0 +1,944 @@
+// Package camundasim is an in-process simulator of the Camunda engine for unit-testing process
+// models without deploying them. It executes a subset of BPMN 2.0: start and end events, tasks,
+// user tasks, service and send tasks, external tasks, receive tasks, exclusive and parallel
+// gateways, intermediate message catch events and sequence flow conditions in a subset of JUEL.
+//
+// The Engine mirrors the functions of the camunda package, so tests drive a simulated process the
+// same way production code drives the engine. Ids and timestamps are deterministic.
+package camundasim
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"sync"
+	"time"
+
+	camunda "github.com/equipmegmbh/camunda-go"
+)
+
+// Handler implements a service task or the worker of an external task topic. It receives a copy of
+// the variables of the process instance and returns the variables to set. Handlers run while the
+// engine is locked and must not call the engine. A returned error raises an incident and the
+// execution stays at the task.
+type Handler func(ctx context.Context, variables map[string]*camunda.Variable) (map[string]*camunda.Variable, error)
+
+// definition is a deployed process definition.
+type definition struct {
+	data    *camunda.ProcessDefinition
+	process *process
+	source  string
+}
+
+// Engine is an in-memory process engine. The entities it returns are copies, changing them does not
+// change the state of the engine.
+type Engine struct {
+	mu    sync.Mutex
+	clock time.Time
+	seq   map[string]int
+
+	deployments   map[string]*camunda.Deployment
+	definitions   []*definition
+	instances     map[string]*instance
+	order         []*instance
+	tasks         map[string]*camunda.Task
+	links         map[string][]*camunda.IdentityLink
+	externalTasks map[string]*camunda.ExternalTask
+	activities    []*camunda.HistoricActivityInstance
+	incidents     []*camunda.Incident
+	services      map[string]Handler
+	topics        map[string]Handler
+}
+
+// NewEngine creates a new simulated engine.
+func NewEngine() *Engine {
+	return &Engine{
+		clock:         time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		seq:           make(map[string]int),
+		deployments:   make(map[string]*camunda.Deployment),
+		definitions:   make([]*definition, 0),
+		instances:     make(map[string]*instance),
+		order:         make([]*instance, 0),
+		tasks:         make(map[string]*camunda.Task),
+		links:         make(map[string][]*camunda.IdentityLink),
+		externalTasks: make(map[string]*camunda.ExternalTask),
+		activities:    make([]*camunda.HistoricActivityInstance, 0),
+		incidents:     make([]*camunda.Incident, 0),
+		services:      make(map[string]Handler),
+		topics:        make(map[string]Handler),
+	}
+}
+
+// HandleService registers the handler of the service tasks with the given activity id. Service
+// tasks without a handler are left immediately.
+func (e *Engine) HandleService(activityId string, handler Handler) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.services[activityId] = handler
+}
+
+// HandleTopic registers a worker for the external tasks of a topic. External tasks of the topic are
+// completed by the handler as soon as they are created, instead of waiting for CompleteExternalTask.
+func (e *Engine) HandleTopic(topic string, handler Handler) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.topics[topic] = handler
+}
+
+// CreateDeployment deploys the processes of a BPMN 2.0 resource.
+func (e *Engine) CreateDeployment(ctx context.Context, tenant, name, filename string, content io.Reader) (*camunda.Deployment, error) {
+	source, err := ioutil.ReadAll(content)
+
+	if err != nil {
+		return nil, err
+	}
+
+	processes, err := parse(string(source))
+
+	if err != nil {
+		return nil, &camunda.Error{Type: "ParseException", Message: fmt.Sprintf("%s: %s", filename, err)}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	deployment := &camunda.Deployment{
+		Id:                         e.id("deployment"),
+		Name:                       name,
+		TenantId:                   tenant,
+		DeploymentTime:             e.now(),
+		DeployedProcessDefinitions: make(map[string]*camunda.ProcessDefinition),
+	}
+
+	for _, p := range processes {
+		data := &camunda.ProcessDefinition{
+			Key:          p.id,
+			Name:         p.name,
+			Version:      1,
+			Resource:     filename,
+			DeploymentId: deployment.Id,
+			TenantId:     tenant,
+		}
+
+		if latest := e.latest(p.id, tenant); latest != nil {
+			data.Version = latest.data.Version + 1
+		}
+
+		data.Id = fmt.Sprintf("%s:%d:%s", data.Key, data.Version, deployment.Id)
+
+		e.definitions = append(e.definitions, &definition{data: data, process: p, source: string(source)})
+
+		deployed := *data
+		deployment.DeployedProcessDefinitions[data.Id] = &deployed
+	}
+
+	e.deployments[deployment.Id] = deployment
+
+	return deployment, nil
+}
+
+// GetProcessDefinitions returns the deployed process definitions, optionally filtered by tenant.
+func (e *Engine) GetProcessDefinitions(ctx context.Context, tenantId string) ([]*camunda.ProcessDefinition, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	result := make([]*camunda.ProcessDefinition, 0)
+
+	for _, v := range e.definitions {
+		if tenantId != "" && v.data.TenantId != tenantId {
+			continue
+		}
+
+		data := *v.data
+		result = append(result, &data)
+	}
+
+	return result, nil
+}
+
+// GetProcessDefinition returns a deployed process definition.
+func (e *Engine) GetProcessDefinition(ctx context.Context, id string) (*camunda.ProcessDefinition, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	d, err := e.definition(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := *d.data
+
+	return &result, nil
+}
+
+// GetProcessDefinitionByKey returns the latest version of a process definition without tenant.
+func (e *Engine) GetProcessDefinitionByKey(ctx context.Context, key string) (*camunda.ProcessDefinition, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	d, err := e.definitionByKey(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := *d.data
+
+	return &result, nil
+}
+
+// GetProcessDefinitionXML returns the BPMN 2.0 source of a process definition.
+func (e *Engine) GetProcessDefinitionXML(ctx context.Context, id string) (*camunda.ProcessDefinitionSource, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	d, err := e.definition(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &camunda.ProcessDefinitionSource{Id: d.data.Id, Content: d.source}, nil
+}
+
+// StartProcessDefinition starts an instance of a process definition. The instance runs until every
+// execution waits or ended before the function returns.
+func (e *Engine) StartProcessDefinition(ctx context.Context, id string, data *camunda.ProcessDefinitionStart) (*camunda.ProcessInstance, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	d, err := e.definition(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return e.start(ctx, d, data)
+}
+
+// StartProcessDefinitionByKey starts an instance of the latest version of a process definition.
+func (e *Engine) StartProcessDefinitionByKey(ctx context.Context, key string, data *camunda.ProcessDefinitionStart) (*camunda.ProcessInstance, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	d, err := e.definitionByKey(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return e.start(ctx, d, data)
+}
+
+func (e *Engine) start(ctx context.Context, d *definition, data *camunda.ProcessDefinitionStart) (*camunda.ProcessInstance, error) {
+	if data == nil {
+		data = new(camunda.ProcessDefinitionStart)
+	}
+
+	inst := &instance{
+		data: &camunda.ProcessInstance{
+			Id:           e.id("process-instance"),
+			DefinitionId: d.data.Id,
+			BusinessKey:  data.BusinessKey,
+			TenantId:     d.data.TenantId,
+		},
+		definition: d,
+		variables:  copyVariables(data.Variables),
+		executions: make(map[string]*execution),
+		joins:      make(map[string]int),
+	}
+
+	inst.history = &camunda.HistoricProcessInstance{
+		Id:                       inst.data.Id,
+		RootProcessInstanceId:    inst.data.Id,
+		BusinessKey:              inst.data.BusinessKey,
+		ProcessDefinitionId:      d.data.Id,
+		ProcessDefinitionKey:     d.data.Key,
+		ProcessDefinitionName:    d.data.Name,
+		ProcessDefinitionVersion: d.data.Version,
+		StartTime:                e.now(),
+		StartActivityId:          d.process.start.id,
+		TenantId:                 d.data.TenantId,
+		State:                    "ACTIVE",
+	}
+
+	root := &execution{id: inst.data.Id, node: d.process.start}
+	inst.executions[root.id] = root
+
+	// the instance is registered once it ran, an instance whose start failed leaves no trace.
+	activities, incidents := len(e.activities), len(e.incidents)
+
+	if err := e.run(ctx, inst, []*execution{root}); err != nil {
+		e.activities = e.activities[:activities]
+		e.incidents = e.incidents[:incidents]
+		e.clear(inst.data.Id)
+
+		return nil, err
+	}
+
+	e.instances[inst.data.Id] = inst
+	e.order = append(e.order, inst)
+
+	result := *inst.data
+
+	if data.WithVariablesInReturn {
+		result.Variables = copyVariables(inst.variables)
+	}
+
+	return &result, nil
+}
+
+// GetProcessInstances returns the running process instances, optionally filtered by tenant.
+func (e *Engine) GetProcessInstances(ctx context.Context, tenantId string) ([]*camunda.ProcessInstance, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	result := make([]*camunda.ProcessInstance, 0)
+
+	for _, v := range e.order {
+		if v.data.Ended || (tenantId != "" && v.data.TenantId != tenantId) {
+			continue
+		}
+
+		data := *v.data
+		result = append(result, &data)
+	}
+
+	return result, nil
+}
+
+// GetProcessInstance returns a running process instance.
+func (e *Engine) GetProcessInstance(ctx context.Context, id string) (*camunda.ProcessInstance, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	inst, err := e.instance(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := *inst.data
+
+	return &result, nil
+}
+
+// GetHistoricProcessInstance returns a running or ended process instance.
+func (e *Engine) GetHistoricProcessInstance(ctx context.Context, id string) (*camunda.HistoricProcessInstance, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	inst, ok := e.instances[id]
+
+	if !ok {
+		return nil, notFound("Historic process instance with id %s does not exist", id)
+	}
+
+	result := *inst.history
+
+	return &result, nil
+}
+
+// DeleteProcessInstance cancels a running process instance.
+func (e *Engine) DeleteProcessInstance(ctx context.Context, id string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	inst, err := e.instance(id)
+
+	if err != nil {
+		return err
+	}
+
+	for _, exec := range inst.executions {
+		e.complete(exec.activity, true)
+	}
+
+	e.clear(inst.data.Id)
+
+	inst.executions = make(map[string]*execution)
+	inst.data.Ended = true
+	inst.history.State = "EXTERNALLY_TERMINATED"
+	inst.history.EndTime = e.now()
+	inst.history.DurationInMillis = e.duration(inst.history.StartTime, inst.history.EndTime)
+
+	return nil
+}
+
+// GetProcessInstanceVariables returns the variables of a running process instance.
+func (e *Engine) GetProcessInstanceVariables(ctx context.Context, id string, deserializeValues bool) (map[string]*camunda.Variable, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	inst, err := e.instance(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return copyVariables(inst.variables), nil
+}
+
+// GetProcessInstanceVariable returns a variable of a running process instance.
+func (e *Engine) GetProcessInstanceVariable(ctx context.Context, id, name string, deserializeValue bool) (*camunda.Variable, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	inst, err := e.instance(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	v, ok := inst.variables[name]
+
+	if !ok {
+		return nil, notFound("process instance variable with name %s does not exist", name)
+	}
+
+	result := *v
+
+	return &result, nil
+}
+
+// SetProcessInstanceVariable sets a variable of a running process instance.
+func (e *Engine) SetProcessInstanceVariable(ctx context.Context, id, name string, variable *camunda.Variable) error {
+	return e.ModifyProcessInstanceVariables(ctx, id, &camunda.VariableModification{
+		Modifications: map[string]*camunda.Variable{name: variable},
+	})
+}
+
+// DeleteProcessInstanceVariable deletes a variable of a running process instance.
+func (e *Engine) DeleteProcessInstanceVariable(ctx context.Context, id, name string) error {
+	return e.ModifyProcessInstanceVariables(ctx, id, &camunda.VariableModification{Deletions: []string{name}})
+}
+
+// ModifyProcessInstanceVariables updates and deletes variables of a running process instance.
+// Deletions are applied before modifications.
+func (e *Engine) ModifyProcessInstanceVariables(ctx context.Context, id string, data *camunda.VariableModification) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	inst, err := e.instance(id)
+
+	if err != nil {
+		return err
+	}
+
+	for _, v := range data.Deletions {
+		delete(inst.variables, v)
+	}
+
+	inst.merge(copyVariables(data.Modifications))
+
+	return nil
+}
+
+// GetTasks returns the open user tasks, optionally filtered by process instance.
+func (e *Engine) GetTasks(ctx context.Context, processInstanceId string) ([]*camunda.Task, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	result := make([]*camunda.Task, 0)
+
+	for _, v := range e.tasks {
+		if processInstanceId != "" && v.ProcessInstanceId != processInstanceId {
+			continue
+		}
+
+		task := *v
+		result = append(result, &task)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return less(result[i].Id, result[j].Id)
+	})
+
+	return result, nil
+}
+
+// GetTask returns an open user task.
+func (e *Engine) GetTask(ctx context.Context, id string) (*camunda.Task, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	task, err := e.task(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := *task
+
+	return &result, nil
+}
+
+// GetTaskVariables returns the variables visible from a user task.
+func (e *Engine) GetTaskVariables(ctx context.Context, id string) (map[string]*camunda.Variable, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	task, err := e.task(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return copyVariables(e.instances[task.ProcessInstanceId].variables), nil
+}
+
+// GetTaskIdentityLinks returns the identity links of a user task, optionally filtered by type.
+// Candidate users and groups are read from the camunda:candidateUsers and camunda:candidateGroups
+// attributes of the user task, the assignee is reported as a link of type assignee.
+func (e *Engine) GetTaskIdentityLinks(ctx context.Context, id, kind string) ([]*camunda.IdentityLink, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	task, err := e.task(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	links := e.links[id]
+
+	if task.Assignee != "" {
+		links = append([]*camunda.IdentityLink{{UserId: task.Assignee, Type: camunda.IdentityLinkAssignee}}, links...)
+	}
+
+	result := make([]*camunda.IdentityLink, 0)
+
+	for _, v := range links {
+		if kind == "" || v.Type == kind {
+			link := *v
+			result = append(result, &link)
+		}
+	}
+
+	return result, nil
+}
+
+// ClaimTask claims a user task for a user.
+func (e *Engine) ClaimTask(ctx context.Context, id, userId string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	task, err := e.task(id)
+
+	if err != nil {
+		return err
+	}
+
+	if task.Assignee != "" && task.Assignee != userId {
+		return &camunda.Error{Type: "TaskAlreadyClaimedException", Message: fmt.Sprintf("Task '%s' is already claimed by someone else.", id)}
+	}
+
+	e.assign(task, userId)
+
+	return nil
+}
+
+// UnclaimTask resets the assignee of a user task.
+func (e *Engine) UnclaimTask(ctx context.Context, id string) error {
+	return e.SetTaskAssignee(ctx, id, "")
+}
+
+// SetTaskAssignee changes the assignee of a user task.
+func (e *Engine) SetTaskAssignee(ctx context.Context, id, userId string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	task, err := e.task(id)
+
+	if err != nil {
+		return err
+	}
+
+	e.assign(task, userId)
+
+	return nil
+}
+
+func (e *Engine) assign(task *camunda.Task, userId string) {
+	task.Assignee = userId
+
+	if exec := e.instances[task.ProcessInstanceId].executions[task.ExecutionId]; exec != nil {
+		exec.activity.Assignee = userId
+	}
+}
+
+// CompleteTask completes a user task, sets the given variables on the process instance and
+// continues the process.
+func (e *Engine) CompleteTask(ctx context.Context, id string, variables map[string]*camunda.Variable) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	task, err := e.task(id)
+
+	if err != nil {
+		return err
+	}
+
+	inst := e.instances[task.ProcessInstanceId]
+	inst.merge(copyVariables(variables))
+
+	delete(e.tasks, id)
+	delete(e.links, id)
+
+	return e.resume(ctx, inst, inst.executions[task.ExecutionId])
+}
+
+// GetExternalTasks returns the open external tasks matching the query. The query supports the
+// external task, topic, activity, execution, process instance and process definition filters.
+func (e *Engine) GetExternalTasks(ctx context.Context, query *camunda.ExternalTaskQuery, firstResult, maxResults int) ([]*camunda.ExternalTask, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if query == nil {
+		query = new(camunda.ExternalTaskQuery)
+	}
+
+	result := make([]*camunda.ExternalTask, 0)
+
+	for _, v := range e.externalTasks {
+		switch {
+		case query.ExternalTaskId != "" && v.Id != query.ExternalTaskId:
+			continue
+		case len(query.ExternalTaskIdIn) > 0 && !contains(query.ExternalTaskIdIn, v.Id):
+			continue
+		case query.TopicName != "" && v.TopicName != query.TopicName:
+			continue
+		case query.ActivityId != "" && v.ActivityId != query.ActivityId:
+			continue
+		case len(query.ActivityIdIn) > 0 && !contains(query.ActivityIdIn, v.ActivityId):
+			continue
+		case query.ExecutionId != "" && v.ExecutionId != query.ExecutionId:
+			continue
+		case query.ProcessInstanceId != "" && v.ProcessInstanceId != query.ProcessInstanceId:
+			continue
+		case len(query.ProcessInstanceIdIn) > 0 && !contains(query.ProcessInstanceIdIn, v.ProcessInstanceId):
+			continue
+		case query.ProcessDefinitionId != "" && v.ProcessDefinitionId != query.ProcessDefinitionId:
+			continue
+		}
+
+		task := *v
+		result = append(result, &task)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return less(result[i].Id, result[j].Id)
+	})
+
+	from, to := bounds(len(result), firstResult, maxResults)
+
+	return result[from:to], nil
+}
+
+// GetExternalTasksCount returns the number of open external tasks matching the query.
+func (e *Engine) GetExternalTasksCount(ctx context.Context, query *camunda.ExternalTaskQuery) (int, error) {
+	result, err := e.GetExternalTasks(ctx, query, 0, 0)
+
+	return len(result), err
+}
+
+// GetExternalTask returns an open external task.
+func (e *Engine) GetExternalTask(ctx context.Context, id string) (*camunda.ExternalTask, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	v, ok := e.externalTasks[id]
+
+	if !ok {
+		return nil, notFound("External task with id %s does not exist", id)
+	}
+
+	result := *v
+
+	return &result, nil
+}
+
+// CompleteExternalTask completes an external task, sets the given variables on the process
+// instance and continues the process.
+func (e *Engine) CompleteExternalTask(ctx context.Context, id string, variables map[string]*camunda.Variable) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	task, ok := e.externalTasks[id]
+
+	if !ok {
+		return notFound("External task with id %s does not exist", id)
+	}
+
+	inst := e.instances[task.ProcessInstanceId]
+	inst.merge(copyVariables(variables))
+
+	delete(e.externalTasks, id)
+
+	return e.resume(ctx, inst, inst.executions[task.ExecutionId])
+}
+
+// CorrelateMessage delivers a message to the execution waiting for it at a message catch event or
+// receive task and continues the process. When processInstanceId is empty, the message is
+// delivered to any instance, but exactly one execution must wait for it.
+func (e *Engine) CorrelateMessage(ctx context.Context, messageName, processInstanceId string, variables map[string]*camunda.Variable) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var target *instance
+	var waiting *execution
+
+	for _, inst := range e.order {
+		if processInstanceId != "" && inst.data.Id != processInstanceId {
+			continue
+		}
+
+		for _, exec := range inst.executions {
+			if exec.node.message != messageName || exec.activity == nil || exec.activity.EndTime != "" {
+				continue
+			}
+
+			if waiting != nil {
+				return &camunda.Error{Type: "MismatchingMessageCorrelationException", Message: fmt.Sprintf("Cannot correlate message '%s': multiple executions match", messageName)}
+			}
+
+			target, waiting = inst, exec
+		}
+	}
+
+	if waiting == nil {
+		return &camunda.Error{Type: "MismatchingMessageCorrelationException", Message: fmt.Sprintf("Cannot correlate message '%s': No process definition or execution matches the parameters", messageName)}
+	}
+
+	target.merge(copyVariables(variables))
+
+	return e.resume(ctx, target, waiting)
+}
+
+// GetHistoricActivityInstances returns the activity instances matching the query, in the order
+// they were started. The query supports the activity, execution, process and state filters.
+func (e *Engine) GetHistoricActivityInstances(ctx context.Context, query *camunda.HistoricActivityInstanceQuery, firstResult, maxResults int) ([]*camunda.HistoricActivityInstance, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if query == nil {
+		query = new(camunda.HistoricActivityInstanceQuery)
+	}
+
+	result := make([]*camunda.HistoricActivityInstance, 0)
+
+	for _, v := range e.activities {
+		switch {
+		case query.ActivityInstanceId != "" && v.Id != query.ActivityInstanceId:
+			continue
+		case query.ProcessInstanceId != "" && v.ProcessInstanceId != query.ProcessInstanceId:
+			continue
+		case query.ProcessDefinitionId != "" && v.ProcessDefinitionId != query.ProcessDefinitionId:
+			continue
+		case query.ExecutionId != "" && v.ExecutionId != query.ExecutionId:
+			continue
+		case query.ActivityId != "" && v.ActivityId != query.ActivityId:
+			continue
+		case query.ActivityType != "" && v.ActivityType != query.ActivityType:
+			continue
+		case query.TaskAssignee != "" && v.Assignee != query.TaskAssignee:
+			continue
+		case query.Finished && v.EndTime == "":
+			continue
+		case query.Unfinished && v.EndTime != "":
+			continue
+		case query.Canceled && !v.Canceled:
+			continue
+		}
+
+		activity := *v
+		result = append(result, &activity)
+	}
+
+	from, to := bounds(len(result), firstResult, maxResults)
+
+	return result[from:to], nil
+}
+
+// GetHistoricActivityInstancesCount returns the number of activity instances matching the query.
+func (e *Engine) GetHistoricActivityInstancesCount(ctx context.Context, query *camunda.HistoricActivityInstanceQuery) (int, error) {
+	result, err := e.GetHistoricActivityInstances(ctx, query, 0, 0)
+
+	return len(result), err
+}
+
+// GetIncidents returns the incidents raised by failed handlers, filtered by the incident, type,
+// process instance and activity of the query.
+func (e *Engine) GetIncidents(ctx context.Context, query *camunda.IncidentQuery, firstResult, maxResults int) ([]*camunda.Incident, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if query == nil {
+		query = new(camunda.IncidentQuery)
+	}
+
+	result := make([]*camunda.Incident, 0)
+
+	for _, v := range e.incidents {
+		switch {
+		case query.IncidentId != "" && v.Id != query.IncidentId:
+			continue
+		case query.IncidentType != "" && v.IncidentType != query.IncidentType:
+			continue
+		case query.ProcessInstanceId != "" && v.ProcessInstanceId != query.ProcessInstanceId:
+			continue
+		case query.ActivityId != "" && v.ActivityId != query.ActivityId:
+			continue
+		}
+
+		incident := *v
+		result = append(result, &incident)
+	}
+
+	from, to := bounds(len(result), firstResult, maxResults)
+
+	return result[from:to], nil
+}
+
+func (e *Engine) definition(id string) (*definition, error) {
+	for _, v := range e.definitions {
+		if v.data.Id == id {
+			return v, nil
+		}
+	}
+
+	return nil, notFound("No matching definition with id %s", id)
+}
+
+func (e *Engine) definitionByKey(key string) (*definition, error) {
+	if v := e.latest(key, ""); v != nil {
+		return v, nil
+	}
+
+	return nil, notFound("No matching process definition with key: %s and no tenant-id", key)
+}
+
+// latest returns the latest version of the process definition with the given key and tenant.
+func (e *Engine) latest(key, tenantId string) *definition {
+	var result *definition
+
+	for _, v := range e.definitions {
+		if v.data.Key != key || v.data.TenantId != tenantId {
+			continue
+		}
+
+		if result == nil || v.data.Version > result.data.Version {
+			result = v
+		}
+	}
+
+	return result
+}
+
+func (e *Engine) instance(id string) (*instance, error) {
+	v, ok := e.instances[id]
+
+	if !ok || v.data.Ended {
+		return nil, notFound("Process instance with id %s does not exist", id)
+	}
+
+	return v, nil
+}
+
+func (e *Engine) task(id string) (*camunda.Task, error) {
+	v, ok := e.tasks[id]
+
+	if !ok {
+		return nil, notFound("No matching task with id %s", id)
+	}
+
+	return v, nil
+}
+
+// clear removes the open tasks and external tasks of a process instance.
+func (e *Engine) clear(processInstanceId string) {
+	for k, v := range e.tasks {
+		if v.ProcessInstanceId == processInstanceId {
+			delete(e.tasks, k)
+			delete(e.links, k)
+		}
+	}
+
+	for k, v := range e.externalTasks {
+		if v.ProcessInstanceId == processInstanceId {
+			delete(e.externalTasks, k)
+		}
+	}
+}
+
+// id returns the next deterministic id for the given kind of entity, e.g. task-1.
+func (e *Engine) id(kind string) string {
+	e.seq[kind]++
+
+	return fmt.Sprintf("%s-%d", kind, e.seq[kind])
+}
+
+// now returns the current time of the simulated engine. The clock starts at a fixed date and
+// advances by one millisecond with every call, so timestamps are deterministic and strictly ordered.
+func (e *Engine) now() string {
+	e.clock = e.clock.Add(time.Millisecond)
+
+	return e.clock.Format(layout)
+}
+
+func (e *Engine) duration(start, end string) int {
+	s, _ := time.Parse(layout, start)
+	t, _ := time.Parse(layout, end)
+
+	return int(t.Sub(s) / time.Millisecond)
+}
+
+const layout = "2006-01-02T15:04:05.000-0700"
+
+// bounds returns the range of a page of results. A page without maxResults extends to the end, a
+// negative firstResult starts at the beginning.
+func bounds(length, firstResult, maxResults int) (int, int) {
+	from, to := firstResult, length
+
+	if from < 0 {
+		from = 0
+	}
+
+	if from > length {
+		from = length
+	}
+
+	if maxResults > 0 && from+maxResults < to {
+		to = from + maxResults
+	}
+
+	return from, to
+}
+
+func notFound(format string, args ...interface{}) error {
+	return &camunda.Error{Type: "InvalidRequestException", Message: fmt.Sprintf(format, args...)}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// less orders generated ids of the same kind by their sequence number.
+func less(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+
+	return a < b
+}
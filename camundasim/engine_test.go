@@ -0,0 +1,195 @@
+package camundasim
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	camunda "github.com/equipmegmbh/camunda-go"
+)
+
+const review = `<?xml version="1.0" encoding="UTF-8"?>
+<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL" xmlns:camunda="http://camunda.org/schema/1.0/bpmn" id="definitions" targetNamespace="http://bpmn.io/schema/bpmn">
+  <bpmn:process id="review" isExecutable="true">
+    <bpmn:startEvent id="start" />
+    <bpmn:userTask id="review-invoice" camunda:assignee="demo" />
+    <bpmn:endEvent id="end" />
+    <bpmn:sequenceFlow id="flow-1" sourceRef="start" targetRef="review-invoice" />
+    <bpmn:sequenceFlow id="flow-2" sourceRef="review-invoice" targetRef="end" />
+  </bpmn:process>
+</bpmn:definitions>`
+
+// fork creates a user task and an incident of the archive worker before the condition of the
+// gateway fails on an unknown variable.
+const fork = `<?xml version="1.0" encoding="UTF-8"?>
+<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL" xmlns:camunda="http://camunda.org/schema/1.0/bpmn" id="definitions" targetNamespace="http://bpmn.io/schema/bpmn">
+  <bpmn:process id="review" isExecutable="true">
+    <bpmn:startEvent id="start" />
+    <bpmn:parallelGateway id="fork" />
+    <bpmn:userTask id="review-invoice" />
+    <bpmn:serviceTask id="archive" camunda:type="external" camunda:topic="archive" />
+    <bpmn:exclusiveGateway id="approved" />
+    <bpmn:endEvent id="reviewed" />
+    <bpmn:endEvent id="archived" />
+    <bpmn:endEvent id="paid" />
+    <bpmn:sequenceFlow id="flow-1" sourceRef="start" targetRef="fork" />
+    <bpmn:sequenceFlow id="flow-2" sourceRef="fork" targetRef="review-invoice" />
+    <bpmn:sequenceFlow id="flow-3" sourceRef="fork" targetRef="archive" />
+    <bpmn:sequenceFlow id="flow-4" sourceRef="fork" targetRef="approved" />
+    <bpmn:sequenceFlow id="flow-5" sourceRef="review-invoice" targetRef="reviewed" />
+    <bpmn:sequenceFlow id="flow-6" sourceRef="archive" targetRef="archived" />
+    <bpmn:sequenceFlow id="flow-7" sourceRef="approved" targetRef="paid">
+      <bpmn:conditionExpression>${approved}</bpmn:conditionExpression>
+    </bpmn:sequenceFlow>
+  </bpmn:process>
+</bpmn:definitions>`
+
+func TestBounds(t *testing.T) {
+	tests := []struct {
+		name        string
+		length      int
+		firstResult int
+		maxResults  int
+		from        int
+		to          int
+	}{
+		{"all results", 5, 0, 0, 0, 5},
+		{"first page", 5, 0, 2, 0, 2},
+		{"middle page", 5, 2, 2, 2, 4},
+		{"last page", 5, 4, 2, 4, 5},
+		{"beyond the end", 5, 7, 2, 5, 5},
+		{"negative first result", 5, -3, 2, 0, 2},
+		{"negative first result without max results", 5, -1, 0, 0, 5},
+		{"no results", 0, 0, 10, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := bounds(tt.length, tt.firstResult, tt.maxResults)
+
+			require.Equal(t, tt.from, from)
+			require.Equal(t, tt.to, to)
+		})
+	}
+}
+
+func TestEngineReturnsCopies(t *testing.T) {
+	ctx := context.Background()
+	e := NewEngine()
+
+	_, err := e.CreateDeployment(ctx, "", "review", "review.bpmn", strings.NewReader(review))
+
+	require.NoError(t, err)
+
+	instance, err := e.StartProcessDefinitionByKey(ctx, "review", nil)
+
+	require.NoError(t, err)
+
+	definition, err := e.GetProcessDefinitionByKey(ctx, "review")
+
+	require.NoError(t, err)
+
+	definition.Name = "changed"
+
+	tasks, err := e.GetTasks(ctx, instance.Id)
+
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+
+	tasks[0].Assignee = "changed"
+
+	task, err := e.GetTask(ctx, tasks[0].Id)
+
+	require.NoError(t, err)
+	require.Equal(t, "demo", task.Assignee)
+
+	task.Assignee = "changed"
+
+	links, err := e.GetTaskIdentityLinks(ctx, task.Id, "")
+
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+
+	links[0].UserId = "changed"
+
+	activities, err := e.GetHistoricActivityInstances(ctx, nil, 0, 0)
+
+	require.NoError(t, err)
+
+	activities[0].ActivityId = "changed"
+
+	instances, err := e.GetProcessInstances(ctx, "")
+
+	require.NoError(t, err)
+
+	instances[0].BusinessKey = "changed"
+
+	definition, err = e.GetProcessDefinitionByKey(ctx, "review")
+
+	require.NoError(t, err)
+	require.Empty(t, definition.Name)
+
+	task, err = e.GetTask(ctx, tasks[0].Id)
+
+	require.NoError(t, err)
+	require.Equal(t, "demo", task.Assignee)
+
+	links, err = e.GetTaskIdentityLinks(ctx, task.Id, "")
+
+	require.NoError(t, err)
+	require.Equal(t, "demo", links[0].UserId)
+
+	activities, err = e.GetHistoricActivityInstances(ctx, nil, 0, 0)
+
+	require.NoError(t, err)
+	require.NotEqual(t, "changed", activities[0].ActivityId)
+
+	instance, err = e.GetProcessInstance(ctx, instance.Id)
+
+	require.NoError(t, err)
+	require.Empty(t, instance.BusinessKey)
+}
+
+func TestFailedStartLeavesNoInstance(t *testing.T) {
+	ctx := context.Background()
+	e := NewEngine()
+
+	_, err := e.CreateDeployment(ctx, "", "review", "review.bpmn", strings.NewReader(fork))
+
+	require.NoError(t, err)
+
+	e.HandleTopic("archive", func(ctx context.Context, variables map[string]*camunda.Variable) (map[string]*camunda.Variable, error) {
+		return nil, errors.New("archive unavailable")
+	})
+
+	_, err = e.StartProcessDefinitionByKey(ctx, "review", nil)
+
+	require.Error(t, err)
+
+	instances, err := e.GetProcessInstances(ctx, "")
+
+	require.NoError(t, err)
+	require.Empty(t, instances)
+
+	_, err = e.GetHistoricProcessInstance(ctx, "process-instance-1")
+
+	require.Error(t, err)
+
+	tasks, err := e.GetTasks(ctx, "")
+
+	require.NoError(t, err)
+	require.Empty(t, tasks)
+
+	activities, err := e.GetHistoricActivityInstances(ctx, nil, 0, 0)
+
+	require.NoError(t, err)
+	require.Empty(t, activities)
+
+	incidents, err := e.GetIncidents(ctx, nil, 0, 0)
+
+	require.NoError(t, err)
+	require.Empty(t, incidents)
+}
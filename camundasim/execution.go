@@ -0,0 +1,390 @@
+package camundasim
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	camunda "github.com/equipmegmbh/camunda-go"
+)
+
+// instance is a running or ended process instance.
+type instance struct {
+	data       *camunda.ProcessInstance
+	history    *camunda.HistoricProcessInstance
+	definition *definition
+	variables  map[string]*camunda.Variable
+	executions map[string]*execution
+	joins      map[string]int
+}
+
+// execution is a token of a process instance. It either moves through the process or waits at
+// a user task, an external task, a message catch event or a failed service task.
+type execution struct {
+	id       string
+	node     *node
+	activity *camunda.HistoricActivityInstance
+}
+
+// activityTypes maps the kinds of nodes to the activity types the engine reports in the history.
+var activityTypes = map[string]string{
+	nodeEndEvent:          "noneEndEvent",
+	nodeIntermediateCatch: "intermediateMessageCatch",
+	nodeIntermediateThrow: "intermediateNoneThrowEvent",
+}
+
+// values returns the values of the variables of an instance for the evaluation of expressions.
+func (i *instance) values() map[string]interface{} {
+	result := make(map[string]interface{}, len(i.variables))
+
+	for k, v := range i.variables {
+		result[k] = v.Value
+	}
+
+	return result
+}
+
+// merge sets the given variables on the instance.
+func (i *instance) merge(variables map[string]*camunda.Variable) {
+	for k, v := range variables {
+		i.variables[k] = v
+	}
+}
+
+// run moves the given executions through the process until every execution waits or ended.
+func (e *Engine) run(ctx context.Context, inst *instance, queue []*execution) error {
+	for len(queue) > 0 {
+		exec := queue[0]
+		queue = queue[1:]
+
+		next, err := e.enter(ctx, inst, exec)
+
+		if err != nil {
+			return err
+		}
+
+		queue = append(queue, next...)
+	}
+
+	if len(inst.executions) == 0 && !inst.data.Ended {
+		inst.data.Ended = true
+		inst.history.State = "COMPLETED"
+		inst.history.EndTime = e.now()
+		inst.history.DurationInMillis = e.duration(inst.history.StartTime, inst.history.EndTime)
+	}
+
+	return nil
+}
+
+// enter executes the node an execution arrived at. It returns the executions that move on.
+func (e *Engine) enter(ctx context.Context, inst *instance, exec *execution) ([]*execution, error) {
+	n := exec.node
+
+	kind := activityTypes[n.kind]
+
+	if kind == "" {
+		kind = n.kind
+	}
+
+	exec.activity = &camunda.HistoricActivityInstance{
+		Id:                    n.id + ":" + e.id("activity"),
+		ActivityId:            n.id,
+		ActivityName:          n.name,
+		ActivityType:          kind,
+		ProcessDefinitionKey:  inst.definition.data.Key,
+		ProcessDefinitionId:   inst.definition.data.Id,
+		ProcessInstanceId:     inst.data.Id,
+		RootProcessInstanceId: inst.data.Id,
+		ExecutionId:           exec.id,
+		StartTime:             e.now(),
+		TenantId:              inst.data.TenantId,
+	}
+
+	e.activities = append(e.activities, exec.activity)
+
+	switch {
+	case n.kind == nodeUserTask:
+		return nil, e.createTask(inst, exec)
+	case n.external():
+		return e.createExternalTask(ctx, inst, exec)
+	case n.kind == nodeIntermediateCatch || n.kind == nodeReceiveTask:
+		return nil, nil
+	case n.kind == nodeEndEvent:
+		e.end(inst, exec, false)
+		return nil, nil
+	case n.kind == nodeParallelGateway && len(n.incoming) > 1:
+		inst.joins[n.id]++
+
+		if inst.joins[n.id] < len(n.incoming) {
+			e.end(inst, exec, false)
+			return nil, nil
+		}
+
+		delete(inst.joins, n.id)
+	case n.kind == nodeServiceTask || n.kind == nodeSendTask:
+		if handler, ok := e.services[n.id]; ok {
+			variables, err := handler(ctx, copyVariables(inst.variables))
+
+			if err != nil {
+				e.fail(inst, exec, err)
+				return nil, nil
+			}
+
+			inst.merge(variables)
+		}
+	}
+
+	return e.leave(inst, exec)
+}
+
+// leave completes the activity of an execution and moves it over the outgoing sequence flows. An
+// exclusive gateway takes the first flow whose condition is true, any other node takes every flow
+// whose condition is true. The default flow is taken when no other flow is.
+func (e *Engine) leave(inst *instance, exec *execution) ([]*execution, error) {
+	n := exec.node
+
+	e.complete(exec.activity, false)
+
+	if len(n.outgoing) == 0 {
+		e.end(inst, exec, true)
+		return nil, nil
+	}
+
+	selected := make([]*flow, 0, len(n.outgoing))
+	var fallback *flow
+
+	for _, f := range n.outgoing {
+		if f.id == n.def {
+			fallback = f
+			continue
+		}
+
+		if f.condition != "" && n.kind != nodeParallelGateway {
+			ok, err := condition(f.condition, inst.values())
+
+			if err != nil {
+				return nil, fmt.Errorf("sequence flow %s: %w", f.id, err)
+			}
+
+			if !ok {
+				continue
+			}
+		}
+
+		selected = append(selected, f)
+
+		if n.kind == nodeExclusiveGateway {
+			break
+		}
+	}
+
+	if len(selected) == 0 && fallback != nil {
+		selected = append(selected, fallback)
+	}
+
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no outgoing sequence flow of %s %s can be taken", n.kind, n.id)
+	}
+
+	result := make([]*execution, 0, len(selected))
+
+	for i, f := range selected {
+		next := exec
+
+		if i > 0 {
+			next = &execution{id: e.id("execution")}
+			inst.executions[next.id] = next
+		}
+
+		next.node = f.target
+		next.activity = nil
+
+		result = append(result, next)
+	}
+
+	return result, nil
+}
+
+// resume moves an execution waiting at a node on.
+func (e *Engine) resume(ctx context.Context, inst *instance, exec *execution) error {
+	next, err := e.leave(inst, exec)
+
+	if err != nil {
+		return err
+	}
+
+	return e.run(ctx, inst, next)
+}
+
+// end removes an execution from its instance. The activity of the execution is completed, unless
+// it was already completed when the execution left it.
+func (e *Engine) end(inst *instance, exec *execution, completed bool) {
+	if !completed {
+		e.complete(exec.activity, false)
+	}
+
+	delete(inst.executions, exec.id)
+}
+
+func (e *Engine) complete(activity *camunda.HistoricActivityInstance, canceled bool) {
+	if activity == nil || activity.EndTime != "" {
+		return
+	}
+
+	activity.EndTime = e.now()
+	activity.DurationInMillis = e.duration(activity.StartTime, activity.EndTime)
+	activity.Canceled = canceled
+}
+
+func (e *Engine) createTask(inst *instance, exec *execution) error {
+	n := exec.node
+	values := inst.values()
+
+	assignee, err := e.text(n.attrs["assignee"], values)
+
+	if err != nil {
+		return err
+	}
+
+	task := &camunda.Task{
+		Id:                  e.id("task"),
+		Name:                n.name,
+		Assignee:            assignee,
+		Created:             e.now(),
+		ExecutionId:         exec.id,
+		ProcessDefinitionId: inst.definition.data.Id,
+		ProcessInstanceId:   inst.data.Id,
+		TaskDefinitionKey:   n.id,
+		FormKey:             n.attrs["formKey"],
+		TenantId:            inst.data.TenantId,
+		Priority:            50,
+	}
+
+	links := make([]*camunda.IdentityLink, 0)
+
+	for _, attr := range []string{"candidateUsers", "candidateGroups"} {
+		value, err := e.text(n.attrs[attr], values)
+
+		if err != nil {
+			return err
+		}
+
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v == "" {
+				continue
+			}
+
+			link := &camunda.IdentityLink{Type: camunda.IdentityLinkCandidate}
+
+			if attr == "candidateUsers" {
+				link.UserId = v
+			} else {
+				link.GroupId = v
+			}
+
+			links = append(links, link)
+		}
+	}
+
+	exec.activity.TaskId = task.Id
+	exec.activity.Assignee = task.Assignee
+
+	e.tasks[task.Id] = task
+	e.links[task.Id] = links
+
+	return nil
+}
+
+func (e *Engine) createExternalTask(ctx context.Context, inst *instance, exec *execution) ([]*execution, error) {
+	n := exec.node
+
+	if handler, ok := e.topics[n.attrs["topic"]]; ok {
+		variables, err := handler(ctx, copyVariables(inst.variables))
+
+		if err != nil {
+			e.fail(inst, exec, err)
+			return nil, nil
+		}
+
+		inst.merge(variables)
+
+		return e.leave(inst, exec)
+	}
+
+	task := &camunda.ExternalTask{
+		Id:                   e.id("external-task"),
+		ActivityId:           n.id,
+		ActivityInstanceId:   exec.activity.Id,
+		ExecutionId:          exec.id,
+		ProcessDefinitionId:  inst.definition.data.Id,
+		ProcessDefinitionKey: inst.definition.data.Key,
+		ProcessInstanceId:    inst.data.Id,
+		TopicName:            n.attrs["topic"],
+		BusinessKey:          inst.data.BusinessKey,
+		TenantId:             inst.data.TenantId,
+	}
+
+	e.externalTasks[task.Id] = task
+
+	return nil, nil
+}
+
+// fail raises an incident for an execution whose service task failed. The execution stays at
+// the service task.
+func (e *Engine) fail(inst *instance, exec *execution, err error) {
+	incident := &camunda.Incident{
+		Id:                  e.id("incident"),
+		ProcessDefinitionId: inst.definition.data.Id,
+		ProcessInstanceId:   inst.data.Id,
+		ExecutionId:         exec.id,
+		IncidentTimestamp:   e.now(),
+		IncidentType:        camunda.IncidentFailedJob,
+		ActivityId:          exec.node.id,
+		FailedActivityId:    exec.node.id,
+		Configuration:       exec.id,
+		TenantId:            inst.data.TenantId,
+		IncidentMessage:     err.Error(),
+	}
+
+	incident.RootCauseIncidentId = incident.Id
+
+	if exec.node.external() {
+		incident.IncidentType = camunda.IncidentFailedExternalTask
+	}
+
+	e.incidents = append(e.incidents, incident)
+}
+
+// text evaluates an attribute that may contain an expression to a string.
+func (e *Engine) text(value string, variables map[string]interface{}) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	result, err := evaluate(value, variables)
+
+	if err != nil {
+		return "", err
+	}
+
+	if result == nil {
+		return "", nil
+	}
+
+	if s, ok := result.(string); ok {
+		return s, nil
+	}
+
+	return fmt.Sprint(result), nil
+}
+
+func copyVariables(variables map[string]*camunda.Variable) map[string]*camunda.Variable {
+	result := make(map[string]*camunda.Variable, len(variables))
+
+	for k, v := range variables {
+		c := *v
+		result[k] = &c
+	}
+
+	return result
+}
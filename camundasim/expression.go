@@ -0,0 +1,584 @@
+package camundasim
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// The simulator evaluates a subset of JUEL: literals, variables and property access, the
+// arithmetic, relational and logical operators in their symbolic and textual forms, empty and
+// parentheses. Method calls and functions are not supported.
+
+// evaluate evaluates an expression of the form ${...} or #{...}. Any other text is returned as is.
+func evaluate(expression string, variables map[string]interface{}) (interface{}, error) {
+	text := strings.TrimSpace(expression)
+
+	if !(strings.HasPrefix(text, "${") || strings.HasPrefix(text, "#{")) || !strings.HasSuffix(text, "}") {
+		return expression, nil
+	}
+
+	tokens, err := tokenize(text[2 : len(text)-1])
+
+	if err != nil {
+		return nil, fmt.Errorf("expression %s: %w", expression, err)
+	}
+
+	p := &parser{tokens: tokens, variables: variables}
+
+	result, err := p.or()
+
+	if err == nil && p.pos < len(p.tokens) {
+		err = fmt.Errorf("unexpected %s", p.tokens[p.pos].text)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("expression %s: %w", expression, err)
+	}
+
+	return result, nil
+}
+
+// condition evaluates the condition of a sequence flow.
+func condition(expression string, variables map[string]interface{}) (bool, error) {
+	result, err := evaluate(expression, variables)
+
+	if err != nil {
+		return false, err
+	}
+
+	v, ok := result.(bool)
+
+	if !ok {
+		return false, fmt.Errorf("condition %s does not evaluate to a boolean", expression)
+	}
+
+	return v, nil
+}
+
+type token struct {
+	kind string
+	text string
+}
+
+const (
+	tokenNumber     = "number"
+	tokenString     = "string"
+	tokenIdentifier = "identifier"
+	tokenOperator   = "operator"
+)
+
+// keywords are the textual operators of JUEL, mapped to their symbolic form.
+var keywords = map[string]string{
+	"and":   "&&",
+	"or":    "||",
+	"not":   "!",
+	"eq":    "==",
+	"ne":    "!=",
+	"lt":    "<",
+	"gt":    ">",
+	"le":    "<=",
+	"ge":    ">=",
+	"div":   "/",
+	"mod":   "%",
+	"empty": "empty",
+}
+
+func tokenize(text string) ([]*token, error) {
+	result := make([]*token, 0)
+	runes := []rune(text)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r):
+			j := i
+
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+
+			if strings.Count(string(runes[i:j]), ".") > 1 {
+				return nil, fmt.Errorf("invalid number %s", string(runes[i:j]))
+			}
+
+			result = append(result, &token{kind: tokenNumber, text: string(runes[i:j])})
+			i = j
+		case r == '\'' || r == '"':
+			j := i + 1
+			b := strings.Builder{}
+
+			for ; j < len(runes) && runes[j] != r; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					j++
+				}
+
+				b.WriteRune(runes[j])
+			}
+
+			if j == len(runes) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+
+			result = append(result, &token{kind: tokenString, text: b.String()})
+			i = j + 1
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_') {
+				j++
+			}
+
+			word := string(runes[i:j])
+
+			if op, ok := keywords[word]; ok {
+				result = append(result, &token{kind: tokenOperator, text: op})
+			} else {
+				result = append(result, &token{kind: tokenIdentifier, text: word})
+			}
+
+			i = j
+		default:
+			op := ""
+
+			if i+1 < len(runes) {
+				switch string(runes[i : i+2]) {
+				case "&&", "||", "==", "!=", "<=", ">=":
+					op = string(runes[i : i+2])
+				}
+			}
+
+			if op == "" && strings.ContainsRune("!<>+-*/%().", r) {
+				op = string(r)
+			}
+
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character %q", r)
+			}
+
+			result = append(result, &token{kind: tokenOperator, text: op})
+			i += len(op)
+		}
+	}
+
+	return result, nil
+}
+
+// parser evaluates the tokens while it parses them. Operands skipped by the short-circuit
+// evaluation of && and || are parsed with skip > 0, which evaluates them to nil without errors.
+type parser struct {
+	tokens    []*token
+	pos       int
+	variables map[string]interface{}
+	skip      int
+}
+
+// accept consumes the next token if it is one of the given operators.
+func (p *parser) accept(operators ...string) string {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokenOperator {
+		return ""
+	}
+
+	for _, v := range operators {
+		if p.tokens[p.pos].text == v {
+			p.pos++
+			return v
+		}
+	}
+
+	return ""
+}
+
+func (p *parser) or() (interface{}, error) {
+	left, err := p.and()
+
+	for err == nil && p.accept("||") != "" {
+		left, err = p.logical(left, true, p.and)
+	}
+
+	return left, err
+}
+
+func (p *parser) and() (interface{}, error) {
+	left, err := p.equality()
+
+	for err == nil && p.accept("&&") != "" {
+		left, err = p.logical(left, false, p.equality)
+	}
+
+	return left, err
+}
+
+// logical evaluates the right operand of && or || unless the left operand already decides the
+// result, which is the case when it equals decisive.
+func (p *parser) logical(left interface{}, decisive bool, operand func() (interface{}, error)) (interface{}, error) {
+	l, err := p.boolean(left)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if l == decisive {
+		p.skip++
+		_, err = operand()
+		p.skip--
+
+		return decisive, err
+	}
+
+	right, err := operand()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return p.boolean(right)
+}
+
+func (p *parser) equality() (interface{}, error) {
+	left, err := p.relational()
+
+	for err == nil {
+		op := p.accept("==", "!=")
+
+		if op == "" {
+			break
+		}
+
+		var right interface{}
+
+		if right, err = p.relational(); err == nil {
+			left, err = p.guard(equal(left, right) == (op == "=="), nil)
+		}
+	}
+
+	return left, err
+}
+
+func (p *parser) relational() (interface{}, error) {
+	left, err := p.additive()
+
+	for err == nil {
+		op := p.accept("<=", ">=", "<", ">")
+
+		if op == "" {
+			break
+		}
+
+		var right interface{}
+
+		if right, err = p.additive(); err != nil {
+			break
+		}
+
+		left, err = p.guard(compare(op, left, right))
+	}
+
+	return left, err
+}
+
+func (p *parser) additive() (interface{}, error) {
+	left, err := p.multiplicative()
+
+	for err == nil {
+		op := p.accept("+", "-")
+
+		if op == "" {
+			break
+		}
+
+		var right interface{}
+
+		if right, err = p.multiplicative(); err != nil {
+			break
+		}
+
+		left, err = p.guard(arithmetic(op, left, right))
+	}
+
+	return left, err
+}
+
+func (p *parser) multiplicative() (interface{}, error) {
+	left, err := p.unary()
+
+	for err == nil {
+		op := p.accept("*", "/", "%")
+
+		if op == "" {
+			break
+		}
+
+		var right interface{}
+
+		if right, err = p.unary(); err != nil {
+			break
+		}
+
+		left, err = p.guard(arithmetic(op, left, right))
+	}
+
+	return left, err
+}
+
+func (p *parser) unary() (interface{}, error) {
+	switch p.accept("!", "-", "empty") {
+	case "!":
+		v, err := p.unary()
+
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := p.boolean(v)
+
+		return !b, err
+	case "-":
+		v, err := p.unary()
+
+		if err != nil {
+			return nil, err
+		}
+
+		return p.guard(arithmetic("-", 0.0, v))
+	case "empty":
+		v, err := p.unary()
+
+		return empty(v), err
+	}
+
+	return p.primary()
+}
+
+func (p *parser) primary() (interface{}, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+
+	t := p.tokens[p.pos]
+	p.pos++
+
+	switch t.kind {
+	case tokenNumber:
+		return strconv.ParseFloat(t.text, 64)
+	case tokenString:
+		return t.text, nil
+	case tokenIdentifier:
+		return p.identifier(t.text)
+	}
+
+	if t.text != "(" {
+		return nil, fmt.Errorf("unexpected %s", t.text)
+	}
+
+	v, err := p.or()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if p.accept(")") == "" {
+		return nil, fmt.Errorf("missing )")
+	}
+
+	return v, nil
+}
+
+func (p *parser) identifier(name string) (interface{}, error) {
+	switch name {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+
+	value, ok := p.variables[name]
+
+	if !ok && p.skip == 0 {
+		return nil, fmt.Errorf("unknown property %s", name)
+	}
+
+	for p.accept(".") != "" {
+		if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokenIdentifier {
+			return nil, fmt.Errorf("missing property name after %s", name)
+		}
+
+		property := p.tokens[p.pos].text
+		p.pos++
+
+		m, ok := value.(map[string]interface{})
+
+		if !ok && p.skip == 0 {
+			return nil, fmt.Errorf("property %s of %s is not accessible", property, name)
+		}
+
+		value = m[property]
+		name = name + "." + property
+	}
+
+	return normalize(value), nil
+}
+
+// normalize converts numbers to float64, so they can be compared regardless of their type.
+func normalize(value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	}
+
+	return value
+}
+
+// guard drops the result and the error of an operation in an operand skipped by the short-circuit
+// evaluation.
+func (p *parser) guard(value interface{}, err error) (interface{}, error) {
+	if p.skip > 0 {
+		return nil, nil
+	}
+
+	return value, err
+}
+
+// boolean coerces a value to a boolean like JUEL: null is false, strings are true when they equal
+// true ignoring case, other values can not be coerced.
+func (p *parser) boolean(value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	case string:
+		return strings.EqualFold(v, "true"), nil
+	}
+
+	if p.skip > 0 {
+		return false, nil
+	}
+
+	return false, fmt.Errorf("cannot coerce %v to a boolean", value)
+}
+
+func empty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+
+	return false
+}
+
+func equal(a, b interface{}) bool {
+	a, b = normalize(a), normalize(b)
+
+	if x, ok := a.(float64); ok {
+		if y, ok := number(b); ok {
+			return x == y
+		}
+	}
+
+	if y, ok := b.(float64); ok {
+		if x, ok := number(a); ok {
+			return x == y
+		}
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+func number(value interface{}) (float64, bool) {
+	switch v := normalize(value).(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+
+		return f, err == nil
+	}
+
+	return 0, false
+}
+
+// compare applies a relational operator. Comparisons with null are false, as in JUEL.
+func compare(op string, a, b interface{}) (interface{}, error) {
+	if a == nil || b == nil {
+		return false, nil
+	}
+
+	x, xs := a.(string)
+	y, ys := b.(string)
+
+	var c int
+
+	if xs && ys {
+		c = strings.Compare(x, y)
+	} else {
+		m, ok := number(a)
+		n, ok2 := number(b)
+
+		if !ok || !ok2 {
+			return nil, fmt.Errorf("cannot compare %v and %v", a, b)
+		}
+
+		switch {
+		case m < n:
+			c = -1
+		case m > n:
+			c = 1
+		}
+	}
+
+	switch op {
+	case "<":
+		return c < 0, nil
+	case ">":
+		return c > 0, nil
+	case "<=":
+		return c <= 0, nil
+	}
+
+	return c >= 0, nil
+}
+
+// arithmetic applies an arithmetic operator. Unlike JUEL, null operands are an error rather than 0,
+// so a missing variable is not hidden by the result.
+func arithmetic(op string, a, b interface{}) (interface{}, error) {
+	x, ok := number(a)
+	y, ok2 := number(b)
+
+	if !ok || !ok2 {
+		return nil, fmt.Errorf("cannot apply %s to %v and %v", op, a, b)
+	}
+
+	switch op {
+	case "+":
+		return x + y, nil
+	case "-":
+		return x - y, nil
+	case "*":
+		return x * y, nil
+	case "/":
+		return x / y, nil
+	}
+
+	return math.Mod(x, y), nil
+}
@@ -0,0 +1,153 @@
+package camundasim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluate(t *testing.T) {
+	variables := map[string]interface{}{
+		"amount":   int64(150),
+		"rate":     0.5,
+		"name":     "invoice",
+		"approved": true,
+		"flag":     "TRUE",
+		"none":     nil,
+		"items":    []interface{}{},
+		"customer": map[string]interface{}{"name": "ACME", "address": map[string]interface{}{"city": "Berlin"}},
+	}
+
+	tests := []struct {
+		name       string
+		expression string
+		expected   interface{}
+	}{
+		{"plain text", "invoice", "invoice"},
+		{"integer", "${42}", 42.0},
+		{"decimal", "${1.25}", 1.25},
+		{"single quoted string", "${'a\\'b'}", "a'b"},
+		{"double quoted string", `${"text"}`, "text"},
+		{"true", "${true}", true},
+		{"false", "#{false}", false},
+		{"null", "${null}", nil},
+		{"variable", "${amount}", 150.0},
+		{"property", "${customer.name}", "ACME"},
+		{"nested property", "${customer.address.city}", "Berlin"},
+		{"missing property", "${customer.phone}", nil},
+		{"addition", "${amount + 50}", 200.0},
+		{"subtraction", "${amount - 50}", 100.0},
+		{"multiplication", "${amount * rate}", 75.0},
+		{"division", "${amount / 4}", 37.5},
+		{"textual division", "${amount div 3}", 50.0},
+		{"modulo", "${amount % 7}", 3.0},
+		{"textual modulo", "${amount mod 7}", 3.0},
+		{"negation", "${-amount}", -150.0},
+		{"precedence of multiplication", "${2 + 3 * 4}", 14.0},
+		{"parentheses", "${(2 + 3) * 4}", 20.0},
+		{"left associativity", "${10 - 4 - 3}", 3.0},
+		{"numeric string arithmetic", "${'2' * 3}", 6.0},
+		{"equal", "${amount == 150}", true},
+		{"textual equal", "${name eq 'invoice'}", true},
+		{"not equal", "${amount != 150}", false},
+		{"textual not equal", "${name ne 'order'}", true},
+		{"equal numeric string", "${amount == '150'}", true},
+		{"null equals null", "${none == null}", true},
+		{"null does not equal zero", "${none == 0}", false},
+		{"less", "${amount < 200}", true},
+		{"greater", "${amount gt 200}", false},
+		{"less or equal", "${amount <= 150}", true},
+		{"greater or equal", "${amount ge 151}", false},
+		{"string comparison", "${'a' < 'b'}", true},
+		{"comparison with null", "${none < 1}", false},
+		{"comparison with null reversed", "${1 > none}", false},
+		{"and", "${approved && amount > 100}", true},
+		{"textual and", "${approved and amount > 200}", false},
+		{"or", "${!approved || amount > 100}", true},
+		{"textual or", "${false or false}", false},
+		{"not", "${!approved}", false},
+		{"textual not", "${not approved}", false},
+		{"precedence of and over or", "${true || false && false}", true},
+		{"precedence of comparison over and", "${amount > 100 && amount < 200}", true},
+		{"string true ignoring case", "${flag && true}", true},
+		{"string other than true", "${name || false}", false},
+		{"null is false", "${none || false}", false},
+		{"not null", "${!none}", true},
+		{"empty null", "${empty none}", true},
+		{"empty string", "${empty ''}", true},
+		{"empty list", "${empty items}", true},
+		{"not empty", "${not empty name}", true},
+		{"empty number", "${empty amount}", false},
+		{"and short-circuits on null", "${none != null && none.a > 1}", false},
+		{"or short-circuits on null", "${none == null || none.a > 1}", true},
+		{"and short-circuits unknown variables", "${false && unknown > 1}", false},
+		{"or short-circuits unknown variables", "${true || unknown.a}", true},
+		{"short-circuit skips arithmetic", "${false && none + 1 > 0}", false},
+		{"short-circuit skips coercion", "${true || amount}", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := evaluate(tt.expression, variables)
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	variables := map[string]interface{}{
+		"amount": 150,
+		"name":   "invoice",
+		"none":   nil,
+	}
+
+	tests := []struct {
+		name       string
+		expression string
+		message    string
+	}{
+		{"malformed number", "${1.2.3}", "invalid number 1.2.3"},
+		{"unterminated string", "${'open}", "unterminated string"},
+		{"unexpected character", "${amount # 2}", "unexpected character '#'"},
+		{"unknown variable", "${unknown}", "unknown property unknown"},
+		{"property of a non-object", "${name.length}", "property length of name is not accessible"},
+		{"property of null", "${none.a}", "property a of none is not accessible"},
+		{"missing property name", "${name.}", "missing property name after name"},
+		{"missing parenthesis", "${(1 + 2}", "missing )"},
+		{"trailing tokens", "${1 2}", "unexpected 2"},
+		{"arithmetic with null", "${none + 1}", "cannot apply + to <nil> and 1"},
+		{"negation of null", "${-none}", "cannot apply -"},
+		{"arithmetic with text", "${name * 2}", "cannot apply * to invoice and 2"},
+		{"comparison of text and number", "${name < 2}", "cannot compare invoice and 2"},
+		{"number as boolean", "${amount && true}", "cannot coerce 150 to a boolean"},
+		{"not of a number", "${!amount}", "cannot coerce 150 to a boolean"},
+		{"right operand not evaluated to boolean", "${true && amount}", "cannot coerce 150 to a boolean"},
+		{"error in the deciding operand", "${unknown && false}", "unknown property unknown"},
+		{"error in an evaluated right operand", "${true && unknown}", "unknown property unknown"},
+		{"syntax error in a skipped operand", "${false && (1 + }", "unexpected end"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := evaluate(tt.expression, variables)
+
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.message)
+		})
+	}
+}
+
+func TestCondition(t *testing.T) {
+	variables := map[string]interface{}{"amount": 150, "name": "invoice"}
+
+	result, err := condition("${amount > 100}", variables)
+
+	require.NoError(t, err)
+	require.True(t, result)
+
+	_, err = condition("${name}", variables)
+
+	require.EqualError(t, err, "condition ${name} does not evaluate to a boolean")
+}
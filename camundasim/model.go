@@ -0,0 +1,187 @@
+package camundasim
+
+import (
+	"fmt"
+
+	"github.com/equipmegmbh/camunda-go/bpmn"
+)
+
+// The kinds of flow nodes the simulator executes.
+const (
+	nodeStartEvent        = "startEvent"
+	nodeEndEvent          = "endEvent"
+	nodeTask              = "task"
+	nodeUserTask          = "userTask"
+	nodeServiceTask       = "serviceTask"
+	nodeSendTask          = "sendTask"
+	nodeScriptTask        = "scriptTask"
+	nodeReceiveTask       = "receiveTask"
+	nodeExclusiveGateway  = "exclusiveGateway"
+	nodeParallelGateway   = "parallelGateway"
+	nodeIntermediateCatch = "intermediateCatchEvent"
+	nodeIntermediateThrow = "intermediateThrowEvent"
+)
+
+// process is a parsed executable process.
+type process struct {
+	id    string
+	name  string
+	nodes map[string]*node
+	start *node
+}
+
+// node is a flow node of a process.
+type node struct {
+	id       string
+	name     string
+	kind     string
+	attrs    map[string]string
+	message  string
+	def      string
+	incoming []*flow
+	outgoing []*flow
+}
+
+// external returns whether the node is a service task implemented by an external worker.
+func (n *node) external() bool {
+	return (n.kind == nodeServiceTask || n.kind == nodeSendTask) && n.attrs["type"] == "external"
+}
+
+// flow is a sequence flow of a process.
+type flow struct {
+	id        string
+	source    *node
+	target    *node
+	condition string
+}
+
+// parse parses the executable processes of a BPMN 2.0 document.
+func parse(content string) ([]*process, error) {
+	definitions, err := bpmn.Parse(content)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*process, 0)
+
+	for _, v := range definitions.Processes {
+		if !v.Executable() {
+			continue
+		}
+
+		p, err := parseProcess(definitions, v)
+
+		if err != nil {
+			return nil, fmt.Errorf("process %s: %w", v.Id, err)
+		}
+
+		result = append(result, p)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no executable process found")
+	}
+
+	return result, nil
+}
+
+func parseProcess(definitions *bpmn.Definitions, bp *bpmn.Process) (*process, error) {
+	p := &process{id: bp.Id, name: bp.Name, nodes: make(map[string]*node)}
+
+	for _, v := range bp.Elements() {
+		base := v.Base()
+
+		switch base.Kind {
+		case nodeStartEvent, nodeEndEvent, nodeTask, nodeUserTask, nodeServiceTask, nodeSendTask, nodeScriptTask,
+			nodeReceiveTask, nodeExclusiveGateway, nodeParallelGateway, nodeIntermediateCatch, nodeIntermediateThrow:
+		default:
+			return nil, fmt.Errorf("unsupported element %s %s", base.Kind, base.Id)
+		}
+
+		n := &node{id: base.Id, name: base.Name, kind: base.Kind, attrs: make(map[string]string)}
+
+		switch e := v.(type) {
+		case *bpmn.Task:
+			n.attrs["type"] = e.Type
+			n.attrs["topic"] = e.Topic
+			n.attrs["assignee"] = e.Assignee
+			n.attrs["candidateUsers"] = e.CandidateUsers
+			n.attrs["candidateGroups"] = e.CandidateGroups
+			n.attrs["formKey"] = e.FormKey
+		case *bpmn.Gateway:
+			n.def = e.Default
+		}
+
+		if err := eventDefinition(definitions, n, v); err != nil {
+			return nil, err
+		}
+
+		if n.kind == nodeStartEvent {
+			if p.start != nil {
+				return nil, fmt.Errorf("multiple start events are not supported")
+			}
+
+			p.start = n
+		}
+
+		p.nodes[n.id] = n
+	}
+
+	if p.start == nil {
+		return nil, fmt.Errorf("no start event found")
+	}
+
+	for _, v := range bp.Elements() {
+		for _, sf := range bp.Outgoing(v.Base().Id) {
+			f := &flow{id: sf.Id, source: p.nodes[sf.SourceRef], target: p.nodes[sf.TargetRef], condition: sf.Condition()}
+
+			f.source.outgoing = append(f.source.outgoing, f)
+			f.target.incoming = append(f.target.incoming, f)
+		}
+	}
+
+	return p, nil
+}
+
+// eventDefinition reads the event definition of an event or receive task. Only message catch events
+// are supported, other event definitions are rejected.
+func eventDefinition(definitions *bpmn.Definitions, n *node, element bpmn.FlowNode) error {
+	message := func(id string) string {
+		if m := definitions.Message(id); m != nil {
+			return m.Name
+		}
+
+		return ""
+	}
+
+	switch e := element.(type) {
+	case *bpmn.Task:
+		if n.kind != nodeReceiveTask {
+			return nil
+		}
+
+		if n.message = message(e.MessageRef); n.message == "" {
+			return fmt.Errorf("receive task %s references no message", n.id)
+		}
+	case *bpmn.Event:
+		if e.None() {
+			break
+		}
+
+		other := *e
+		other.Message = nil
+
+		if e.Message == nil || n.kind != nodeIntermediateCatch || !other.None() {
+			return fmt.Errorf("unsupported event definition on %s %s", n.kind, n.id)
+		}
+
+		n.message = message(e.Message.MessageRef)
+	}
+
+	if n.kind == nodeIntermediateCatch && n.message == "" {
+		return fmt.Errorf("intermediate catch event %s references no message", n.id)
+	}
+
+	return nil
+}
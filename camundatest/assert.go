@@ -0,0 +1,491 @@
+package camundatest
+
+import (
+	"context"
+	"sort"
+
+	camunda "github.com/equipmegmbh/camunda-go"
+	"github.com/stretchr/testify/require"
+)
+
+// ProcessInstanceAssert asserts the state of a process instance. Assertions fail the test
+// immediately, so they can be chained:
+//
+//	AssertThat(t, instance).IsWaitingAt("review").HasPassed("validate").HasVariables("amount")
+type ProcessInstanceAssert struct {
+	t        require.TestingT
+	ctx      context.Context
+	engine   Engine
+	instance *camunda.ProcessInstance
+}
+
+// AssertThat starts the assertions on a process instance, against the engine configured by
+// ConfigureEngine.
+func AssertThat(t require.TestingT, instance *camunda.ProcessInstance) *ProcessInstanceAssert {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	require.NotNil(t, instance, "process instance must not be nil")
+
+	return &ProcessInstanceAssert{t: t, ctx: context.Background(), engine: engine, instance: instance}
+}
+
+// WithEngine returns the assertions against another engine.
+func (a *ProcessInstanceAssert) WithEngine(e Engine) *ProcessInstanceAssert {
+	result := *a
+	result.engine = e
+
+	return &result
+}
+
+// WithContext returns the assertions with the context requests to the engine are sent with.
+func (a *ProcessInstanceAssert) WithContext(ctx context.Context) *ProcessInstanceAssert {
+	result := *a
+	result.ctx = ctx
+
+	return &result
+}
+
+// IsActive asserts that the process instance has not ended.
+func (a *ProcessInstanceAssert) IsActive() *ProcessInstanceAssert {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	history := a.history()
+
+	require.Emptyf(a.t, history.EndTime, "expected process instance %s to be active, but it ended in state %s", a.instance.Id, history.State)
+
+	return a
+}
+
+// IsEnded asserts that the process instance has ended, either completed or terminated.
+func (a *ProcessInstanceAssert) IsEnded() *ProcessInstanceAssert {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	history := a.history()
+
+	require.NotEmptyf(a.t, history.EndTime, "expected process instance %s to be ended, but it is waiting at %v", a.instance.Id, a.waiting())
+
+	return a
+}
+
+// IsWaitingAt asserts that the process instance is waiting at all of the given activities.
+func (a *ProcessInstanceAssert) IsWaitingAt(activityIds ...string) *ProcessInstanceAssert {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	waiting := a.waiting()
+
+	for _, v := range activityIds {
+		require.Containsf(a.t, waiting, v, "expected process instance %s to be waiting at %s, but it is waiting at %v", a.instance.Id, v, waiting)
+	}
+
+	return a
+}
+
+// IsNotWaitingAt asserts that the process instance is waiting at none of the given activities.
+func (a *ProcessInstanceAssert) IsNotWaitingAt(activityIds ...string) *ProcessInstanceAssert {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	waiting := a.waiting()
+
+	for _, v := range activityIds {
+		require.NotContainsf(a.t, waiting, v, "expected process instance %s not to be waiting at %s", a.instance.Id, v)
+	}
+
+	return a
+}
+
+// HasPassed asserts that the process instance has completed all of the given activities.
+func (a *ProcessInstanceAssert) HasPassed(activityIds ...string) *ProcessInstanceAssert {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	passed := a.passed()
+
+	for _, v := range activityIds {
+		require.Containsf(a.t, passed, v, "expected process instance %s to have passed %s, but it passed %v", a.instance.Id, v, passed)
+	}
+
+	return a
+}
+
+// HasNotPassed asserts that the process instance has completed none of the given activities.
+func (a *ProcessInstanceAssert) HasNotPassed(activityIds ...string) *ProcessInstanceAssert {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	passed := a.passed()
+
+	for _, v := range activityIds {
+		require.NotContainsf(a.t, passed, v, "expected process instance %s not to have passed %s", a.instance.Id, v)
+	}
+
+	return a
+}
+
+// HasVariables asserts that the running process instance has all of the given variables. Without
+// names, it asserts that the process instance has any variable.
+func (a *ProcessInstanceAssert) HasVariables(names ...string) *ProcessInstanceAssert {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	variables := a.variables()
+
+	if len(names) == 0 {
+		require.NotEmptyf(a.t, variables, "expected process instance %s to have variables", a.instance.Id)
+	}
+
+	for _, v := range names {
+		require.Containsf(a.t, variables, v, "expected process instance %s to have variable %s, but it has %v", a.instance.Id, v, keys(variables))
+	}
+
+	return a
+}
+
+// HasNoVariables asserts that the running process instance has no variables.
+func (a *ProcessInstanceAssert) HasNoVariables() *ProcessInstanceAssert {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	variables := a.variables()
+
+	require.Emptyf(a.t, variables, "expected process instance %s to have no variables, but it has %v", a.instance.Id, keys(variables))
+
+	return a
+}
+
+// HasVariableValue asserts that a variable of the running process instance has the given value.
+// Values are compared with ObjectsAreEqualValues, so numbers of different types are equal.
+func (a *ProcessInstanceAssert) HasVariableValue(name string, value interface{}) *ProcessInstanceAssert {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	variables := a.variables()
+
+	require.Containsf(a.t, variables, name, "expected process instance %s to have variable %s", a.instance.Id, name)
+	require.EqualValuesf(a.t, value, variables[name].Value, "unexpected value of variable %s of process instance %s", name, a.instance.Id)
+
+	return a
+}
+
+// Task asserts that the process instance has exactly one open user task and starts the assertions
+// on it.
+func (a *ProcessInstanceAssert) Task() *TaskAssert {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	tasks, err := a.engine.GetTasks(a.ctx, a.instance.Id)
+
+	require.NoError(a.t, err)
+	require.Lenf(a.t, tasks, 1, "expected process instance %s to have exactly one open task", a.instance.Id)
+
+	return &TaskAssert{parent: a, task: tasks[0]}
+}
+
+// TaskByKey asserts that the process instance has an open user task with the given task definition
+// key and starts the assertions on it.
+func (a *ProcessInstanceAssert) TaskByKey(taskDefinitionKey string) *TaskAssert {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	tasks, err := a.engine.GetTasks(a.ctx, a.instance.Id)
+
+	require.NoError(a.t, err)
+
+	for _, v := range tasks {
+		if v.TaskDefinitionKey == taskDefinitionKey {
+			return &TaskAssert{parent: a, task: v}
+		}
+	}
+
+	require.FailNowf(a.t, "task not found", "expected process instance %s to have an open task %s", a.instance.Id, taskDefinitionKey)
+
+	return nil
+}
+
+// ExternalTask asserts that the process instance has exactly one open external task and starts
+// the assertions on it.
+func (a *ProcessInstanceAssert) ExternalTask() *ExternalTaskAssert {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	tasks, err := a.engine.GetExternalTasks(a.ctx, &camunda.ExternalTaskQuery{ProcessInstanceId: a.instance.Id}, 0, 0)
+
+	require.NoError(a.t, err)
+	require.Lenf(a.t, tasks, 1, "expected process instance %s to have exactly one open external task", a.instance.Id)
+
+	return &ExternalTaskAssert{parent: a, task: tasks[0]}
+}
+
+// CompleteTask completes the only open user task of the process instance with the given variables.
+func (a *ProcessInstanceAssert) CompleteTask(variables map[string]*camunda.Variable) *ProcessInstanceAssert {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return a.Task().Complete(variables)
+}
+
+// CompleteExternalTask completes the only open external task of the process instance with the given
+// variables.
+func (a *ProcessInstanceAssert) CompleteExternalTask(variables map[string]*camunda.Variable) *ProcessInstanceAssert {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return a.ExternalTask().Complete(variables)
+}
+
+func (a *ProcessInstanceAssert) history() *camunda.HistoricProcessInstance {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	h, err := a.engine.GetHistoricProcessInstance(a.ctx, a.instance.Id)
+
+	require.NoError(a.t, err)
+
+	return h
+}
+
+func (a *ProcessInstanceAssert) activities(query *camunda.HistoricActivityInstanceQuery) []string {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	query.ProcessInstanceId = a.instance.Id
+
+	activities, err := a.engine.GetHistoricActivityInstances(a.ctx, query, 0, 0)
+
+	require.NoError(a.t, err)
+
+	result := make([]string, 0, len(activities))
+
+	for _, v := range activities {
+		result = append(result, v.ActivityId)
+	}
+
+	return result
+}
+
+// waiting returns the ids of the activities the process instance is waiting at.
+func (a *ProcessInstanceAssert) waiting() []string {
+	return a.activities(&camunda.HistoricActivityInstanceQuery{Unfinished: true})
+}
+
+// passed returns the ids of the activities the process instance has completed.
+func (a *ProcessInstanceAssert) passed() []string {
+	return a.activities(&camunda.HistoricActivityInstanceQuery{Finished: true})
+}
+
+func (a *ProcessInstanceAssert) variables() map[string]*camunda.Variable {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	variables, err := a.engine.GetProcessInstanceVariables(a.ctx, a.instance.Id, true)
+
+	require.NoError(a.t, err)
+
+	return variables
+}
+
+// TaskAssert asserts the state of an open user task.
+type TaskAssert struct {
+	parent *ProcessInstanceAssert
+	task   *camunda.Task
+}
+
+// Task returns the asserted task.
+func (a *TaskAssert) Task() *camunda.Task {
+	return a.task
+}
+
+// HasDefinitionKey asserts the task definition key, i.e. the activity id of the user task.
+func (a *TaskAssert) HasDefinitionKey(key string) *TaskAssert {
+	if h, ok := a.parent.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	require.Equalf(a.parent.t, key, a.task.TaskDefinitionKey, "unexpected definition key of task %s", a.task.Id)
+
+	return a
+}
+
+// HasName asserts the name of the task.
+func (a *TaskAssert) HasName(name string) *TaskAssert {
+	if h, ok := a.parent.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	require.Equalf(a.parent.t, name, a.task.Name, "unexpected name of task %s", a.task.Id)
+
+	return a
+}
+
+// IsAssignedTo asserts the assignee of the task.
+func (a *TaskAssert) IsAssignedTo(userId string) *TaskAssert {
+	if h, ok := a.parent.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	require.Equalf(a.parent.t, userId, a.task.Assignee, "unexpected assignee of task %s", a.task.Id)
+
+	return a
+}
+
+// IsNotAssigned asserts that the task has no assignee.
+func (a *TaskAssert) IsNotAssigned() *TaskAssert {
+	if h, ok := a.parent.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	require.Emptyf(a.parent.t, a.task.Assignee, "expected task %s not to be assigned", a.task.Id)
+
+	return a
+}
+
+// HasCandidateGroups asserts that all of the given groups are candidates of the task.
+func (a *TaskAssert) HasCandidateGroups(groupIds ...string) *TaskAssert {
+	if h, ok := a.parent.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	groups, _ := a.candidates()
+
+	for _, v := range groupIds {
+		require.Containsf(a.parent.t, groups, v, "expected group %s to be candidate of task %s, candidates are %v", v, a.task.Id, groups)
+	}
+
+	return a
+}
+
+// HasCandidateUsers asserts that all of the given users are candidates of the task.
+func (a *TaskAssert) HasCandidateUsers(userIds ...string) *TaskAssert {
+	if h, ok := a.parent.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	_, users := a.candidates()
+
+	for _, v := range userIds {
+		require.Containsf(a.parent.t, users, v, "expected user %s to be candidate of task %s, candidates are %v", v, a.task.Id, users)
+	}
+
+	return a
+}
+
+// Complete completes the task with the given variables and returns to the assertions on the process
+// instance.
+func (a *TaskAssert) Complete(variables map[string]*camunda.Variable) *ProcessInstanceAssert {
+	if h, ok := a.parent.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	require.NoError(a.parent.t, a.parent.engine.CompleteTask(a.parent.ctx, a.task.Id, variables))
+
+	return a.parent
+}
+
+func (a *TaskAssert) candidates() ([]string, []string) {
+	if h, ok := a.parent.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	links, err := a.parent.engine.GetTaskIdentityLinks(a.parent.ctx, a.task.Id, camunda.IdentityLinkCandidate)
+
+	require.NoError(a.parent.t, err)
+
+	groups := make([]string, 0)
+	users := make([]string, 0)
+
+	for _, v := range links {
+		if v.GroupId != "" {
+			groups = append(groups, v.GroupId)
+		}
+
+		if v.UserId != "" {
+			users = append(users, v.UserId)
+		}
+	}
+
+	return groups, users
+}
+
+// ExternalTaskAssert asserts the state of an open external task.
+type ExternalTaskAssert struct {
+	parent *ProcessInstanceAssert
+	task   *camunda.ExternalTask
+}
+
+// ExternalTask returns the asserted external task.
+func (a *ExternalTaskAssert) ExternalTask() *camunda.ExternalTask {
+	return a.task
+}
+
+// HasTopic asserts the topic of the external task.
+func (a *ExternalTaskAssert) HasTopic(topic string) *ExternalTaskAssert {
+	if h, ok := a.parent.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	require.Equalf(a.parent.t, topic, a.task.TopicName, "unexpected topic of external task %s", a.task.Id)
+
+	return a
+}
+
+// HasActivityId asserts the id of the activity the external task belongs to.
+func (a *ExternalTaskAssert) HasActivityId(activityId string) *ExternalTaskAssert {
+	if h, ok := a.parent.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	require.Equalf(a.parent.t, activityId, a.task.ActivityId, "unexpected activity of external task %s", a.task.Id)
+
+	return a
+}
+
+// Complete completes the external task with the given variables and returns to the assertions on
+// the process instance.
+func (a *ExternalTaskAssert) Complete(variables map[string]*camunda.Variable) *ProcessInstanceAssert {
+	if h, ok := a.parent.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	require.NoError(a.parent.t, a.parent.engine.CompleteExternalTask(a.parent.ctx, a.task.Id, variables))
+
+	return a.parent
+}
+
+// tHelper is implemented by testing.T, assertions mark themselves as helpers if supported.
+type tHelper interface {
+	Helper()
+}
+
+func keys(variables map[string]*camunda.Variable) []string {
+	result := make([]string, 0, len(variables))
+
+	for k := range variables {
+		result = append(result, k)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
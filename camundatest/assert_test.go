@@ -0,0 +1,138 @@
+package camundatest
+
+import (
+	"context"
+	"testing"
+
+	camunda "github.com/equipmegmbh/camunda-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssertionsAgainstServer(t *testing.T) {
+	ctx := context.Background()
+
+	s := NewServer()
+	defer s.Close()
+
+	s.Configure()
+
+	instance := deploy(t, s)
+	task := s.AddTask(instance.Id, "approve", "Approve invoice")
+
+	require.NoError(t, camunda.AddTaskIdentityLink(ctx, task.Id, &camunda.IdentityLink{GroupId: "accounting", Type: camunda.IdentityLinkCandidate}))
+	require.NoError(t, camunda.AddTaskIdentityLink(ctx, task.Id, &camunda.IdentityLink{UserId: "demo", Type: camunda.IdentityLinkCandidate}))
+	require.NoError(t, camunda.AddTaskIdentityLink(ctx, task.Id, &camunda.IdentityLink{UserId: "mary", Type: camunda.IdentityLinkCandidate}))
+	require.NoError(t, camunda.DeleteTaskIdentityLink(ctx, task.Id, &camunda.IdentityLink{UserId: "mary", Type: camunda.IdentityLinkCandidate}))
+	require.NoError(t, camunda.ClaimTask(ctx, task.Id, "demo"))
+
+	AssertThat(t, instance).
+		IsActive().
+		IsWaitingAt("approve").
+		HasNotPassed("approve").
+		HasVariableValue("amount", 120).
+		Task().
+		HasDefinitionKey("approve").
+		HasName("Approve invoice").
+		IsAssignedTo("demo").
+		HasCandidateGroups("accounting").
+		HasCandidateUsers("demo").
+		Complete(map[string]*camunda.Variable{"approved": {Type: "Boolean", Value: true}}).
+		IsNotWaitingAt("approve").
+		HasPassed("approve").
+		HasVariables("amount", "approved")
+
+	s.AddExternalTask(instance.Id, "archive", "archive-invoice")
+
+	AssertThat(t, instance).
+		IsWaitingAt("archive").
+		ExternalTask().
+		HasTopic("archive-invoice").
+		HasActivityId("archive").
+		Complete(nil).
+		HasPassed("approve", "archive")
+
+	s.EndProcessInstance(instance.Id)
+
+	AssertThat(t, instance).IsEnded()
+}
+
+func TestServerIdentityLinks(t *testing.T) {
+	ctx := context.Background()
+
+	s := NewServer()
+	defer s.Close()
+
+	s.Configure()
+
+	instance := deploy(t, s)
+	task := s.AddTask(instance.Id, "approve", "Approve invoice")
+
+	require.NoError(t, camunda.AddTaskIdentityLink(ctx, task.Id, &camunda.IdentityLink{GroupId: "accounting", Type: camunda.IdentityLinkCandidate}))
+	require.NoError(t, camunda.SetTaskAssignee(ctx, task.Id, "demo"))
+
+	links, err := camunda.GetTaskIdentityLinks(ctx, task.Id, "")
+
+	require.NoError(t, err)
+	require.Equal(t, []*camunda.IdentityLink{
+		{UserId: "demo", Type: camunda.IdentityLinkAssignee},
+		{GroupId: "accounting", Type: camunda.IdentityLinkCandidate},
+	}, links)
+
+	links, err = camunda.GetTaskIdentityLinks(ctx, task.Id, camunda.IdentityLinkCandidate)
+
+	require.NoError(t, err)
+	require.Equal(t, []*camunda.IdentityLink{{GroupId: "accounting", Type: camunda.IdentityLinkCandidate}}, links)
+
+	err = camunda.AddTaskIdentityLink(ctx, task.Id, &camunda.IdentityLink{Type: camunda.IdentityLinkCandidate})
+
+	require.Error(t, err)
+
+	_, err = camunda.GetTaskIdentityLinks(ctx, "unknown", "")
+
+	require.Error(t, err)
+}
+
+func TestServerHistoricActivityInstances(t *testing.T) {
+	ctx := context.Background()
+
+	s := NewServer()
+	defer s.Close()
+
+	s.Configure()
+
+	instance := deploy(t, s)
+	first := s.AddTask(instance.Id, "review", "Review invoice")
+
+	s.AddTask(instance.Id, "approve", "Approve invoice")
+
+	require.NoError(t, camunda.CompleteTask(ctx, first.Id, nil))
+
+	activities, err := camunda.GetHistoricActivityInstances(ctx, &camunda.HistoricActivityInstanceQuery{ProcessInstanceId: instance.Id}, 0, 0)
+
+	require.NoError(t, err)
+	require.Len(t, activities, 2)
+	require.Equal(t, "review", activities[0].ActivityId)
+	require.Equal(t, "userTask", activities[0].ActivityType)
+	require.Equal(t, first.Id, activities[0].TaskId)
+	require.NotEmpty(t, activities[0].EndTime)
+	require.Empty(t, activities[1].EndTime)
+
+	activities, err = camunda.GetHistoricActivityInstances(ctx, &camunda.HistoricActivityInstanceQuery{ProcessInstanceId: instance.Id}, 1, 1)
+
+	require.NoError(t, err)
+	require.Len(t, activities, 1)
+	require.Equal(t, "approve", activities[0].ActivityId)
+
+	count, err := camunda.GetHistoricActivityInstancesCount(ctx, &camunda.HistoricActivityInstanceQuery{Unfinished: true})
+
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	require.NoError(t, camunda.DeleteProcessInstance(ctx, instance.Id))
+
+	activities, err = camunda.GetHistoricActivityInstances(ctx, &camunda.HistoricActivityInstanceQuery{Canceled: true}, 0, 0)
+
+	require.NoError(t, err)
+	require.Len(t, activities, 1)
+	require.Equal(t, "approve", activities[0].ActivityId)
+}
@@ -0,0 +1,573 @@
+package camundatest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Redacted replaces redacted values in recorded interactions.
+const Redacted = "[redacted]"
+
+// Match selects the parts of a request that are compared when an interaction is replayed. Json
+// bodies are compared by their decoded values, multipart bodies by their parts.
+type Match int
+
+const (
+	MatchMethod Match = 1 << iota
+	MatchPath
+	MatchQuery
+	MatchBody
+
+	// MatchAll compares method, path, query and body.
+	MatchAll = MatchMethod | MatchPath | MatchQuery | MatchBody
+)
+
+// Interaction is a request and the response it received. A cassette holds one interaction per line,
+// encoded as json.
+type Interaction struct {
+	// The recorded request.
+	Request *RecordedRequest `json:"request"`
+
+	// The recorded response.
+	Response *RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request of an interaction.
+type RecordedRequest struct {
+	// The http method of the request.
+	Method string `json:"method"`
+
+	// The path of the request url.
+	Path string `json:"path"`
+
+	// The encoded query of the request url.
+	Query string `json:"query,omitempty"`
+
+	// The headers of the request.
+	Header http.Header `json:"header,omitempty"`
+
+	// The body of the request.
+	Body string `json:"body,omitempty"`
+
+	// The encoding of the body, base64 for binary content and empty otherwise.
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// RecordedResponse is a response of an interaction.
+type RecordedResponse struct {
+	// The http status code of the response.
+	Status int `json:"status"`
+
+	// The headers of the response.
+	Header http.Header `json:"header,omitempty"`
+
+	// The body of the response.
+	Body string `json:"body,omitempty"`
+
+	// The encoding of the body, base64 for binary content and empty otherwise.
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// Redaction configures which values are removed from interactions before they are recorded.
+type Redaction struct {
+	// The headers whose values are redacted, e.g. Authorization.
+	Headers []string
+
+	// Redact the values of process variables in json bodies. A variable is any json object with
+	// a value and a type property.
+	Variables bool
+}
+
+// DefaultRedaction redacts the Authorization header.
+var DefaultRedaction = &Redaction{Headers: []string{"Authorization"}}
+
+// Recorder is a http.RoundTripper that sends requests with another transport and writes every
+// interaction to a cassette.
+type Recorder struct {
+	mu        sync.Mutex
+	w         io.Writer
+	transport http.RoundTripper
+	redaction *Redaction
+}
+
+// NewRecorder creates a recorder that writes interactions to w. Requests are sent with the given
+// transport, or the default transport of the http package when transport is nil. When redaction
+// is nil, DefaultRedaction is used.
+func NewRecorder(w io.Writer, transport http.RoundTripper, redaction *Redaction) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	if redaction == nil {
+		redaction = DefaultRedaction
+	}
+
+	return &Recorder{w: w, transport: transport, redaction: redaction}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var request []byte
+	var response []byte
+	var err error
+
+	if request, err = drain(&req.Body); err != nil {
+		return nil, err
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if response, err = drain(&resp.Body); err != nil {
+		return nil, err
+	}
+
+	interaction := &Interaction{
+		Request: &RecordedRequest{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Query:  req.URL.RawQuery,
+			Header: r.redaction.header(req.Header),
+		},
+		Response: &RecordedResponse{
+			Status: resp.StatusCode,
+			Header: r.redaction.header(resp.Header),
+		},
+	}
+
+	interaction.Request.Body, interaction.Request.Encoding = r.redaction.body(request)
+	interaction.Response.Body, interaction.Response.Encoding = r.redaction.body(response)
+
+	payload, err := json.Marshal(interaction)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err = r.w.Write(append(payload, '\n')); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ReplayOptions configures how a Replayer matches requests.
+type ReplayOptions struct {
+	// The parts of a request that must be equal to the recorded request. Defaults to MatchAll.
+	Match Match
+
+	// The redaction that was applied while recording. It is applied to incoming requests before
+	// their bodies are compared. Defaults to DefaultRedaction.
+	Redaction *Redaction
+
+	// Fail requests that match no interaction with an error. Otherwise unmatched requests are
+	// sent with the fallback transport.
+	Strict bool
+
+	// The transport unmatched requests are sent with when not strict. Defaults to the default
+	// transport of the http package.
+	Fallback http.RoundTripper
+}
+
+// Replayer is a http.RoundTripper that serves responses from a cassette. Every interaction is
+// served once, in the order it was recorded. When all matching interactions were served, the last
+// of them is served again.
+type Replayer struct {
+	mu           sync.Mutex
+	options      ReplayOptions
+	interactions []*Interaction
+	used         []bool
+}
+
+// NewReplayer creates a replayer that serves the interactions of the cassette read from r.
+func NewReplayer(r io.Reader, options *ReplayOptions) (*Replayer, error) {
+	rp := new(Replayer)
+
+	if options != nil {
+		rp.options = *options
+	}
+
+	if rp.options.Match == 0 {
+		rp.options.Match = MatchAll
+	}
+
+	if rp.options.Redaction == nil {
+		rp.options.Redaction = DefaultRedaction
+	}
+
+	if rp.options.Fallback == nil {
+		rp.options.Fallback = http.DefaultTransport
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		interaction := new(Interaction)
+
+		if err := json.Unmarshal(scanner.Bytes(), interaction); err != nil {
+			return nil, fmt.Errorf("cassette line %d: %w", line, err)
+		}
+
+		if interaction.Request == nil || interaction.Response == nil {
+			return nil, fmt.Errorf("cassette line %d: incomplete interaction", line)
+		}
+
+		rp.interactions = append(rp.interactions, interaction)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	rp.used = make([]bool, len(rp.interactions))
+
+	return rp, nil
+}
+
+// Unused returns the interactions that were not served yet.
+func (r *Replayer) Unused() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]*Interaction, 0)
+
+	for i, v := range r.interactions {
+		if !r.used[i] {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	content, err := drain(&req.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, _ := r.options.Redaction.body(content)
+
+	r.mu.Lock()
+
+	last := -1
+	next := -1
+
+	for i, v := range r.interactions {
+		if !r.matches(v.Request, req, content, body) {
+			continue
+		}
+
+		last = i
+
+		if !r.used[i] {
+			next = i
+			break
+		}
+	}
+
+	if next < 0 {
+		next = last
+	}
+
+	if next >= 0 {
+		r.used[next] = true
+	}
+
+	r.mu.Unlock()
+
+	if next >= 0 {
+		return respond(req, r.interactions[next].Response)
+	}
+
+	if r.options.Strict {
+		return nil, fmt.Errorf("no recorded interaction matches %s %s", req.Method, req.URL)
+	}
+
+	return r.options.Fallback.RoundTrip(req)
+}
+
+func (r *Replayer) matches(recorded *RecordedRequest, req *http.Request, content []byte, body string) bool {
+	if r.options.Match&MatchMethod != 0 && recorded.Method != req.Method {
+		return false
+	}
+
+	if r.options.Match&MatchPath != 0 && recorded.Path != req.URL.Path {
+		return false
+	}
+
+	if r.options.Match&MatchQuery != 0 && !reflect.DeepEqual(parseQuery(recorded.Query), req.URL.Query()) {
+		return false
+	}
+
+	if r.options.Match&MatchBody == 0 {
+		return true
+	}
+
+	if isMultipart(recorded.Header.Get("Content-Type")) || isMultipart(req.Header.Get("Content-Type")) {
+		return equalMultipart(recorded, req.Header.Get("Content-Type"), content)
+	}
+
+	return equalBody(recorded.Body, body)
+}
+
+func respond(req *http.Request, recorded *RecordedResponse) (*http.Response, error) {
+	content := []byte(recorded.Body)
+
+	if recorded.Encoding == "base64" {
+		var err error
+
+		if content, err = base64.StdEncoding.DecodeString(recorded.Body); err != nil {
+			return nil, err
+		}
+	}
+
+	header := recorded.Header.Clone()
+
+	if header == nil {
+		header = make(http.Header)
+	}
+
+	resp := &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		StatusCode:    recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(content)),
+		ContentLength: int64(len(content)),
+		Request:       req,
+	}
+
+	return resp, nil
+}
+
+// header returns a copy of h with the configured headers redacted.
+func (r *Redaction) header(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+
+	result := h.Clone()
+
+	for _, v := range r.Headers {
+		if result.Get(v) != "" {
+			result.Set(v, Redacted)
+		}
+	}
+
+	return result
+}
+
+// body returns the content as it is recorded, with variable values redacted if configured. Binary
+// content is base64 encoded.
+func (r *Redaction) body(content []byte) (string, string) {
+	if !utf8.Valid(content) {
+		return base64.StdEncoding.EncodeToString(content), "base64"
+	}
+
+	if !r.Variables {
+		return string(content), ""
+	}
+
+	var data interface{}
+
+	if err := json.Unmarshal(content, &data); err != nil {
+		return string(content), ""
+	}
+
+	redact(data)
+
+	payload, err := json.Marshal(data)
+
+	if err != nil {
+		return string(content), ""
+	}
+
+	return string(payload), ""
+}
+
+// redact replaces the values of all variables in the decoded json value.
+func redact(data interface{}) {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		_, value := v["value"]
+		_, kind := v["type"]
+
+		if value && kind {
+			v["value"] = Redacted
+		}
+
+		for _, child := range v {
+			redact(child)
+		}
+	case []interface{}:
+		for _, child := range v {
+			redact(child)
+		}
+	}
+}
+
+// drain reads the body and replaces it with a reader over the read content.
+func drain(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	content, err := ioutil.ReadAll(*body)
+
+	//goland:noinspection GoUnhandledErrorResult
+	(*body).Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	*body = ioutil.NopCloser(bytes.NewReader(content))
+
+	return content, nil
+}
+
+// parseQuery parses a recorded query, a malformed query matches no request.
+func parseQuery(query string) url.Values {
+	values, err := url.ParseQuery(query)
+
+	if err != nil {
+		return nil
+	}
+
+	return values
+}
+
+func isMultipart(contentType string) bool {
+	kind, _, err := mime.ParseMediaType(contentType)
+
+	return err == nil && strings.HasPrefix(kind, "multipart/")
+}
+
+// equalMultipart compares a recorded multipart body with the body of a request. The boundary is
+// chosen randomly for every request, so the bodies are compared by their parts: the headers of
+// the parts must be equal and their content is compared like a body.
+func equalMultipart(recorded *RecordedRequest, contentType string, content []byte) bool {
+	recordedContent := []byte(recorded.Body)
+
+	if recorded.Encoding == "base64" {
+		var err error
+
+		if recordedContent, err = base64.StdEncoding.DecodeString(recorded.Body); err != nil {
+			return false
+		}
+	}
+
+	x, err := parts(recorded.Header.Get("Content-Type"), recordedContent)
+
+	if err != nil {
+		return false
+	}
+
+	y, err := parts(contentType, content)
+
+	if err != nil || len(x) != len(y) {
+		return false
+	}
+
+	for i := range x {
+		if !reflect.DeepEqual(x[i].header, y[i].header) || !equalBody(x[i].content, y[i].content) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// part is a part of a multipart body.
+type part struct {
+	header  map[string][]string
+	content string
+}
+
+// parts returns the parts of a multipart body ordered by their headers, as the order of form
+// fields is not significant.
+func parts(contentType string, content []byte) ([]*part, error) {
+	kind, params, err := mime.ParseMediaType(contentType)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(kind, "multipart/") || params["boundary"] == "" {
+		return nil, fmt.Errorf("not a multipart body: %s", contentType)
+	}
+
+	reader := multipart.NewReader(bytes.NewReader(content), params["boundary"])
+	result := make([]*part, 0)
+
+	for {
+		p, err := reader.NextPart()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := ioutil.ReadAll(p)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, &part{header: p.Header, content: string(data)})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return fmt.Sprint(result[i].header) < fmt.Sprint(result[j].header)
+	})
+
+	return result, nil
+}
+
+// equalBody compares two bodies, json bodies are compared by their decoded values.
+func equalBody(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	var x, y interface{}
+
+	if json.Unmarshal([]byte(a), &x) != nil || json.Unmarshal([]byte(b), &y) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(x, y)
+}
@@ -0,0 +1,242 @@
+package camundatest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	camunda "github.com/equipmegmbh/camunda-go"
+	"github.com/stretchr/testify/require"
+)
+
+// scenario deploys and starts the invoice process.
+func scenario(t *testing.T) *camunda.ProcessInstance {
+	ctx := context.Background()
+
+	_, err := camunda.CreateDeployment(ctx, "", "invoice", "invoice.bpmn", strings.NewReader(invoice))
+
+	require.NoError(t, err)
+
+	instance, err := camunda.StartProcessDefinitionByKey(ctx, "invoice", &camunda.ProcessDefinitionStart{
+		BusinessKey: "invoice-1",
+		Variables:   map[string]*camunda.Variable{"iban": {Type: "String", Value: "DE02120300000000202051"}},
+	})
+
+	require.NoError(t, err)
+
+	return instance
+}
+
+// record records the scenario against the fake engine, followed by two queries of the tasks of the
+// instance, before and after the task was created.
+func record(t *testing.T, redaction *Redaction) ([]byte, *camunda.ProcessInstance, []*camunda.Task) {
+	var cassette bytes.Buffer
+
+	ctx := context.Background()
+
+	s := NewServer()
+	defer s.Close()
+
+	s.Configure()
+
+	camunda.ConfigureTransport(NewRecorder(&cassette, nil, redaction))
+	defer camunda.ConfigureTransport(nil)
+
+	instance := scenario(t)
+
+	tasks, err := camunda.GetTasks(ctx, instance.Id)
+
+	require.NoError(t, err)
+	require.Empty(t, tasks)
+
+	s.AddTask(instance.Id, "approve", "Approve invoice")
+
+	tasks, err = camunda.GetTasks(ctx, instance.Id)
+
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+
+	return cassette.Bytes(), instance, tasks
+}
+
+func TestCassetteRoundTrip(t *testing.T) {
+	cassette, instance, tasks := record(t, nil)
+
+	replayer, err := NewReplayer(bytes.NewReader(cassette), &ReplayOptions{Strict: true})
+
+	require.NoError(t, err)
+
+	// the replay runs without a server.
+	camunda.Configure("http://localhost:1", Context)
+	camunda.ConfigureTransport(replayer)
+	defer camunda.ConfigureTransport(nil)
+
+	ctx := context.Background()
+
+	// the deployment is a multipart request with a new boundary.
+	require.Equal(t, instance, scenario(t))
+
+	result, err := camunda.GetTasks(ctx, instance.Id)
+
+	require.NoError(t, err)
+	require.Empty(t, result)
+
+	result, err = camunda.GetTasks(ctx, instance.Id)
+
+	require.NoError(t, err)
+	require.Equal(t, tasks, result)
+	require.Empty(t, replayer.Unused())
+}
+
+func TestCassetteRedaction(t *testing.T) {
+	redaction := &Redaction{Headers: []string{"Authorization"}, Variables: true}
+	cassette, _, _ := record(t, redaction)
+
+	require.NotContains(t, string(cassette), "DE02120300000000202051")
+	require.Contains(t, string(cassette), Redacted)
+	require.Contains(t, string(cassette), "invoice-1")
+
+	var interaction Interaction
+
+	line := bytes.SplitN(cassette, []byte("\n"), 3)[1]
+
+	require.NoError(t, json.Unmarshal(line, &interaction))
+	require.Equal(t, http.MethodPost, interaction.Request.Method)
+	require.Equal(t, "/engine-rest/process-definition/key/invoice/start", interaction.Request.Path)
+
+	// requests are redacted before they are compared, so the replay matches other values too.
+	replayer, err := NewReplayer(bytes.NewReader(cassette), &ReplayOptions{Strict: true, Redaction: redaction})
+
+	require.NoError(t, err)
+
+	camunda.Configure("http://localhost:1", Context)
+	camunda.ConfigureTransport(replayer)
+	defer camunda.ConfigureTransport(nil)
+
+	ctx := context.Background()
+
+	_, err = camunda.CreateDeployment(ctx, "", "invoice", "invoice.bpmn", strings.NewReader(invoice))
+
+	require.NoError(t, err)
+
+	instance, err := camunda.StartProcessDefinitionByKey(ctx, "invoice", &camunda.ProcessDefinitionStart{
+		BusinessKey: "invoice-1",
+		Variables:   map[string]*camunda.Variable{"iban": {Type: "String", Value: "GB33BUKB20201555555555"}},
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "process-instance-1", instance.Id)
+}
+
+func TestCassetteRedactsHeaders(t *testing.T) {
+	var cassette bytes.Buffer
+
+	s := NewServer()
+	defer s.Close()
+
+	client := &http.Client{Transport: NewRecorder(&cassette, nil, nil)}
+
+	req, err := http.NewRequest(http.MethodGet, s.URL+"/"+Context+"/tenant", nil)
+
+	require.NoError(t, err)
+
+	req.Header.Set("Authorization", "Bearer secret")
+
+	resp, err := client.Do(req)
+
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	var interaction Interaction
+
+	require.NoError(t, json.Unmarshal(cassette.Bytes(), &interaction))
+	require.Equal(t, Redacted, interaction.Request.Header.Get("Authorization"))
+	require.Equal(t, "Bearer secret", req.Header.Get("Authorization"))
+	require.NotContains(t, cassette.String(), "secret")
+}
+
+func TestCassetteStrict(t *testing.T) {
+	cassette, _, _ := record(t, nil)
+
+	replayer, err := NewReplayer(bytes.NewReader(cassette), &ReplayOptions{Strict: true})
+
+	require.NoError(t, err)
+
+	camunda.Configure("http://localhost:1", Context)
+	camunda.ConfigureTransport(replayer)
+	defer camunda.ConfigureTransport(nil)
+
+	ctx := context.Background()
+
+	// a different deployment does not match the recorded multipart body.
+	_, err = camunda.CreateDeployment(ctx, "", "invoice", "other.bpmn", strings.NewReader(invoice))
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no recorded interaction matches POST")
+
+	_, err = camunda.GetTenants(ctx)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no recorded interaction matches GET")
+
+	// without strict mode, unmatched requests are sent to the fallback.
+	s := NewServer()
+	defer s.Close()
+
+	s.Configure()
+
+	replayer, err = NewReplayer(bytes.NewReader(cassette), &ReplayOptions{})
+
+	require.NoError(t, err)
+
+	camunda.ConfigureTransport(replayer)
+
+	tenants, err := camunda.GetTenants(ctx)
+
+	require.NoError(t, err)
+	require.Empty(t, tenants)
+	require.Len(t, replayer.Unused(), 4)
+}
+
+func TestCassetteRepeatsLastInteraction(t *testing.T) {
+	cassette, instance, tasks := record(t, nil)
+
+	replayer, err := NewReplayer(bytes.NewReader(cassette), &ReplayOptions{Strict: true, Match: MatchMethod | MatchPath})
+
+	require.NoError(t, err)
+
+	camunda.Configure("http://localhost:1", Context)
+	camunda.ConfigureTransport(replayer)
+	defer camunda.ConfigureTransport(nil)
+
+	ctx := context.Background()
+
+	// the first GET /task was recorded before the task existed, the second after.
+	first, err := camunda.GetTasks(ctx, instance.Id)
+
+	require.NoError(t, err)
+	require.Empty(t, first)
+
+	for i := 0; i < 3; i++ {
+		result, err := camunda.GetTasks(ctx, instance.Id)
+
+		require.NoError(t, err)
+		require.Equal(t, tasks, result)
+	}
+
+	require.Len(t, replayer.Unused(), 2)
+}
+
+func TestReplayerRejectsMalformedCassettes(t *testing.T) {
+	_, err := NewReplayer(strings.NewReader("{\"request\":{}}\n"), nil)
+
+	require.EqualError(t, err, "cassette line 1: incomplete interaction")
+
+	_, err = NewReplayer(strings.NewReader("\n{"), nil)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cassette line 2")
+}
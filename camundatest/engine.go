@@ -0,0 +1,83 @@
+package camundatest
+
+import (
+	"context"
+
+	camunda "github.com/equipmegmbh/camunda-go"
+)
+
+// Engine is the part of the engine API the assertions are built on. Client implements it with the
+// functions of the camunda package, the simulated engine of the camundasim package implements it
+// as well.
+type Engine interface {
+	GetProcessInstance(ctx context.Context, id string) (*camunda.ProcessInstance, error)
+	GetHistoricProcessInstance(ctx context.Context, id string) (*camunda.HistoricProcessInstance, error)
+	GetProcessInstanceVariables(ctx context.Context, id string, deserializeValues bool) (map[string]*camunda.Variable, error)
+	GetHistoricActivityInstances(ctx context.Context, query *camunda.HistoricActivityInstanceQuery, firstResult, maxResults int) ([]*camunda.HistoricActivityInstance, error)
+	GetTasks(ctx context.Context, processInstanceId string) ([]*camunda.Task, error)
+	GetTaskIdentityLinks(ctx context.Context, id, kind string) ([]*camunda.IdentityLink, error)
+	CompleteTask(ctx context.Context, id string, variables map[string]*camunda.Variable) error
+	GetExternalTasks(ctx context.Context, query *camunda.ExternalTaskQuery, firstResult, maxResults int) ([]*camunda.ExternalTask, error)
+	CompleteExternalTask(ctx context.Context, id string, variables map[string]*camunda.Variable) error
+}
+
+// Client implements Engine with the functions of the camunda package, i.e. against the engine the
+// camunda package is configured for. External tasks are locked for the worker before they are
+// completed.
+type Client struct {
+	// The id of the worker external tasks are completed by. Defaults to camundatest.
+	WorkerId string
+}
+
+var engine Engine = Client{}
+
+// ConfigureEngine configures the engine the assertions run against. The default engine is Client.
+func ConfigureEngine(e Engine) {
+	engine = e
+}
+
+func (c Client) GetProcessInstance(ctx context.Context, id string) (*camunda.ProcessInstance, error) {
+	return camunda.GetProcessInstance(ctx, id)
+}
+
+func (c Client) GetHistoricProcessInstance(ctx context.Context, id string) (*camunda.HistoricProcessInstance, error) {
+	return camunda.GetHistoricProcessInstance(ctx, id)
+}
+
+func (c Client) GetProcessInstanceVariables(ctx context.Context, id string, deserializeValues bool) (map[string]*camunda.Variable, error) {
+	return camunda.GetProcessInstanceVariables(ctx, id, deserializeValues)
+}
+
+func (c Client) GetHistoricActivityInstances(ctx context.Context, query *camunda.HistoricActivityInstanceQuery, firstResult, maxResults int) ([]*camunda.HistoricActivityInstance, error) {
+	return camunda.GetHistoricActivityInstances(ctx, query, firstResult, maxResults)
+}
+
+func (c Client) GetTasks(ctx context.Context, processInstanceId string) ([]*camunda.Task, error) {
+	return camunda.GetTasks(ctx, processInstanceId)
+}
+
+func (c Client) GetTaskIdentityLinks(ctx context.Context, id, kind string) ([]*camunda.IdentityLink, error) {
+	return camunda.GetTaskIdentityLinks(ctx, id, kind)
+}
+
+func (c Client) CompleteTask(ctx context.Context, id string, variables map[string]*camunda.Variable) error {
+	return camunda.CompleteTask(ctx, id, variables)
+}
+
+func (c Client) GetExternalTasks(ctx context.Context, query *camunda.ExternalTaskQuery, firstResult, maxResults int) ([]*camunda.ExternalTask, error) {
+	return camunda.GetExternalTasks(ctx, query, firstResult, maxResults)
+}
+
+func (c Client) CompleteExternalTask(ctx context.Context, id string, variables map[string]*camunda.Variable) error {
+	workerId := c.WorkerId
+
+	if workerId == "" {
+		workerId = "camundatest"
+	}
+
+	if err := camunda.LockExternalTask(ctx, id, workerId, 60000); err != nil {
+		return err
+	}
+
+	return camunda.CompleteExternalTask(ctx, id, workerId, variables)
+}
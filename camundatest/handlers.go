@@ -0,0 +1,1358 @@
+package camundatest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	camunda "github.com/equipmegmbh/camunda-go"
+)
+
+func (s *Server) register() {
+	s.handle(http.MethodPost, "/deployment/create", s.createDeployment)
+
+	s.handle(http.MethodGet, "/process-definition", s.getProcessDefinitions)
+	s.handle(http.MethodGet, "/process-definition/{}", s.getProcessDefinition)
+	s.handle(http.MethodGet, "/process-definition/{}/xml", s.getProcessDefinitionXML)
+	s.handle(http.MethodPost, "/process-definition/{}/start", s.startProcessDefinition)
+	s.handle(http.MethodPut, "/process-definition/{}/suspended", s.suspendProcessDefinition)
+	s.handle(http.MethodGet, "/process-definition/key/{}", s.getProcessDefinitionByKey)
+	s.handle(http.MethodGet, "/process-definition/key/{}/xml", s.getProcessDefinitionXMLByKey)
+	s.handle(http.MethodPost, "/process-definition/key/{}/start", s.startProcessDefinitionByKey)
+	s.handle(http.MethodGet, "/process-definition/key/{}/tenant-id/{}", s.getProcessDefinitionByKey)
+	s.handle(http.MethodGet, "/process-definition/key/{}/tenant-id/{}/xml", s.getProcessDefinitionXMLByKey)
+	s.handle(http.MethodPost, "/process-definition/key/{}/tenant-id/{}/start", s.startProcessDefinitionByKey)
+
+	s.handle(http.MethodGet, "/process-instance", s.getProcessInstances)
+	s.handle(http.MethodGet, "/process-instance/{}", s.getProcessInstance)
+	s.handle(http.MethodDelete, "/process-instance/{}", s.deleteProcessInstance)
+	s.handle(http.MethodGet, "/process-instance/{}/variables", s.getVariables)
+	s.handle(http.MethodPost, "/process-instance/{}/variables", s.modifyVariables)
+	s.handle(http.MethodGet, "/process-instance/{}/variables/{}", s.getVariable)
+	s.handle(http.MethodPut, "/process-instance/{}/variables/{}", s.setVariable)
+	s.handle(http.MethodDelete, "/process-instance/{}/variables/{}", s.deleteVariable)
+	s.handle(http.MethodGet, "/history/process-instance/{}", s.getHistoricProcessInstance)
+	s.handle(http.MethodPost, "/history/activity-instance", s.getHistoricActivityInstances)
+	s.handle(http.MethodPost, "/history/activity-instance/count", s.getHistoricActivityInstancesCount)
+
+	s.handle(http.MethodGet, "/task", s.getTasks)
+	s.handle(http.MethodGet, "/task/{}", s.getTask)
+	s.handle(http.MethodGet, "/task/{}/variables", s.getTaskVariables)
+	s.handle(http.MethodPost, "/task/{}/claim", s.claimTask)
+	s.handle(http.MethodPost, "/task/{}/unclaim", s.unclaimTask)
+	s.handle(http.MethodPost, "/task/{}/assignee", s.assignTask)
+	s.handle(http.MethodPost, "/task/{}/delegate", s.delegateTask)
+	s.handle(http.MethodPost, "/task/{}/resolve", s.resolveTask)
+	s.handle(http.MethodPost, "/task/{}/complete", s.completeTask)
+	s.handle(http.MethodGet, "/task/{}/identity-links", s.getTaskIdentityLinks)
+	s.handle(http.MethodPost, "/task/{}/identity-links", s.addTaskIdentityLink)
+	s.handle(http.MethodPost, "/task/{}/identity-links/delete", s.deleteTaskIdentityLink)
+	s.handle(http.MethodGet, "/task/{}/comment", s.getTaskComments)
+	s.handle(http.MethodGet, "/task/{}/comment/{}", s.getTaskComment)
+	s.handle(http.MethodPost, "/task/{}/comment/create", s.createTaskComment)
+	s.handle(http.MethodGet, "/history/task", s.getTasksHistory)
+
+	s.handle(http.MethodGet, "/tenant", s.getTenants)
+	s.handle(http.MethodPost, "/tenant/create", s.createTenant)
+	s.handle(http.MethodGet, "/tenant/{}", s.getTenant)
+	s.handle(http.MethodPut, "/tenant/{}", s.updateTenant)
+	s.handle(http.MethodDelete, "/tenant/{}", s.deleteTenant)
+
+	s.handle(http.MethodPost, "/external-task", s.getExternalTasks)
+	s.handle(http.MethodPost, "/external-task/count", s.getExternalTasksCount)
+	s.handle(http.MethodGet, "/external-task/{}", s.getExternalTask)
+	s.handle(http.MethodPut, "/external-task/{}/retries", s.setExternalTaskRetries)
+	s.handle(http.MethodPost, "/external-task/{}/lock", s.lockExternalTask)
+	s.handle(http.MethodPost, "/external-task/{}/complete", s.completeExternalTask)
+
+	s.handle(http.MethodGet, "/history/user-operation", s.getUserOperations)
+}
+
+// AddTask creates a task for a running process instance, as the engine would when the instance
+// arrives at a user task. The fake does not execute process models, tests create tasks explicitly.
+// The activity instance of the task is recorded in the history until the task is completed.
+func (s *Server) AddTask(processInstanceId, taskDefinitionKey, name string) *camunda.Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instance := s.instances[processInstanceId]
+
+	task := &camunda.Task{
+		Id:                s.id("task"),
+		Name:              name,
+		Created:           s.now(),
+		ProcessInstanceId: processInstanceId,
+		TaskDefinitionKey: taskDefinitionKey,
+	}
+
+	if instance != nil {
+		task.ProcessDefinitionId = instance.DefinitionId
+		task.ExecutionId = instance.Id
+		task.TenantId = instance.TenantId
+	}
+
+	s.tasks[task.Id] = task
+
+	s.taskHistory[task.Id] = &camunda.TaskHistory{
+		Id:                  task.Id,
+		ProcessDefinitionId: task.ProcessDefinitionId,
+		ProcessInstanceId:   task.ProcessInstanceId,
+		ExecutionId:         task.ExecutionId,
+		Name:                task.Name,
+		TaskDefinitionKey:   task.TaskDefinitionKey,
+		StartTime:           task.Created,
+		TenantId:            task.TenantId,
+	}
+
+	s.enter(task.ProcessInstanceId, task.TaskDefinitionKey, "userTask", task.Id)
+
+	return task
+}
+
+// AddExternalTask creates an external task for a running process instance, as the engine would
+// when the instance arrives at an external service task. The activity instance of the task is
+// recorded in the history until the task is completed.
+func (s *Server) AddExternalTask(processInstanceId, activityId, topicName string) *camunda.ExternalTask {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	retries := 3
+
+	task := &camunda.ExternalTask{
+		Id:                s.id("external-task"),
+		ActivityId:        activityId,
+		ProcessInstanceId: processInstanceId,
+		TopicName:         topicName,
+		Retries:           &retries,
+	}
+
+	if instance := s.instances[processInstanceId]; instance != nil {
+		task.ProcessDefinitionId = instance.DefinitionId
+		task.ExecutionId = instance.Id
+		task.TenantId = instance.TenantId
+		task.BusinessKey = instance.BusinessKey
+	}
+
+	if h := s.history[processInstanceId]; h != nil {
+		task.ProcessDefinitionKey = h.ProcessDefinitionKey
+	}
+
+	s.externalTasks[task.Id] = task
+
+	s.enter(task.ProcessInstanceId, task.ActivityId, "serviceTask", "")
+
+	return task
+}
+
+// EndProcessInstance completes a running process instance, as the engine would when the instance
+// arrives at an end event. Open tasks of the instance are removed.
+func (s *Server) EndProcessInstance(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.end(id, "COMPLETED", "")
+}
+
+// Variables returns the variables of a process instance.
+func (s *Server) Variables(processInstanceId string) map[string]*camunda.Variable {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make(map[string]*camunda.Variable)
+
+	for k, v := range s.variables[processInstanceId] {
+		result[k] = v
+	}
+
+	return result
+}
+
+func (s *Server) end(id, state, reason string) {
+	instance, ok := s.instances[id]
+
+	if !ok {
+		return
+	}
+
+	now := s.now()
+
+	for k, v := range s.tasks {
+		if v.ProcessInstanceId != id {
+			continue
+		}
+
+		s.taskHistory[k].EndTime = now
+		s.taskHistory[k].DeleteReason = "deleted"
+
+		delete(s.tasks, k)
+	}
+
+	for k, v := range s.externalTasks {
+		if v.ProcessInstanceId == id {
+			delete(s.externalTasks, k)
+		}
+	}
+
+	for _, v := range s.activities {
+		if v.ProcessInstanceId == id && v.EndTime == "" {
+			v.EndTime = now
+			v.Canceled = true
+		}
+	}
+
+	instance.Ended = true
+
+	delete(s.instances, id)
+
+	if h := s.history[id]; h != nil {
+		h.EndTime = now
+		h.State = state
+		h.DeleteReason = reason
+	}
+}
+
+// enter records the start of an activity instance of a process instance.
+func (s *Server) enter(processInstanceId, activityId, activityType, taskId string) {
+	activity := &camunda.HistoricActivityInstance{
+		Id:                    s.id("activity-instance"),
+		ActivityId:            activityId,
+		ActivityType:          activityType,
+		ProcessInstanceId:     processInstanceId,
+		RootProcessInstanceId: processInstanceId,
+		TaskId:                taskId,
+		StartTime:             s.now(),
+	}
+
+	if instance := s.instances[processInstanceId]; instance != nil {
+		activity.ProcessDefinitionId = instance.DefinitionId
+		activity.ExecutionId = instance.Id
+		activity.TenantId = instance.TenantId
+	}
+
+	if h := s.history[processInstanceId]; h != nil {
+		activity.ProcessDefinitionKey = h.ProcessDefinitionKey
+	}
+
+	s.activities = append(s.activities, activity)
+}
+
+// leave records the end of the open activity instance of a task, identified by the task id for
+// user tasks or by the activity id for external tasks.
+func (s *Server) leave(processInstanceId, activityId, taskId string) {
+	for _, v := range s.activities {
+		if v.ProcessInstanceId == processInstanceId && v.ActivityId == activityId && v.TaskId == taskId && v.EndTime == "" {
+			v.EndTime = s.now()
+			return
+		}
+	}
+}
+
+func (s *Server) log(operation string, task *camunda.Task, property, org, value string) {
+	entry := &camunda.UserOperationLog{
+		Id:                  s.id("operation"),
+		Timestamp:           s.now(),
+		OperationId:         s.id("operation-id"),
+		OperationType:       operation,
+		EntityType:          "Task",
+		Category:            "TaskWorker",
+		Property:            property,
+		OrgValue:            org,
+		NewValue:            value,
+		ProcessDefinitionId: task.ProcessDefinitionId,
+		ProcessInstanceId:   task.ProcessInstanceId,
+		ExecutionId:         task.ExecutionId,
+		TaskId:              task.Id,
+	}
+
+	s.operations = append(s.operations, entry)
+}
+
+func (s *Server) createDeployment(w http.ResponseWriter, r *http.Request, _ []string) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		fail(w, http.StatusBadRequest, "InvalidRequestException", err.Error())
+		return
+	}
+
+	deployment := &camunda.Deployment{
+		Id:       s.id("deployment"),
+		Name:     r.FormValue("deployment-source"),
+		TenantId: r.FormValue("tenant-id"),
+	}
+
+	for _, files := range r.MultipartForm.File {
+		for _, header := range files {
+			file, err := header.Open()
+
+			if err != nil {
+				fail(w, http.StatusBadRequest, "InvalidRequestException", err.Error())
+				return
+			}
+
+			content, err := io.ReadAll(file)
+
+			//goland:noinspection GoUnhandledErrorResult
+			file.Close()
+
+			if err != nil {
+				fail(w, http.StatusBadRequest, "InvalidRequestException", err.Error())
+				return
+			}
+
+			if err = s.deploy(deployment, header.Filename, string(content)); err != nil {
+				fail(w, http.StatusBadRequest, "ParseException", err.Error())
+				return
+			}
+		}
+	}
+
+	s.deployments[deployment.Id] = deployment
+
+	reply(w, deployment)
+}
+
+// deploy registers the processes of a BPMN resource as process definitions.
+func (s *Server) deploy(deployment *camunda.Deployment, resource, content string) error {
+	decoder := xml.NewDecoder(strings.NewReader(content))
+
+	for {
+		token, err := decoder.Token()
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		element, ok := token.(xml.StartElement)
+
+		if !ok || element.Name.Local != "process" {
+			continue
+		}
+
+		definition := &camunda.ProcessDefinition{
+			DeploymentId: deployment.Id,
+			Resource:     resource,
+			TenantId:     deployment.TenantId,
+			Version:      1,
+		}
+
+		for _, attr := range element.Attr {
+			switch attr.Name.Local {
+			case "id":
+				definition.Key = attr.Value
+			case "name":
+				definition.Name = attr.Value
+			}
+		}
+
+		if latest := s.latest(definition.Key, deployment.TenantId); latest != nil {
+			definition.Version = latest.Version + 1
+		}
+
+		definition.Id = fmt.Sprintf("%s:%d:%s", definition.Key, definition.Version, deployment.Id)
+
+		s.definitions = append(s.definitions, definition)
+		s.sources[definition.Id] = content
+	}
+}
+
+// latest returns the latest version of the process definition with the given key and tenant.
+func (s *Server) latest(key, tenantId string) *camunda.ProcessDefinition {
+	var result *camunda.ProcessDefinition
+
+	for _, v := range s.definitions {
+		if v.Key != key || v.TenantId != tenantId {
+			continue
+		}
+
+		if result == nil || v.Version > result.Version {
+			result = v
+		}
+	}
+
+	return result
+}
+
+func (s *Server) definition(w http.ResponseWriter, id string) *camunda.ProcessDefinition {
+	for _, v := range s.definitions {
+		if v.Id == id {
+			return v
+		}
+	}
+
+	fail(w, http.StatusNotFound, "RestException", fmt.Sprintf("No matching definition with id %s", id))
+
+	return nil
+}
+
+func (s *Server) definitionByKey(w http.ResponseWriter, params []string) *camunda.ProcessDefinition {
+	tenantId := ""
+
+	if len(params) > 1 {
+		tenantId = params[1]
+	}
+
+	if v := s.latest(params[0], tenantId); v != nil {
+		return v
+	}
+
+	fail(w, http.StatusNotFound, "RestException", fmt.Sprintf("No matching process definition with key: %s and tenant-id: %s", params[0], tenantId))
+
+	return nil
+}
+
+func (s *Server) getProcessDefinitions(w http.ResponseWriter, r *http.Request, _ []string) {
+	tenants := r.URL.Query().Get("tenantIdIn")
+
+	result := make([]*camunda.ProcessDefinition, 0)
+
+	for _, v := range s.definitions {
+		if tenants != "" && !contains(strings.Split(tenants, ","), v.TenantId) {
+			continue
+		}
+
+		result = append(result, v)
+	}
+
+	reply(w, result)
+}
+
+func (s *Server) getProcessDefinition(w http.ResponseWriter, _ *http.Request, params []string) {
+	if v := s.definition(w, params[0]); v != nil {
+		reply(w, v)
+	}
+}
+
+func (s *Server) getProcessDefinitionByKey(w http.ResponseWriter, _ *http.Request, params []string) {
+	if v := s.definitionByKey(w, params); v != nil {
+		reply(w, v)
+	}
+}
+
+func (s *Server) getProcessDefinitionXML(w http.ResponseWriter, _ *http.Request, params []string) {
+	if v := s.definition(w, params[0]); v != nil {
+		reply(w, &camunda.ProcessDefinitionSource{Id: v.Id, Content: s.sources[v.Id]})
+	}
+}
+
+func (s *Server) getProcessDefinitionXMLByKey(w http.ResponseWriter, _ *http.Request, params []string) {
+	if v := s.definitionByKey(w, params); v != nil {
+		reply(w, &camunda.ProcessDefinitionSource{Id: v.Id, Content: s.sources[v.Id]})
+	}
+}
+
+func (s *Server) startProcessDefinition(w http.ResponseWriter, r *http.Request, params []string) {
+	if v := s.definition(w, params[0]); v != nil {
+		s.start(w, r, v)
+	}
+}
+
+func (s *Server) startProcessDefinitionByKey(w http.ResponseWriter, r *http.Request, params []string) {
+	if v := s.definitionByKey(w, params); v != nil {
+		s.start(w, r, v)
+	}
+}
+
+func (s *Server) start(w http.ResponseWriter, r *http.Request, definition *camunda.ProcessDefinition) {
+	data := new(camunda.ProcessDefinitionStart)
+
+	if !decode(w, r, data) {
+		return
+	}
+
+	if definition.Suspended {
+		fail(w, http.StatusInternalServerError, "RestException", fmt.Sprintf("Process definition %s is suspended", definition.Id))
+		return
+	}
+
+	instance := &camunda.ProcessInstance{
+		Id:           s.id("process-instance"),
+		DefinitionId: definition.Id,
+		BusinessKey:  data.BusinessKey,
+		TenantId:     definition.TenantId,
+	}
+
+	instance.Links = []*camunda.Link{{
+		Method: http.MethodGet,
+		Ref:    fmt.Sprintf("%s/%s/process-instance/%s", s.URL, Context, instance.Id),
+		Rel:    "self",
+	}}
+
+	variables := make(map[string]*camunda.Variable)
+
+	for k, v := range data.Variables {
+		variables[k] = v
+	}
+
+	s.instances[instance.Id] = instance
+	s.variables[instance.Id] = variables
+
+	s.history[instance.Id] = &camunda.HistoricProcessInstance{
+		Id:                       instance.Id,
+		RootProcessInstanceId:    instance.Id,
+		ProcessDefinitionName:    definition.Name,
+		ProcessDefinitionKey:     definition.Key,
+		ProcessDefinitionVersion: definition.Version,
+		ProcessDefinitionId:      definition.Id,
+		BusinessKey:              instance.BusinessKey,
+		StartTime:                s.now(),
+		TenantId:                 instance.TenantId,
+		State:                    "ACTIVE",
+	}
+
+	if !data.WithVariablesInReturn {
+		reply(w, instance)
+		return
+	}
+
+	result := *instance
+	result.Variables = variables
+
+	reply(w, &result)
+}
+
+func (s *Server) suspendProcessDefinition(w http.ResponseWriter, r *http.Request, params []string) {
+	data := make(map[string]interface{})
+
+	if !decode(w, r, &data) {
+		return
+	}
+
+	definition := s.definition(w, params[0])
+
+	if definition == nil {
+		return
+	}
+
+	suspended, _ := data["suspended"].(bool)
+	definition.Suspended = suspended
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) instance(w http.ResponseWriter, id string) *camunda.ProcessInstance {
+	if v, ok := s.instances[id]; ok {
+		return v
+	}
+
+	fail(w, http.StatusNotFound, "InvalidRequestException", fmt.Sprintf("Process instance with id %s does not exist", id))
+
+	return nil
+}
+
+func (s *Server) getProcessInstances(w http.ResponseWriter, r *http.Request, _ []string) {
+	tenants := r.URL.Query().Get("tenantIdIn")
+
+	result := make([]*camunda.ProcessInstance, 0)
+
+	for _, v := range s.instances {
+		if tenants != "" && !contains(strings.Split(tenants, ","), v.TenantId) {
+			continue
+		}
+
+		result = append(result, v)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return less(result[i].Id, result[j].Id)
+	})
+
+	reply(w, result)
+}
+
+func (s *Server) getProcessInstance(w http.ResponseWriter, _ *http.Request, params []string) {
+	if v := s.instance(w, params[0]); v != nil {
+		reply(w, v)
+	}
+}
+
+func (s *Server) deleteProcessInstance(w http.ResponseWriter, _ *http.Request, params []string) {
+	if v := s.instance(w, params[0]); v == nil {
+		return
+	}
+
+	s.end(params[0], "EXTERNALLY_TERMINATED", "")
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getHistoricProcessInstance(w http.ResponseWriter, _ *http.Request, params []string) {
+	v, ok := s.history[params[0]]
+
+	if !ok {
+		fail(w, http.StatusNotFound, "InvalidRequestException", fmt.Sprintf("Historic process instance with id %s does not exist", params[0]))
+		return
+	}
+
+	reply(w, v)
+}
+
+func (s *Server) getVariables(w http.ResponseWriter, _ *http.Request, params []string) {
+	if v := s.instance(w, params[0]); v != nil {
+		reply(w, s.variables[v.Id])
+	}
+}
+
+func (s *Server) getVariable(w http.ResponseWriter, _ *http.Request, params []string) {
+	if v := s.instance(w, params[0]); v == nil {
+		return
+	}
+
+	variable, ok := s.variables[params[0]][params[1]]
+
+	if !ok {
+		fail(w, http.StatusNotFound, "InvalidRequestException", fmt.Sprintf("process instance variable with name %s does not exist", params[1]))
+		return
+	}
+
+	reply(w, variable)
+}
+
+func (s *Server) setVariable(w http.ResponseWriter, r *http.Request, params []string) {
+	variable := new(camunda.Variable)
+
+	if !decode(w, r, variable) {
+		return
+	}
+
+	if v := s.instance(w, params[0]); v == nil {
+		return
+	}
+
+	s.variables[params[0]][params[1]] = variable
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteVariable(w http.ResponseWriter, _ *http.Request, params []string) {
+	if v := s.instance(w, params[0]); v == nil {
+		return
+	}
+
+	delete(s.variables[params[0]], params[1])
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) modifyVariables(w http.ResponseWriter, r *http.Request, params []string) {
+	data := new(camunda.VariableModification)
+
+	if !decode(w, r, data) {
+		return
+	}
+
+	if v := s.instance(w, params[0]); v == nil {
+		return
+	}
+
+	for _, name := range data.Deletions {
+		delete(s.variables[params[0]], name)
+	}
+
+	for k, v := range data.Modifications {
+		s.variables[params[0]][k] = v
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) task(w http.ResponseWriter, id string) *camunda.Task {
+	if v, ok := s.tasks[id]; ok {
+		return v
+	}
+
+	fail(w, http.StatusNotFound, "InvalidRequestException", fmt.Sprintf("No matching task with id %s", id))
+
+	return nil
+}
+
+func (s *Server) getTasks(w http.ResponseWriter, r *http.Request, _ []string) {
+	processInstanceId := r.URL.Query().Get("processInstanceId")
+
+	result := make([]*camunda.Task, 0)
+
+	for _, v := range s.tasks {
+		if processInstanceId != "" && v.ProcessInstanceId != processInstanceId {
+			continue
+		}
+
+		result = append(result, v)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return less(result[i].Id, result[j].Id)
+	})
+
+	reply(w, result)
+}
+
+func (s *Server) getTask(w http.ResponseWriter, _ *http.Request, params []string) {
+	if v := s.task(w, params[0]); v != nil {
+		reply(w, v)
+	}
+}
+
+func (s *Server) getTaskVariables(w http.ResponseWriter, _ *http.Request, params []string) {
+	task := s.task(w, params[0])
+
+	if task == nil {
+		return
+	}
+
+	result := s.variables[task.ProcessInstanceId]
+
+	if result == nil {
+		result = make(map[string]*camunda.Variable)
+	}
+
+	reply(w, result)
+}
+
+func (s *Server) claimTask(w http.ResponseWriter, r *http.Request, params []string) {
+	data := make(map[string]string)
+
+	if !decode(w, r, &data) {
+		return
+	}
+
+	task := s.task(w, params[0])
+
+	if task == nil {
+		return
+	}
+
+	if task.Assignee != "" && task.Assignee != data["userId"] {
+		fail(w, http.StatusInternalServerError, "TaskAlreadyClaimedException", fmt.Sprintf("Task '%s' is already claimed by someone else.", task.Id))
+		return
+	}
+
+	s.assign(task, "Claim", data["userId"])
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) unclaimTask(w http.ResponseWriter, _ *http.Request, params []string) {
+	task := s.task(w, params[0])
+
+	if task == nil {
+		return
+	}
+
+	s.assign(task, "Claim", "")
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) assignTask(w http.ResponseWriter, r *http.Request, params []string) {
+	data := make(map[string]string)
+
+	if !decode(w, r, &data) {
+		return
+	}
+
+	task := s.task(w, params[0])
+
+	if task == nil {
+		return
+	}
+
+	s.assign(task, "Assign", data["userId"])
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) assign(task *camunda.Task, operation, userId string) {
+	s.log(operation, task, "assignee", task.Assignee, userId)
+
+	task.Assignee = userId
+	s.taskHistory[task.Id].Assignee = userId
+
+	for _, v := range s.activities {
+		if v.TaskId == task.Id {
+			v.Assignee = userId
+		}
+	}
+}
+
+func (s *Server) delegateTask(w http.ResponseWriter, r *http.Request, params []string) {
+	data := make(map[string]string)
+
+	if !decode(w, r, &data) {
+		return
+	}
+
+	task := s.task(w, params[0])
+
+	if task == nil {
+		return
+	}
+
+	if task.Owner == "" {
+		task.Owner = task.Assignee
+		s.taskHistory[task.Id].Owner = task.Owner
+	}
+
+	task.DelegationState = "PENDING"
+
+	s.assign(task, "Delegate", data["userId"])
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) resolveTask(w http.ResponseWriter, r *http.Request, params []string) {
+	data := make(map[string]map[string]*camunda.Variable)
+
+	if !decode(w, r, &data) {
+		return
+	}
+
+	task := s.task(w, params[0])
+
+	if task == nil {
+		return
+	}
+
+	if task.DelegationState != "PENDING" {
+		fail(w, http.StatusInternalServerError, "RestException", fmt.Sprintf("Task %s is not delegated", task.Id))
+		return
+	}
+
+	s.merge(task.ProcessInstanceId, data["variables"])
+
+	task.DelegationState = "RESOLVED"
+
+	s.assign(task, "Resolve", task.Owner)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) completeTask(w http.ResponseWriter, r *http.Request, params []string) {
+	data := new(completion)
+
+	if !decode(w, r, data) {
+		return
+	}
+
+	task := s.task(w, params[0])
+
+	if task == nil {
+		return
+	}
+
+	s.merge(task.ProcessInstanceId, data.Variables)
+	s.log("Complete", task, "", "", "")
+
+	s.taskHistory[task.Id].EndTime = s.now()
+	s.taskHistory[task.Id].DeleteReason = "completed"
+	s.leave(task.ProcessInstanceId, task.TaskDefinitionKey, task.Id)
+
+	delete(s.tasks, task.Id)
+	delete(s.links, task.Id)
+
+	if data.WithVariablesInReturn {
+		reply(w, s.variables[task.ProcessInstanceId])
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// completion is the payload of a task completion.
+type completion struct {
+	Variables             map[string]*camunda.Variable `json:"variables"`
+	WithVariablesInReturn bool                         `json:"withVariablesInReturn"`
+}
+
+func (s *Server) merge(processInstanceId string, variables map[string]*camunda.Variable) {
+	target, ok := s.variables[processInstanceId]
+
+	if !ok {
+		return
+	}
+
+	for k, v := range variables {
+		target[k] = v
+	}
+}
+
+func (s *Server) getTaskIdentityLinks(w http.ResponseWriter, r *http.Request, params []string) {
+	task := s.task(w, params[0])
+
+	if task == nil {
+		return
+	}
+
+	kind := r.URL.Query().Get("type")
+	links := make([]*camunda.IdentityLink, 0)
+
+	if task.Assignee != "" {
+		links = append(links, &camunda.IdentityLink{UserId: task.Assignee, Type: camunda.IdentityLinkAssignee})
+	}
+
+	if task.Owner != "" {
+		links = append(links, &camunda.IdentityLink{UserId: task.Owner, Type: camunda.IdentityLinkOwner})
+	}
+
+	result := make([]*camunda.IdentityLink, 0)
+
+	for _, v := range append(links, s.links[task.Id]...) {
+		if kind == "" || v.Type == kind {
+			result = append(result, v)
+		}
+	}
+
+	reply(w, result)
+}
+
+func (s *Server) addTaskIdentityLink(w http.ResponseWriter, r *http.Request, params []string) {
+	data := new(camunda.IdentityLink)
+
+	if !decode(w, r, data) {
+		return
+	}
+
+	task := s.task(w, params[0])
+
+	if task == nil {
+		return
+	}
+
+	if (data.UserId == "") == (data.GroupId == "") {
+		fail(w, http.StatusBadRequest, "InvalidRequestException", "Identity link requires either a userId or a groupId")
+		return
+	}
+
+	for _, v := range s.links[task.Id] {
+		if *v == *data {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+
+	s.links[task.Id] = append(s.links[task.Id], data)
+	s.log("AddIdentityLink", task, data.Type, "", data.UserId+data.GroupId)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteTaskIdentityLink(w http.ResponseWriter, r *http.Request, params []string) {
+	data := new(camunda.IdentityLink)
+
+	if !decode(w, r, data) {
+		return
+	}
+
+	task := s.task(w, params[0])
+
+	if task == nil {
+		return
+	}
+
+	links := make([]*camunda.IdentityLink, 0)
+
+	for _, v := range s.links[task.Id] {
+		if *v != *data {
+			links = append(links, v)
+		}
+	}
+
+	s.links[task.Id] = links
+	s.log("DeleteIdentityLink", task, data.Type, data.UserId+data.GroupId, "")
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getTaskComments(w http.ResponseWriter, _ *http.Request, params []string) {
+	result := s.comments[params[0]]
+
+	if result == nil {
+		result = make([]*camunda.Comment, 0)
+	}
+
+	reply(w, result)
+}
+
+func (s *Server) getTaskComment(w http.ResponseWriter, _ *http.Request, params []string) {
+	for _, v := range s.comments[params[0]] {
+		if v.Id == params[1] {
+			reply(w, v)
+			return
+		}
+	}
+
+	fail(w, http.StatusNotFound, "InvalidRequestException", fmt.Sprintf("Task comment with id %s does not exist for task id %s.", params[1], params[0]))
+}
+
+func (s *Server) createTaskComment(w http.ResponseWriter, r *http.Request, params []string) {
+	data := make(map[string]string)
+
+	if !decode(w, r, &data) {
+		return
+	}
+
+	task := s.task(w, params[0])
+
+	if task == nil {
+		return
+	}
+
+	comment := &camunda.Comment{
+		Id:                    s.id("comment"),
+		TaskId:                task.Id,
+		ProcessInstanceId:     task.ProcessInstanceId,
+		RootProcessInstanceId: task.ProcessInstanceId,
+		Time:                  s.now(),
+		Message:               data["message"],
+	}
+
+	comment.Links = []*camunda.Link{{
+		Method: http.MethodGet,
+		Ref:    fmt.Sprintf("%s/%s/task/%s/comment/%s", s.URL, Context, task.Id, comment.Id),
+		Rel:    "self",
+	}}
+
+	s.comments[task.Id] = append(s.comments[task.Id], comment)
+
+	reply(w, comment)
+}
+
+func (s *Server) getTasksHistory(w http.ResponseWriter, r *http.Request, _ []string) {
+	processInstanceId := r.URL.Query().Get("processInstanceId")
+
+	result := make([]*camunda.TaskHistory, 0)
+
+	for _, v := range s.taskHistory {
+		if processInstanceId != "" && v.ProcessInstanceId != processInstanceId {
+			continue
+		}
+
+		result = append(result, v)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return less(result[i].Id, result[j].Id)
+	})
+
+	reply(w, result)
+}
+
+func (s *Server) activitiesOf(w http.ResponseWriter, r *http.Request) ([]*camunda.HistoricActivityInstance, bool) {
+	query := new(camunda.HistoricActivityInstanceQuery)
+
+	if !decode(w, r, query) {
+		return nil, false
+	}
+
+	result := make([]*camunda.HistoricActivityInstance, 0)
+
+	for _, v := range s.activities {
+		switch {
+		case query.ActivityInstanceId != "" && v.Id != query.ActivityInstanceId:
+			continue
+		case query.ProcessInstanceId != "" && v.ProcessInstanceId != query.ProcessInstanceId:
+			continue
+		case query.ProcessDefinitionId != "" && v.ProcessDefinitionId != query.ProcessDefinitionId:
+			continue
+		case query.ExecutionId != "" && v.ExecutionId != query.ExecutionId:
+			continue
+		case query.ActivityId != "" && v.ActivityId != query.ActivityId:
+			continue
+		case query.ActivityType != "" && v.ActivityType != query.ActivityType:
+			continue
+		case query.TaskAssignee != "" && v.Assignee != query.TaskAssignee:
+			continue
+		case query.Finished && v.EndTime == "":
+			continue
+		case query.Unfinished && v.EndTime != "":
+			continue
+		case query.Canceled && !v.Canceled:
+			continue
+		}
+
+		result = append(result, v)
+	}
+
+	return result, true
+}
+
+func (s *Server) getHistoricActivityInstances(w http.ResponseWriter, r *http.Request, _ []string) {
+	result, ok := s.activitiesOf(w, r)
+
+	if !ok {
+		return
+	}
+
+	from, to := page(r, len(result))
+
+	reply(w, result[from:to])
+}
+
+func (s *Server) getHistoricActivityInstancesCount(w http.ResponseWriter, r *http.Request, _ []string) {
+	if result, ok := s.activitiesOf(w, r); ok {
+		reply(w, &camunda.Count{Count: len(result)})
+	}
+}
+
+func (s *Server) getTenants(w http.ResponseWriter, _ *http.Request, _ []string) {
+	result := make([]*camunda.Tenant, 0)
+
+	for _, v := range s.tenants {
+		result = append(result, v)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Id < result[j].Id
+	})
+
+	reply(w, result)
+}
+
+func (s *Server) getTenant(w http.ResponseWriter, _ *http.Request, params []string) {
+	v, ok := s.tenants[params[0]]
+
+	if !ok {
+		fail(w, http.StatusNotFound, "InvalidRequestException", fmt.Sprintf("Tenant with id %s does not exist", params[0]))
+		return
+	}
+
+	reply(w, v)
+}
+
+func (s *Server) createTenant(w http.ResponseWriter, r *http.Request, _ []string) {
+	tenant := new(camunda.Tenant)
+
+	if !decode(w, r, tenant) {
+		return
+	}
+
+	if _, ok := s.tenants[tenant.Id]; ok {
+		fail(w, http.StatusInternalServerError, "ProcessEngineException", fmt.Sprintf("Tenant with id %s already exists", tenant.Id))
+		return
+	}
+
+	s.tenants[tenant.Id] = tenant
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) updateTenant(w http.ResponseWriter, r *http.Request, params []string) {
+	tenant := new(camunda.Tenant)
+
+	if !decode(w, r, tenant) {
+		return
+	}
+
+	if _, ok := s.tenants[params[0]]; !ok {
+		fail(w, http.StatusNotFound, "InvalidRequestException", fmt.Sprintf("Tenant with id %s does not exist", params[0]))
+		return
+	}
+
+	tenant.Id = params[0]
+	s.tenants[params[0]] = tenant
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteTenant(w http.ResponseWriter, _ *http.Request, params []string) {
+	if _, ok := s.tenants[params[0]]; !ok {
+		fail(w, http.StatusNotFound, "InvalidRequestException", fmt.Sprintf("Tenant with id %s does not exist", params[0]))
+		return
+	}
+
+	delete(s.tenants, params[0])
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) externalTasksOf(w http.ResponseWriter, r *http.Request) ([]*camunda.ExternalTask, bool) {
+	query := new(camunda.ExternalTaskQuery)
+
+	if !decode(w, r, query) {
+		return nil, false
+	}
+
+	result := make([]*camunda.ExternalTask, 0)
+
+	for _, v := range s.externalTasks {
+		switch {
+		case query.ExternalTaskId != "" && v.Id != query.ExternalTaskId:
+			continue
+		case len(query.ExternalTaskIdIn) > 0 && !contains(query.ExternalTaskIdIn, v.Id):
+			continue
+		case query.TopicName != "" && v.TopicName != query.TopicName:
+			continue
+		case query.ActivityId != "" && v.ActivityId != query.ActivityId:
+			continue
+		case query.ProcessInstanceId != "" && v.ProcessInstanceId != query.ProcessInstanceId:
+			continue
+		case query.ProcessDefinitionId != "" && v.ProcessDefinitionId != query.ProcessDefinitionId:
+			continue
+		case query.NoRetriesLeft && (v.Retries == nil || *v.Retries > 0):
+			continue
+		case query.WithRetriesLeft && v.Retries != nil && *v.Retries == 0:
+			continue
+		}
+
+		result = append(result, v)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return less(result[i].Id, result[j].Id)
+	})
+
+	return result, true
+}
+
+func (s *Server) getExternalTasks(w http.ResponseWriter, r *http.Request, _ []string) {
+	result, ok := s.externalTasksOf(w, r)
+
+	if !ok {
+		return
+	}
+
+	from, to := page(r, len(result))
+
+	reply(w, result[from:to])
+}
+
+func (s *Server) getExternalTasksCount(w http.ResponseWriter, r *http.Request, _ []string) {
+	if result, ok := s.externalTasksOf(w, r); ok {
+		reply(w, &camunda.Count{Count: len(result)})
+	}
+}
+
+func (s *Server) getExternalTask(w http.ResponseWriter, _ *http.Request, params []string) {
+	v, ok := s.externalTasks[params[0]]
+
+	if !ok {
+		fail(w, http.StatusNotFound, "InvalidRequestException", fmt.Sprintf("External task with id %s does not exist", params[0]))
+		return
+	}
+
+	reply(w, v)
+}
+
+func (s *Server) setExternalTaskRetries(w http.ResponseWriter, r *http.Request, params []string) {
+	data := make(map[string]int)
+
+	if !decode(w, r, &data) {
+		return
+	}
+
+	v, ok := s.externalTasks[params[0]]
+
+	if !ok {
+		fail(w, http.StatusNotFound, "InvalidRequestException", fmt.Sprintf("External task with id %s does not exist", params[0]))
+		return
+	}
+
+	retries := data["retries"]
+	v.Retries = &retries
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) lockExternalTask(w http.ResponseWriter, r *http.Request, params []string) {
+	data := new(lock)
+
+	if !decode(w, r, data) {
+		return
+	}
+
+	v, ok := s.externalTasks[params[0]]
+
+	if !ok {
+		fail(w, http.StatusNotFound, "InvalidRequestException", fmt.Sprintf("External task with id %s does not exist", params[0]))
+		return
+	}
+
+	if v.WorkerId != "" && v.WorkerId != data.WorkerId {
+		fail(w, http.StatusBadRequest, "BadUserRequestException", fmt.Sprintf("External Task %s is already locked by worker %s", v.Id, v.WorkerId))
+		return
+	}
+
+	v.WorkerId = data.WorkerId
+	v.LockExpirationTime = s.clock.Add(time.Duration(data.LockDuration) * time.Millisecond).Format("2006-01-02T15:04:05.000-0700")
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) completeExternalTask(w http.ResponseWriter, r *http.Request, params []string) {
+	data := new(lock)
+
+	if !decode(w, r, data) {
+		return
+	}
+
+	v, ok := s.externalTasks[params[0]]
+
+	if !ok {
+		fail(w, http.StatusNotFound, "InvalidRequestException", fmt.Sprintf("External task with id %s does not exist", params[0]))
+		return
+	}
+
+	if v.WorkerId != data.WorkerId {
+		fail(w, http.StatusBadRequest, "BadUserRequestException", fmt.Sprintf("External Task %s cannot be completed by worker '%s'. It is locked by worker '%s'.", v.Id, data.WorkerId, v.WorkerId))
+		return
+	}
+
+	s.merge(v.ProcessInstanceId, data.Variables)
+	s.leave(v.ProcessInstanceId, v.ActivityId, "")
+
+	delete(s.externalTasks, v.Id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// lock is the payload of the lock and complete requests of external tasks.
+type lock struct {
+	WorkerId     string                       `json:"workerId"`
+	LockDuration int                          `json:"lockDuration"`
+	Variables    map[string]*camunda.Variable `json:"variables"`
+}
+
+func (s *Server) getUserOperations(w http.ResponseWriter, r *http.Request, _ []string) {
+	taskId := r.URL.Query().Get("taskId")
+	processInstanceId := r.URL.Query().Get("processInstanceId")
+
+	result := make([]*camunda.UserOperationLog, 0)
+
+	for _, v := range s.operations {
+		if taskId != "" && v.TaskId != taskId {
+			continue
+		}
+
+		if processInstanceId != "" && v.ProcessInstanceId != processInstanceId {
+			continue
+		}
+
+		result = append(result, v)
+	}
+
+	reply(w, result)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// less orders generated ids of the same kind by their sequence number.
+func less(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+
+	return a < b
+}
@@ -0,0 +1,285 @@
+// Package camundatest provides an in-memory fake of the Camunda REST API for tests. The fake
+// emulates the endpoints called by the camunda package, keeps its state in memory and generates
+// deterministic ids and timestamps, so tests can run offline and produce stable results.
+package camundatest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	camunda "github.com/equipmegmbh/camunda-go"
+)
+
+// Context is the context path the fake engine is served at.
+const Context = "engine-rest"
+
+// Hook is invoked for every request before it is handled. If the hook returns a Failure, the
+// request fails with it instead of being handled. Hooks are invoked without holding the lock of
+// the server, so they may call its methods.
+type Hook func(r *http.Request) *Failure
+
+// Failure describes an error response of the fake engine.
+type Failure struct {
+	// The http status code of the response.
+	Status int
+
+	// The type of the exception, e.g. RestException or InvalidRequestException.
+	Type string
+
+	// The error message.
+	Message string
+}
+
+type route struct {
+	method  string
+	pattern []string
+	handle  func(w http.ResponseWriter, r *http.Request, params []string)
+}
+
+// Server is an in-memory fake of the Camunda REST API.
+type Server struct {
+	// URL is the base url of the server, without the context path.
+	URL string
+
+	mu     sync.Mutex
+	http   *httptest.Server
+	routes []*route
+	hooks  []Hook
+	clock  time.Time
+	seq    map[string]int
+
+	deployments   map[string]*camunda.Deployment
+	definitions   []*camunda.ProcessDefinition
+	sources       map[string]string
+	instances     map[string]*camunda.ProcessInstance
+	history       map[string]*camunda.HistoricProcessInstance
+	variables     map[string]map[string]*camunda.Variable
+	tasks         map[string]*camunda.Task
+	taskHistory   map[string]*camunda.TaskHistory
+	activities    []*camunda.HistoricActivityInstance
+	links         map[string][]*camunda.IdentityLink
+	comments      map[string][]*camunda.Comment
+	tenants       map[string]*camunda.Tenant
+	externalTasks map[string]*camunda.ExternalTask
+	operations    []*camunda.UserOperationLog
+}
+
+// NewServer starts a new fake engine. The server must be closed by calling Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		clock:         time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		seq:           make(map[string]int),
+		deployments:   make(map[string]*camunda.Deployment),
+		definitions:   make([]*camunda.ProcessDefinition, 0),
+		sources:       make(map[string]string),
+		instances:     make(map[string]*camunda.ProcessInstance),
+		history:       make(map[string]*camunda.HistoricProcessInstance),
+		variables:     make(map[string]map[string]*camunda.Variable),
+		tasks:         make(map[string]*camunda.Task),
+		taskHistory:   make(map[string]*camunda.TaskHistory),
+		activities:    make([]*camunda.HistoricActivityInstance, 0),
+		links:         make(map[string][]*camunda.IdentityLink),
+		comments:      make(map[string][]*camunda.Comment),
+		tenants:       make(map[string]*camunda.Tenant),
+		externalTasks: make(map[string]*camunda.ExternalTask),
+		operations:    make([]*camunda.UserOperationLog, 0),
+	}
+
+	s.register()
+
+	s.http = httptest.NewServer(http.HandlerFunc(s.serve))
+	s.URL = s.http.URL
+
+	return s
+}
+
+// Configure configures the camunda package to send its requests to the server.
+func (s *Server) Configure() {
+	camunda.Configure(s.URL, Context)
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.http.Close()
+}
+
+// Hook registers a hook which is invoked for every request. Hooks are invoked in the order
+// they were registered, the first returned Failure is sent to the client.
+func (s *Server) Hook(hook Hook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.hooks = append(s.hooks, hook)
+}
+
+// Fail makes every request with the given method and path fail with the given status and message.
+// The path is relative to the context path, e.g. /task/*/complete, a segment of * matches
+// any value.
+func (s *Server) Fail(method, path string, status int, message string) {
+	pattern := split(path)
+
+	s.Hook(func(r *http.Request) *Failure {
+		if r.Method != method {
+			return nil
+		}
+
+		if _, ok := match(pattern, split(strings.TrimPrefix(r.URL.Path, "/"+Context))); !ok {
+			return nil
+		}
+
+		return &Failure{Status: status, Type: "RestException", Message: message}
+	})
+}
+
+// Reset removes all registered hooks.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.hooks = nil
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	hooks := append([]Hook(nil), s.hooks...)
+	s.mu.Unlock()
+
+	// hooks run without the lock, so they may call the server, e.g. to add a task.
+	for _, hook := range hooks {
+		if f := hook(r); f != nil {
+			fail(w, f.Status, f.Type, f.Message)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !strings.HasPrefix(r.URL.Path, "/"+Context+"/") {
+		fail(w, http.StatusNotFound, "NotFoundException", fmt.Sprintf("unknown path %s", r.URL.Path))
+		return
+	}
+
+	segments := split(strings.TrimPrefix(r.URL.Path, "/"+Context))
+
+	for _, v := range s.routes {
+		if v.method != r.Method {
+			continue
+		}
+
+		if params, ok := match(v.pattern, segments); ok {
+			v.handle(w, r, params)
+			return
+		}
+	}
+
+	fail(w, http.StatusNotFound, "NotFoundException", fmt.Sprintf("unknown endpoint %s %s", r.Method, r.URL.Path))
+}
+
+func (s *Server) handle(method, pattern string, handle func(w http.ResponseWriter, r *http.Request, params []string)) {
+	s.routes = append(s.routes, &route{method: method, pattern: split(pattern), handle: handle})
+}
+
+// id returns the next deterministic id for the given kind of entity, e.g. task-1.
+func (s *Server) id(kind string) string {
+	s.seq[kind]++
+
+	return fmt.Sprintf("%s-%d", kind, s.seq[kind])
+}
+
+// now returns the current time of the fake engine. The clock starts at a fixed date and advances
+// by one second with every call, so timestamps are deterministic and strictly ordered.
+func (s *Server) now() string {
+	s.clock = s.clock.Add(time.Second)
+
+	return s.clock.Format("2006-01-02T15:04:05.000-0700")
+}
+
+func split(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// match matches path segments against a pattern. Pattern segments of {} and * match any value,
+// the values matched by {} are returned as params.
+func match(pattern, segments []string) ([]string, bool) {
+	if len(pattern) != len(segments) {
+		return nil, false
+	}
+
+	params := make([]string, 0)
+
+	for i, v := range pattern {
+		switch v {
+		case "{}":
+			params = append(params, segments[i])
+		case "*":
+			continue
+		default:
+			if v != segments[i] {
+				return nil, false
+			}
+		}
+	}
+
+	return params, true
+}
+
+// page returns the range of the results selected by the firstResult and maxResults parameters of
+// a request. Without maxResults, the page extends to the end.
+func page(r *http.Request, length int) (int, int) {
+	from, _ := strconv.Atoi(r.URL.Query().Get("firstResult"))
+	max, _ := strconv.Atoi(r.URL.Query().Get("maxResults"))
+
+	if from < 0 {
+		from = 0
+	}
+
+	if from > length {
+		from = length
+	}
+
+	to := length
+
+	if max > 0 && from+max < to {
+		to = from + max
+	}
+
+	return from, to
+}
+
+func reply(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func fail(w http.ResponseWriter, status int, kind, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	//goland:noinspection GoUnhandledErrorResult
+	json.NewEncoder(w).Encode(&camunda.Error{Type: kind, Message: message})
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if r.Body == nil {
+		return true
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		fail(w, http.StatusBadRequest, "InvalidRequestException", err.Error())
+		return false
+	}
+
+	return true
+}
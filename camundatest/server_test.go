@@ -0,0 +1,448 @@
+package camundatest
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	camunda "github.com/equipmegmbh/camunda-go"
+	"github.com/stretchr/testify/require"
+)
+
+const invoice = `<?xml version="1.0" encoding="UTF-8"?>
+<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL" id="definitions" targetNamespace="http://bpmn.io/schema/bpmn">
+  <bpmn:process id="invoice" name="Invoice" isExecutable="true" />
+</bpmn:definitions>`
+
+// deploy deploys the invoice process to the server and starts an instance of it.
+func deploy(t *testing.T, s *Server) *camunda.ProcessInstance {
+	ctx := context.Background()
+
+	_, err := camunda.CreateDeployment(ctx, "", "invoice", "invoice.bpmn", strings.NewReader(invoice))
+
+	require.NoError(t, err)
+
+	instance, err := camunda.StartProcessDefinitionByKey(ctx, "invoice", &camunda.ProcessDefinitionStart{
+		Variables: map[string]*camunda.Variable{"amount": {Type: "Integer", Value: 120}},
+	})
+
+	require.NoError(t, err)
+
+	return instance
+}
+
+func TestServerProcessDefinitions(t *testing.T) {
+	ctx := context.Background()
+
+	s := NewServer()
+	defer s.Close()
+
+	s.Configure()
+
+	deployment, err := camunda.CreateDeployment(ctx, "", "invoice", "invoice.bpmn", strings.NewReader(invoice))
+
+	require.NoError(t, err)
+	require.Equal(t, "deployment-1", deployment.Id)
+
+	_, err = camunda.CreateDeployment(ctx, "", "invoice", "invoice.bpmn", strings.NewReader(invoice))
+
+	require.NoError(t, err)
+
+	_, err = camunda.CreateDeployment(ctx, "acme", "invoice", "invoice.bpmn", strings.NewReader(invoice))
+
+	require.NoError(t, err)
+
+	definitions, err := camunda.GetProcessDefinitions(ctx, "")
+
+	require.NoError(t, err)
+	require.Len(t, definitions, 3)
+
+	definitions, err = camunda.GetProcessDefinitions(ctx, "acme")
+
+	require.NoError(t, err)
+	require.Len(t, definitions, 1)
+
+	definition, err := camunda.GetProcessDefinitionByKey(ctx, "invoice")
+
+	require.NoError(t, err)
+	require.Equal(t, "Invoice", definition.Name)
+	require.Equal(t, 2, definition.Version)
+
+	byId, err := camunda.GetProcessDefinition(ctx, definition.Id)
+
+	require.NoError(t, err)
+	require.Equal(t, definition, byId)
+
+	byTenant, err := camunda.GetProcessDefinitionByTenant(ctx, "invoice", "acme")
+
+	require.NoError(t, err)
+	require.Equal(t, "acme", byTenant.TenantId)
+
+	source, err := camunda.GetProcessDefinitionXML(ctx, definition.Id)
+
+	require.NoError(t, err)
+	require.Equal(t, invoice, source.Content)
+
+	source, err = camunda.GetProcessDefinitionXMLByKey(ctx, "invoice")
+
+	require.NoError(t, err)
+	require.Equal(t, definition.Id, source.Id)
+
+	require.NoError(t, camunda.SuspendProcessDefinition(ctx, definition.Id, "", false))
+
+	_, err = camunda.StartProcessDefinition(ctx, definition.Id, nil)
+
+	require.Error(t, err)
+
+	require.NoError(t, camunda.ActivateProcessDefinition(ctx, definition.Id, "", false))
+
+	_, err = camunda.StartProcessDefinition(ctx, definition.Id, nil)
+
+	require.NoError(t, err)
+
+	_, err = camunda.GetProcessDefinitionByKey(ctx, "unknown")
+
+	var e *camunda.Error
+
+	require.ErrorAs(t, err, &e)
+	require.Equal(t, "RestException", e.Type)
+}
+
+func TestServerProcessInstances(t *testing.T) {
+	ctx := context.Background()
+
+	s := NewServer()
+	defer s.Close()
+
+	s.Configure()
+
+	instance := deploy(t, s)
+
+	require.NotEmpty(t, instance.Links)
+
+	result, err := camunda.GetProcessInstance(ctx, instance.Id)
+
+	require.NoError(t, err)
+	require.Equal(t, instance.Id, result.Id)
+
+	instances, err := camunda.GetProcessInstances(ctx, "")
+
+	require.NoError(t, err)
+	require.Len(t, instances, 1)
+
+	require.NoError(t, camunda.SetProcessInstanceVariable(ctx, instance.Id, "approved", &camunda.Variable{Type: "Boolean", Value: true}))
+
+	variable, err := camunda.GetProcessInstanceVariable(ctx, instance.Id, "approved", true)
+
+	require.NoError(t, err)
+	require.Equal(t, true, variable.Value)
+
+	require.NoError(t, camunda.ModifyProcessInstanceVariables(ctx, instance.Id, &camunda.VariableModification{
+		Modifications: map[string]*camunda.Variable{"comment": {Type: "String", Value: "ok"}},
+		Deletions:     []string{"approved"},
+	}))
+
+	require.NoError(t, camunda.DeleteProcessInstanceVariable(ctx, instance.Id, "amount"))
+
+	variables, err := camunda.GetProcessInstanceVariables(ctx, instance.Id, true)
+
+	require.NoError(t, err)
+	require.Len(t, variables, 1)
+	require.Contains(t, variables, "comment")
+	require.Equal(t, variables, s.Variables(instance.Id))
+
+	require.NoError(t, camunda.DeleteProcessInstance(ctx, instance.Id))
+
+	_, err = camunda.GetProcessInstance(ctx, instance.Id)
+
+	require.Error(t, err)
+
+	history, err := camunda.GetHistoricProcessInstance(ctx, instance.Id)
+
+	require.NoError(t, err)
+	require.Equal(t, "EXTERNALLY_TERMINATED", history.State)
+	require.NotEmpty(t, history.EndTime)
+}
+
+func TestServerTasks(t *testing.T) {
+	ctx := context.Background()
+
+	s := NewServer()
+	defer s.Close()
+
+	s.Configure()
+
+	instance := deploy(t, s)
+	task := s.AddTask(instance.Id, "approve", "Approve invoice")
+
+	tasks, err := camunda.GetTasks(ctx, instance.Id)
+
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	require.Equal(t, task.Id, tasks[0].Id)
+
+	variables, err := camunda.GetTaskVariables(ctx, task.Id)
+
+	require.NoError(t, err)
+	require.Contains(t, variables, "amount")
+
+	require.NoError(t, camunda.ClaimTask(ctx, task.Id, "demo"))
+
+	err = camunda.ClaimTask(ctx, task.Id, "mary")
+
+	var e *camunda.Error
+
+	require.ErrorAs(t, err, &e)
+	require.Equal(t, "TaskAlreadyClaimedException", e.Type)
+
+	require.NoError(t, camunda.UnclaimTask(ctx, task.Id))
+	require.NoError(t, camunda.SetTaskAssignee(ctx, task.Id, "demo"))
+	require.NoError(t, camunda.DelegateTask(ctx, task.Id, "mary"))
+
+	result, err := camunda.GetTask(ctx, task.Id)
+
+	require.NoError(t, err)
+	require.Equal(t, "mary", result.Assignee)
+	require.Equal(t, "demo", result.Owner)
+	require.Equal(t, "PENDING", result.DelegationState)
+
+	require.NoError(t, camunda.ResolveTask(ctx, task.Id, map[string]*camunda.Variable{"checked": {Type: "Boolean", Value: true}}))
+
+	result, err = camunda.GetTask(ctx, task.Id)
+
+	require.NoError(t, err)
+	require.Equal(t, "demo", result.Assignee)
+
+	returned, err := camunda.CompleteTaskWithVariables(ctx, task.Id, map[string]*camunda.Variable{"approved": {Type: "Boolean", Value: true}})
+
+	require.NoError(t, err)
+	require.Contains(t, returned, "checked")
+	require.Contains(t, returned, "approved")
+
+	_, err = camunda.GetTask(ctx, task.Id)
+
+	require.Error(t, err)
+
+	history, err := camunda.GetTasksHistory(ctx, instance.Id)
+
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Equal(t, "completed", history[0].DeleteReason)
+
+	operations, err := camunda.GetUserOperations(ctx, task.Id)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, operations)
+	require.Equal(t, "Claim", operations[0].OperationType)
+	require.Equal(t, "Complete", operations[len(operations)-1].OperationType)
+
+	operations, err = camunda.GetUserOperationsByProcessInstance(ctx, instance.Id)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, operations)
+}
+
+func TestServerTaskComments(t *testing.T) {
+	ctx := context.Background()
+
+	s := NewServer()
+	defer s.Close()
+
+	s.Configure()
+
+	instance := deploy(t, s)
+	task := s.AddTask(instance.Id, "approve", "Approve invoice")
+
+	comment, err := camunda.CreateTaskComment(ctx, task.Id, "looks good")
+
+	require.NoError(t, err)
+	require.Equal(t, "looks good", comment.Message)
+
+	result, err := camunda.GetTaskComment(ctx, task.Id, comment.Id)
+
+	require.NoError(t, err)
+	require.Equal(t, comment, result)
+
+	comments, err := camunda.GetTaskComments(ctx, task.Id)
+
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+
+	_, err = camunda.GetTaskComment(ctx, task.Id, "unknown")
+
+	require.Error(t, err)
+}
+
+func TestServerTenants(t *testing.T) {
+	ctx := context.Background()
+
+	s := NewServer()
+	defer s.Close()
+
+	s.Configure()
+
+	require.NoError(t, camunda.CreateTenant(ctx, &camunda.Tenant{Id: "acme", Name: "ACME"}))
+	require.NoError(t, camunda.UpdateTenant(ctx, "acme", &camunda.Tenant{Id: "acme", Name: "ACME Corporation"}))
+
+	tenant, err := camunda.GetTenant(ctx, "acme")
+
+	require.NoError(t, err)
+	require.Equal(t, "ACME Corporation", tenant.Name)
+
+	tenants, err := camunda.GetTenants(ctx)
+
+	require.NoError(t, err)
+	require.Len(t, tenants, 1)
+
+	require.NoError(t, camunda.DeleteTenant(ctx, "acme"))
+
+	_, err = camunda.GetTenant(ctx, "acme")
+
+	require.Error(t, err)
+}
+
+func TestServerExternalTasks(t *testing.T) {
+	ctx := context.Background()
+
+	s := NewServer()
+	defer s.Close()
+
+	s.Configure()
+
+	instance := deploy(t, s)
+	task := s.AddExternalTask(instance.Id, "archive", "archive-invoice")
+
+	s.AddExternalTask(instance.Id, "notify", "notify-customer")
+
+	tasks, err := camunda.GetExternalTasks(ctx, &camunda.ExternalTaskQuery{TopicName: "archive-invoice"}, 0, 0)
+
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	require.Equal(t, task.Id, tasks[0].Id)
+	require.Equal(t, "invoice", tasks[0].ProcessDefinitionKey)
+
+	tasks, err = camunda.GetExternalTasks(ctx, &camunda.ExternalTaskQuery{ProcessInstanceId: instance.Id}, 1, 1)
+
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	require.Equal(t, "notify", tasks[0].ActivityId)
+
+	tasks, err = camunda.GetExternalTasks(ctx, &camunda.ExternalTaskQuery{ProcessInstanceId: instance.Id}, 2, 1)
+
+	require.NoError(t, err)
+	require.Empty(t, tasks)
+
+	count, err := camunda.GetExternalTasksCount(ctx, &camunda.ExternalTaskQuery{ProcessInstanceId: instance.Id})
+
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	require.NoError(t, camunda.SetExternalTaskRetries(ctx, task.Id, 0))
+
+	count, err = camunda.GetExternalTasksCount(ctx, &camunda.ExternalTaskQuery{NoRetriesLeft: true})
+
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	require.NoError(t, camunda.LockExternalTask(ctx, task.Id, "worker", 1000))
+
+	err = camunda.LockExternalTask(ctx, task.Id, "other", 1000)
+
+	var e *camunda.Error
+
+	require.ErrorAs(t, err, &e)
+	require.Equal(t, "BadUserRequestException", e.Type)
+
+	require.Error(t, camunda.CompleteExternalTask(ctx, task.Id, "other", nil))
+	require.NoError(t, camunda.CompleteExternalTask(ctx, task.Id, "worker", map[string]*camunda.Variable{"archived": {Type: "Boolean", Value: true}}))
+
+	_, err = camunda.GetExternalTask(ctx, task.Id)
+
+	require.Error(t, err)
+	require.Contains(t, s.Variables(instance.Id), "archived")
+}
+
+func TestServerFail(t *testing.T) {
+	ctx := context.Background()
+
+	s := NewServer()
+	defer s.Close()
+
+	s.Configure()
+
+	instance := deploy(t, s)
+	task := s.AddTask(instance.Id, "approve", "Approve invoice")
+
+	s.Fail(http.MethodPost, "/task/*/complete", http.StatusInternalServerError, "database unavailable")
+
+	err := camunda.CompleteTask(ctx, task.Id, nil)
+
+	var e *camunda.Error
+
+	require.ErrorAs(t, err, &e)
+	require.Equal(t, "RestException", e.Type)
+	require.Equal(t, "database unavailable", e.Message)
+
+	// other methods and paths are not affected.
+	_, err = camunda.GetTask(ctx, task.Id)
+
+	require.NoError(t, err)
+
+	s.Reset()
+
+	require.NoError(t, camunda.CompleteTask(ctx, task.Id, nil))
+}
+
+func TestServerHook(t *testing.T) {
+	ctx := context.Background()
+
+	s := NewServer()
+	defer s.Close()
+
+	s.Configure()
+
+	instance := deploy(t, s)
+
+	var calls int32
+
+	// the hook calls the server, which must not deadlock.
+	s.Hook(func(r *http.Request) *Failure {
+		if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/task") && atomic.AddInt32(&calls, 1) == 1 {
+			s.AddTask(instance.Id, "approve", "Approve invoice")
+		}
+
+		return nil
+	})
+
+	tasks, err := camunda.GetTasks(ctx, instance.Id)
+
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+
+	s.Hook(func(r *http.Request) *Failure {
+		return &Failure{Status: http.StatusForbidden, Type: "AuthorizationException", Message: "forbidden"}
+	})
+
+	_, err = camunda.GetTasks(ctx, instance.Id)
+
+	var e *camunda.Error
+
+	require.ErrorAs(t, err, &e)
+	require.Equal(t, "AuthorizationException", e.Type)
+}
+
+func TestServerUnknownEndpoint(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	resp, err := http.Get(s.URL + "/" + Context + "/unknown")
+
+	require.NoError(t, err)
+
+	//goland:noinspection GoUnhandledErrorResult
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -6,7 +6,11 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
+	urlpkg "net/url"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/dimchansky/utfbom"
@@ -53,9 +57,110 @@ func (c *Client) send(ctx context.Context, url, method, ct string, payload io.Re
 		goto ok
 	}
 
+	return failure(resp)
+
+ok:
+	//goland:noinspection GoUnhandledErrorResult
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 204 {
+		return nil
+	}
+
+	content, err = ioutil.ReadAll(utfbom.SkipOnly(resp.Body))
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(content, out)
+}
+
+// stream sends a request and returns the body of the response without decoding it. The caller
+// is responsible for closing the returned reader.
+func (c *Client) stream(ctx context.Context, url, method, ct string, payload io.Reader) (io.ReadCloser, error) {
+	c.once.Do(c.init)
+
+	var err error
+
+	var req *http.Request
+	var resp *http.Response
+
+	req, err = http.NewRequestWithContext(ctx, method, url, payload)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "*/*")
+	req.Header.Set("Content-Type", ct)
+
+	resp, err = c.cli.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.Body, nil
+	}
+
+	return nil, failure(resp)
+}
+
+// upload sends a multipart request with the given form fields and a file part with the given name.
+// The content is streamed to the engine without being buffered in memory. The file part is omitted
+// if content is nil.
+func (c *Client) upload(ctx context.Context, url string, fields map[string]string, part, filename string, content io.Reader, out interface{}) error {
+	reader, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+
+	go func() {
+		var fw io.Writer
+		var err error
+
+		for k, v := range fields {
+			if err = form.WriteField(k, v); err != nil {
+				goto done
+			}
+		}
+
+		if content == nil {
+			goto finish
+		}
+
+		if fw, err = form.CreateFormFile(part, filename); err != nil {
+			goto done
+		}
+
+		if _, err = io.Copy(fw, content); err != nil {
+			goto done
+		}
+
+	finish:
+		err = form.Close()
+
+	done:
+		//goland:noinspection GoUnhandledErrorResult
+		writer.CloseWithError(err)
+	}()
+
+	err := c.send(ctx, url, http.MethodPost, form.FormDataContentType(), reader, out)
+
+	//goland:noinspection GoUnhandledErrorResult
+	reader.Close()
+
+	return err
+}
+
+// failure reads the error of an unsuccessful response. The body is closed afterwards.
+func failure(resp *http.Response) error {
 	//goland:noinspection GoUnhandledErrorResult
 	defer resp.Body.Close()
 
+	var content []byte
+	var err error
+
 	content, err = ioutil.ReadAll(utfbom.SkipOnly(resp.Body))
 
 	if err != nil {
@@ -74,20 +179,82 @@ func (c *Client) send(ctx context.Context, url, method, ct string, payload io.Re
 
 unknown:
 	return fmt.Errorf("send failed, status code: %d", resp.StatusCode)
+}
 
-ok:
-	//goland:noinspection GoUnhandledErrorResult
-	defer resp.Body.Close()
+// escape escapes a value so it can be safely placed inside a path segment of an url.
+func escape(value string) string {
+	return urlpkg.PathEscape(value)
+}
 
-	if resp.StatusCode == 204 {
-		return nil
+// separator returns the character to append a parameter to an url with the given query string.
+func separator(params string) string {
+	if params == "" {
+		return "?"
 	}
 
-	content, err = ioutil.ReadAll(utfbom.SkipOnly(resp.Body))
+	return "&"
+}
+
+// encode converts a query model into url query parameters. The json tags of the model are used as
+// parameter names, arrays are joined by commas as expected by the engine. Paging parameters are
+// appended when maxResults is greater than zero.
+func encode(query interface{}, firstResult, maxResults int) (string, error) {
+	var content []byte
+	var err error
+
+	values := make(urlpkg.Values)
+	fields := make(map[string]interface{})
+
+	if query == nil {
+		goto paging
+	}
+
+	content, err = json.Marshal(query)
 
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	return json.Unmarshal(content, out)
+	if err = json.Unmarshal(content, &fields); err != nil {
+		return "", err
+	}
+
+	for k, v := range fields {
+		switch t := v.(type) {
+		case []interface{}:
+			items := make([]string, 0, len(t))
+
+			for _, item := range t {
+				if _, ok := item.(map[string]interface{}); ok {
+					continue
+				}
+
+				items = append(items, fmt.Sprint(item))
+			}
+
+			if len(items) == 0 {
+				continue
+			}
+
+			values.Set(k, strings.Join(items, ","))
+		case map[string]interface{}:
+			continue
+		case float64:
+			values.Set(k, strconv.FormatFloat(t, 'f', -1, 64))
+		default:
+			values.Set(k, fmt.Sprint(t))
+		}
+	}
+
+paging:
+	if maxResults > 0 {
+		values.Set("firstResult", strconv.Itoa(firstResult))
+		values.Set("maxResults", strconv.Itoa(maxResults))
+	}
+
+	if len(values) == 0 {
+		return "", nil
+	}
+
+	return "?" + values.Encode(), nil
 }
@@ -0,0 +1,422 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/equipmegmbh/camunda-go/bpmn"
+)
+
+// variablePrefix is the prefix of the names of the properties declaring process variables.
+const variablePrefix = "variable."
+
+// objectPrefix is the prefix of the Object value type followed by the java type name of the
+// object, e.g. Object:com.example.Customer.
+const objectPrefix = "Object:"
+
+// objectTypeName is the java type name of Object variables declared without a type name, a map
+// holding the properties of a json object.
+const objectTypeName = "java.util.LinkedHashMap"
+
+// types maps the Camunda value types to the Go types of the variables. Objects are kept as json.
+var types = map[string]string{
+	"String":  "string",
+	"Boolean": "bool",
+	"Short":   "int16",
+	"Integer": "int32",
+	"Long":    "int64",
+	"Double":  "float64",
+	"Date":    "string",
+	"Json":    "string",
+	"Object":  "json.RawMessage",
+}
+
+// facade is the data of the package generated for a process.
+type facade struct {
+	Source     string
+	Package    string
+	Key        string
+	Ident      string
+	Activities []*constant
+	Messages   []*constant
+	Topics     []*topic
+	Variables  []*variable
+	Pointers   []*pointer
+}
+
+type constant struct {
+	Ident string
+	Value string
+	Kind  string
+}
+
+type topic struct {
+	constant
+	Activities string
+}
+
+// variable is a field of Vars. Fields of objects are nil when unset, all others are pointers.
+type variable struct {
+	Ident      string
+	Name       string
+	Type       string
+	GoType     string
+	ObjectType string
+}
+
+// pointer is a function returning a pointer to a value of a Go type, to set the fields of Vars.
+type pointer struct {
+	Ident  string
+	GoType string
+}
+
+// Objects returns whether the process declares variables of type Object.
+func (f *facade) Objects() bool {
+	for _, v := range f.Variables {
+		if v.ObjectType != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// generate returns the package name and the formatted source of the package of a process.
+func generate(source string, definitions *bpmn.Definitions, p *bpmn.Process) (string, []byte, error) {
+	f := &facade{Source: source, Package: packageName(p.Id), Key: p.Id, Ident: ident(p.Id)}
+
+	activities := make(map[string]bool)
+
+	for _, v := range p.Elements() {
+		f.Activities = append(f.Activities, &constant{Ident: unique(activities, "Activity"+ident(v.Base().Id)), Value: v.Base().Id, Kind: v.Base().Kind})
+	}
+
+	messages := make(map[string]bool)
+	seen := make(map[string]bool)
+
+	for _, v := range p.Elements() {
+		ref := ""
+
+		switch e := v.(type) {
+		case *bpmn.Task:
+			ref = e.MessageRef
+		case *bpmn.Event:
+			if e.Message != nil {
+				ref = e.Message.MessageRef
+			}
+		}
+
+		if m := definitions.Message(ref); m != nil && m.Name != "" && !seen[m.Name] {
+			seen[m.Name] = true
+			f.Messages = append(f.Messages, &constant{Ident: unique(messages, "Message"+ident(m.Name)), Value: m.Name})
+		}
+	}
+
+	topics := make(map[string]bool)
+
+	for _, name := range p.ExternalTaskTopics() {
+		ids := make([]string, 0)
+
+		for _, v := range p.Elements() {
+			if topic, _ := bpmn.Topic(v); topic == name {
+				ids = append(ids, v.Base().Id)
+			}
+		}
+
+		t := &topic{constant: constant{Ident: unique(topics, ident(name)), Value: name}, Activities: strings.Join(ids, ", ")}
+		f.Topics = append(f.Topics, t)
+	}
+
+	// the fields of Vars must not collide with its methods.
+	variables := map[string]bool{"Variables": true}
+	pointers := make(map[string]bool)
+
+	if p.Extensions != nil && p.Extensions.Properties != nil {
+		for _, v := range p.Extensions.Properties.Properties {
+			if !strings.HasPrefix(v.Name, variablePrefix) {
+				continue
+			}
+
+			name := strings.TrimPrefix(v.Name, variablePrefix)
+			kind, objectType := v.Value, ""
+
+			if strings.HasPrefix(kind, objectPrefix) {
+				kind, objectType = "Object", strings.TrimSpace(strings.TrimPrefix(kind, objectPrefix))
+
+				if objectType == "" {
+					return "", nil, fmt.Errorf("variable %s has no object type name", name)
+				}
+			} else if kind == "Object" {
+				objectType = objectTypeName
+			}
+
+			goType, ok := types[kind]
+
+			if !ok {
+				return "", nil, fmt.Errorf("variable %s has unsupported type %q", name, v.Value)
+			}
+
+			result := &variable{Ident: unique(variables, ident(name)), Name: name, Type: kind, GoType: goType, ObjectType: objectType}
+
+			if objectType == "" {
+				result.GoType = "*" + goType
+
+				if !pointers[goType] {
+					pointers[goType] = true
+					f.Pointers = append(f.Pointers, &pointer{Ident: ident(goType), GoType: goType})
+				}
+			}
+
+			f.Variables = append(f.Variables, result)
+		}
+	}
+
+	sort.Slice(f.Pointers, func(i, j int) bool { return f.Pointers[i].Ident < f.Pointers[j].Ident })
+
+	var buf bytes.Buffer
+
+	if err := facadeTemplate.Execute(&buf, f); err != nil {
+		return "", nil, err
+	}
+
+	result, err := format.Source(buf.Bytes())
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	return f.Package, result, nil
+}
+
+// ident returns an exported Go identifier for an id or a name, e.g. ReviewInvoice for
+// review-invoice.
+func ident(value string) string {
+	var b strings.Builder
+
+	upper := true
+
+	for _, r := range value {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+
+		b.WriteRune(r)
+	}
+
+	result := b.String()
+
+	if result == "" || !unicode.IsLetter([]rune(result)[0]) {
+		result = "X" + result
+	}
+
+	return result
+}
+
+// packageName returns a Go package name for a process id, e.g. invoiceapproval for
+// invoice-approval.
+func packageName(id string) string {
+	var b strings.Builder
+
+	for _, r := range strings.ToLower(id) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+	}
+
+	result := b.String()
+
+	if result == "" || unicode.IsDigit(rune(result[0])) || token.IsKeyword(result) {
+		result = "process" + result
+	}
+
+	return result
+}
+
+// unique returns the identifier, suffixed with a number when it was returned before.
+func unique(used map[string]bool, value string) string {
+	result := value
+
+	for i := 2; used[result]; i++ {
+		result = fmt.Sprintf("%s%d", value, i)
+	}
+
+	used[result] = true
+
+	return result
+}
+
+var facadeTemplate = template.Must(template.New("facade").Parse(`// Code generated by bpmngen from {{.Source}}. DO NOT EDIT.
+
+// Package {{.Package}} is the typed facade of the process {{.Key}}.
+package {{.Package}}
+
+import (
+	"context"
+{{- if .Variables}}
+	"encoding/json"
+	"fmt"
+{{- end}}
+
+	camunda "github.com/equipmegmbh/camunda-go"
+)
+
+// Key is the key of the process definition.
+const Key = {{printf "%q" .Key}}
+{{if .Activities}}
+// The ids of the activities.
+const (
+{{- range .Activities}}
+	{{.Ident}} = {{printf "%q" .Value}} // {{.Kind}}
+{{- end}}
+)
+{{end}}
+{{- if .Messages}}
+// The names of the messages.
+const (
+{{- range .Messages}}
+	{{.Ident}} = {{printf "%q" .Value}}
+{{- end}}
+)
+{{end}}
+{{- if .Topics}}
+// The external task topics.
+const (
+{{- range .Topics}}
+	Topic{{.Ident}} = {{printf "%q" .Value}}
+{{- end}}
+)
+{{end}}
+// Topics returns the external task topics of the process.
+func Topics() []string {
+	return []string{ {{- range $i, $v := .Topics}}{{if $i}}, {{end}}Topic{{$v.Ident}}{{end -}} }
+}
+{{range .Topics}}
+// {{.Ident}}Handler handles the external tasks of the topic {{.Value}}, used by {{.Activities}}.
+type {{.Ident}}Handler interface {
+	Handle{{.Ident}}(ctx context.Context, vars *Vars) (map[string]*camunda.Variable, error)
+}
+{{end}}
+// Vars are the variables of the process. Unset variables are nil and are not sent to the engine.
+type Vars struct {
+{{- range .Variables}}
+	// The variable {{.Name}} of type {{.Type}}{{if .ObjectType}}, a {{.ObjectType}} serialized as json{{end}}.
+	{{.Ident}} {{.GoType}}
+{{end -}}
+}
+{{range .Pointers}}
+// {{.Ident}} returns a pointer to the value, to set a variable of Vars.
+func {{.Ident}}(value {{.GoType}}) *{{.GoType}} {
+	return &value
+}
+{{end}}
+// Variables returns the set variables in the form of the engine API.
+func (v *Vars) Variables() map[string]*camunda.Variable {
+	result := make(map[string]*camunda.Variable)
+{{range .Variables}}
+	if v.{{.Ident}} != nil {
+{{- if .ObjectType}}
+		info := &camunda.ObjectValueInfo{ObjectTypeName: {{printf "%q" .ObjectType}}, SerializationDataFormat: "application/json"}
+		result[{{printf "%q" .Name}}] = &camunda.Variable{Type: {{printf "%q" .Type}}, Value: string(v.{{.Ident}}), ValueInfo: info}
+{{- else}}
+		result[{{printf "%q" .Name}}] = &camunda.Variable{Type: {{printf "%q" .Type}}, Value: *v.{{.Ident}}}
+{{- end}}
+	}
+{{end}}
+	return result
+}
+
+// ParseVars reads the variables from the form of the engine API. Missing variables are nil.
+func ParseVars(variables map[string]*camunda.Variable) (*Vars, error) {
+	result := &Vars{}
+{{range .Variables}}
+{{- if .ObjectType}}
+	if err := convertObject(variables, {{printf "%q" .Name}}, &result.{{.Ident}}); err != nil {
+		return nil, err
+	}
+{{- else}}
+	if err := convert(variables, {{printf "%q" .Name}}, &result.{{.Ident}}); err != nil {
+		return nil, err
+	}
+{{- end}}
+{{end}}
+	return result, nil
+}
+
+// Start{{.Ident}} starts the latest version of the process with the given variables.
+func Start{{.Ident}}(ctx context.Context, vars *Vars) (*camunda.ProcessInstance, error) {
+	if vars == nil {
+		vars = &Vars{}
+	}
+
+	data := &camunda.ProcessDefinitionStart{Variables: vars.Variables()}
+
+	return camunda.StartProcessDefinitionByKey(ctx, Key, data)
+}
+{{- if .Variables}}
+
+// convert reads the value of a variable into a field of Vars.
+func convert(variables map[string]*camunda.Variable, name string, target interface{}) error {
+	v, ok := variables[name]
+
+	if !ok || v == nil || v.Value == nil {
+		return nil
+	}
+
+	content, err := json.Marshal(v.Value)
+
+	if err != nil {
+		return fmt.Errorf("variable %s: %w", name, err)
+	}
+
+	if err = json.Unmarshal(content, target); err != nil {
+		return fmt.Errorf("variable %s: %w", name, err)
+	}
+
+	return nil
+}
+{{- end}}
+{{- if .Objects}}
+
+// convertObject reads the json of an object variable into a field of Vars. The engine returns
+// the serialized json, or the deserialized value when the values are deserialized.
+func convertObject(variables map[string]*camunda.Variable, name string, target *json.RawMessage) error {
+	v, ok := variables[name]
+
+	if !ok || v == nil || v.Value == nil {
+		return nil
+	}
+
+	if s, ok := v.Value.(string); ok {
+		if !json.Valid([]byte(s)) {
+			return fmt.Errorf("variable %s: invalid json", name)
+		}
+
+		*target = json.RawMessage(s)
+
+		return nil
+	}
+
+	content, err := json.Marshal(v.Value)
+
+	if err != nil {
+		return fmt.Errorf("variable %s: %w", name, err)
+	}
+
+	*target = content
+
+	return nil
+}
+{{- end}}
+`))
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestGenerate(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("the go command is needed to compile the generated packages")
+	}
+
+	tests := []struct {
+		name   string
+		pkg    string
+		golden string
+	}{
+		{"invoice.bpmn", "invoiceapproval", "invoice.golden"},
+		{"reserved.bpmn", "processtype", "reserved.golden"},
+	}
+
+	// the packages are written inside the module to compile them against it, the underscore keeps
+	// them out of ./... patterns.
+	out, err := os.MkdirTemp(".", "_generated")
+
+	require.NoError(t, err)
+
+	defer os.RemoveAll(out)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.NoError(t, run(filepath.Join("testdata", test.name), out))
+
+			source, err := ioutil.ReadFile(filepath.Join(out, test.pkg, test.pkg+".go"))
+
+			require.NoError(t, err)
+
+			golden := filepath.Join("testdata", test.golden)
+
+			if *update {
+				require.NoError(t, ioutil.WriteFile(golden, source, 0644))
+			}
+
+			expected, err := ioutil.ReadFile(golden)
+
+			require.NoError(t, err)
+			require.Equal(t, string(expected), string(source))
+
+			output, err := exec.Command("go", "vet", "./"+filepath.Join(out, test.pkg)).CombinedOutput()
+
+			require.NoError(t, err, string(output))
+		})
+	}
+}
+
+func TestGenerateRejectsUnsupportedTypes(t *testing.T) {
+	for value, message := range map[string]string{
+		"Float":   `process invoice: variable amount has unsupported type "Float"`,
+		"Object:": "process invoice: variable amount has no object type name",
+	} {
+		content := strings.Replace(`<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL" xmlns:camunda="http://camunda.org/schema/1.0/bpmn" id="definitions" targetNamespace="http://bpmn.io/schema/bpmn">
+  <bpmn:process id="invoice" isExecutable="true">
+    <bpmn:extensionElements>
+      <camunda:properties>
+        <camunda:property name="variable.amount" value="TYPE" />
+      </camunda:properties>
+    </bpmn:extensionElements>
+  </bpmn:process>
+</bpmn:definitions>`, "TYPE", value, 1)
+
+		filename := filepath.Join(t.TempDir(), "invoice.bpmn")
+
+		require.NoError(t, ioutil.WriteFile(filename, []byte(content), 0644))
+		require.EqualError(t, run(filename, t.TempDir()), message)
+	}
+}
+
+func TestIdent(t *testing.T) {
+	require.Equal(t, "ReviewInvoice", ident("review-invoice"))
+	require.Equal(t, "X2ndReview", ident("2nd review"))
+	require.Equal(t, "invoiceapproval", packageName("Invoice-Approval"))
+	require.Equal(t, "processtype", packageName("type"))
+}
@@ -0,0 +1,102 @@
+// Command bpmngen generates a typed Go package for each executable process of BPMN files. The
+// package of a process contains
+//
+//   - Start<Process>, starting the latest version of the process with typed variables,
+//   - constants for the process key, the activity ids, the message names and the external task topics,
+//   - the Vars struct of the process variables, with conversions from and to engine variables,
+//   - a handler interface for each external task topic.
+//
+// The process variables are declared with camunda:property elements on the process, named
+// variable.<name> with the Camunda value type as value, e.g.
+//
+//	<camunda:property name="variable.amount" value="Double" />
+//
+// The supported value types are String, Boolean, Short, Integer, Long, Double, Date, Json and
+// Object. Dates are kept in the engine's string format. The fields of Vars are pointers, so only
+// the variables which are set are sent to the engine; the generated package has functions like
+// Float64 returning a pointer to a value. Objects are kept as json.RawMessage and sent serialized
+// as json, with the java type name following the type, e.g.
+//
+//	<camunda:property name="variable.customer" value="Object:com.example.Customer" />
+//
+// Objects declared without a type name are sent as java.util.LinkedHashMap.
+//
+// bpmngen is meant to be run with go generate:
+//
+//	//go:generate go run github.com/equipmegmbh/camunda-go/cmd/bpmngen -out ./processes invoice.bpmn
+//
+// The package of each process is written to a directory named like the package below the output
+// directory.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/equipmegmbh/camunda-go/bpmn"
+)
+
+func main() {
+	out := flag.String("out", ".", "the directory the packages are written to")
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: bpmngen [-out dir] file.bpmn...\n")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	for _, v := range flag.Args() {
+		if err := run(v, *out); err != nil {
+			fmt.Fprintf(os.Stderr, "bpmngen: %s: %v\n", v, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// run generates the packages of the executable processes of a BPMN file.
+func run(filename, out string) error {
+	content, err := ioutil.ReadFile(filename)
+
+	if err != nil {
+		return err
+	}
+
+	definitions, err := bpmn.Parse(string(content))
+
+	if err != nil {
+		return err
+	}
+
+	for _, v := range definitions.Processes {
+		if !v.Executable() {
+			continue
+		}
+
+		name, source, err := generate(filepath.Base(filename), definitions, v)
+
+		if err != nil {
+			return fmt.Errorf("process %s: %w", v.Id, err)
+		}
+
+		dir := filepath.Join(out, name)
+
+		if err = os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+
+		if err = ioutil.WriteFile(filepath.Join(dir, name+".go"), source, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
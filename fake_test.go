@@ -0,0 +1,113 @@
+package camunda_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	camunda "github.com/equipmegmbh/camunda-go"
+	"github.com/equipmegmbh/camunda-go/camundatest"
+	"github.com/stretchr/testify/require"
+)
+
+// The tests of this file run against the fake engine of the camundatest package, which imports the
+// camunda package and can therefore only be used from an external test package.
+
+// fake starts a fake engine, configures the client for it and records the requests whose path
+// contains the given fragment, as method, path and body.
+func fake(t *testing.T, fragment string) (*camundatest.Server, func() []string) {
+	var mu sync.Mutex
+
+	requests := make([]string, 0)
+
+	s := camundatest.NewServer()
+
+	t.Cleanup(s.Close)
+
+	s.Configure()
+
+	s.Hook(func(r *http.Request) *camundatest.Failure {
+		if !strings.Contains(r.URL.Path, fragment) || r.Method == http.MethodGet {
+			return nil
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		requests = append(requests, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/"+camundatest.Context)+" "+string(body))
+
+		return nil
+	})
+
+	return s, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		result := requests
+		requests = make([]string, 0)
+
+		return result
+	}
+}
+
+func TestSyncTaskCandidates(t *testing.T) {
+	ctx := context.Background()
+
+	s, requests := fake(t, "/identity-links")
+	task := s.AddTask("process-instance-1", "review", "Review invoice")
+
+	candidates := func() []*camunda.IdentityLink {
+		links, err := camunda.GetTaskIdentityLinks(ctx, task.Id, camunda.IdentityLinkCandidate)
+
+		require.NoError(t, err)
+
+		return links
+	}
+
+	// add only, users before groups, each sorted.
+	require.NoError(t, camunda.SyncTaskCandidates(ctx, task.Id, []string{"mary", "demo"}, []string{"sales", "accounting"}))
+	require.Equal(t, []string{
+		`POST /task/task-1/identity-links {"userId":"demo","type":"candidate"}`,
+		`POST /task/task-1/identity-links {"userId":"mary","type":"candidate"}`,
+		`POST /task/task-1/identity-links {"groupId":"accounting","type":"candidate"}`,
+		`POST /task/task-1/identity-links {"groupId":"sales","type":"candidate"}`,
+	}, requests())
+	require.Len(t, candidates(), 4)
+
+	// no changes, no calls.
+	require.NoError(t, camunda.SyncTaskCandidates(ctx, task.Id, []string{"demo", "mary", "demo"}, []string{"accounting", "sales"}))
+	require.Empty(t, requests())
+
+	// delete only.
+	require.NoError(t, camunda.SyncTaskCandidates(ctx, task.Id, []string{"mary"}, []string{"sales"}))
+	require.Equal(t, []string{
+		`POST /task/task-1/identity-links/delete {"userId":"demo","type":"candidate"}`,
+		`POST /task/task-1/identity-links/delete {"groupId":"accounting","type":"candidate"}`,
+	}, requests())
+	require.Equal(t, []*camunda.IdentityLink{
+		{UserId: "mary", Type: camunda.IdentityLinkCandidate},
+		{GroupId: "sales", Type: camunda.IdentityLinkCandidate},
+	}, candidates())
+
+	// links are added before others are deleted.
+	require.NoError(t, camunda.SyncTaskCandidates(ctx, task.Id, []string{"demo"}, []string{"sales"}))
+	require.Equal(t, []string{
+		`POST /task/task-1/identity-links {"userId":"demo","type":"candidate"}`,
+		`POST /task/task-1/identity-links/delete {"userId":"mary","type":"candidate"}`,
+	}, requests())
+
+	// a failed delete keeps the added candidates.
+	s.Fail(http.MethodPost, "/task/{}/identity-links/delete", http.StatusInternalServerError, "database unavailable")
+
+	err := camunda.SyncTaskCandidates(ctx, task.Id, []string{"mary"}, nil)
+
+	require.EqualError(t, err, "type RestException, message: database unavailable")
+	require.Len(t, candidates(), 3)
+}
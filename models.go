@@ -2,6 +2,7 @@ package camunda
 
 import (
 	"fmt"
+	"time"
 )
 
 //goland:noinspection GoUnusedConst,GoNameStartsWithPackageName
@@ -10,6 +11,171 @@ const (
 	TimeLayout = "2006-01-02T15:04:05.000-07:00"
 )
 
+const (
+	AuthorizationGlobal = 0
+	AuthorizationGrant  = 1
+	AuthorizationRevoke = 2
+)
+
+const (
+	ResourceApplication                    = 0
+	ResourceUser                           = 1
+	ResourceGroup                          = 2
+	ResourceGroupMembership                = 3
+	ResourceAuthorization                  = 4
+	ResourceFilter                         = 5
+	ResourceProcessDefinition              = 6
+	ResourceTask                           = 7
+	ResourceProcessInstance                = 8
+	ResourceDeployment                     = 9
+	ResourceDecisionDefinition             = 10
+	ResourceTenant                         = 11
+	ResourceTenantMembership               = 12
+	ResourceBatch                          = 13
+	ResourceDecisionRequirementsDefinition = 14
+	ResourceReport                         = 15
+	ResourceDashboard                      = 16
+	ResourceUserOperationLogCategory       = 17
+	ResourceOptimize                       = 18
+	ResourceHistoricTask                   = 19
+	ResourceHistoricProcessInstance        = 20
+	ResourceSystem                         = 21
+)
+
+const (
+	IdentityLinkAssignee  = "assignee"
+	IdentityLinkOwner     = "owner"
+	IdentityLinkCandidate = "candidate"
+)
+
+const (
+	ValueTypeBytes = "Bytes"
+	ValueTypeFile  = "File"
+)
+
+const (
+	IncidentFailedJob          = "failedJob"
+	IncidentFailedExternalTask = "failedExternalTask"
+)
+
+const (
+	EventActivityStarted = "activityStarted"
+	EventActivityEnded   = "activityEnded"
+	EventTaskCreated     = "taskCreated"
+	EventTaskEnded       = "taskEnded"
+	EventUserOperation   = "userOperation"
+	EventIncidentCreated = "incidentCreated"
+	EventIncidentEnded   = "incidentEnded"
+)
+
+type Attachment struct {
+	// The id of the task attachment.
+	Id string `json:"id,omitempty"`
+
+	// The name of the task attachment.
+	Name string `json:"name,omitempty"`
+
+	// The description of the task attachment.
+	Description string `json:"description,omitempty"`
+
+	// The id of the task to which the attachment belongs.
+	TaskId string `json:"taskId,omitempty"`
+
+	// Indication of the type of content for this attachment. Can be mime type or any other indication.
+	Type string `json:"type,omitempty"`
+
+	// The url to the remote content of the task attachment.
+	Url string `json:"url,omitempty"`
+
+	// The time the attachment was created. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	CreateTime string `json:"createTime,omitempty"`
+
+	// The time after which the attachment should be removed by the History Cleanup job.
+	// Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	RemovalTime string `json:"removalTime,omitempty"`
+
+	// The process instance id of the root process instance that initiated the process containing the task.
+	RootProcessInstanceId string `json:"rootProcessInstanceId,omitempty"`
+
+	// Link to the newly created task attachment with method, href and rel.
+	Links []*Link `json:"links,omitempty"`
+}
+
+type Authorization struct {
+	// The id of the authorization.
+	Id string `json:"id,omitempty"`
+
+	// The type of the authorization, one of AuthorizationGlobal, AuthorizationGrant or AuthorizationRevoke.
+	Type int `json:"type"`
+
+	// An array of strings holding the permissions provided by this authorization, e.g. READ or ALL.
+	Permissions []string `json:"permissions,omitempty"`
+
+	// The id of the user this authorization has been created for. The value * represents a global
+	// authorization ranging over all users.
+	UserId string `json:"userId,omitempty"`
+
+	// The id of the group this authorization has been created for.
+	GroupId string `json:"groupId,omitempty"`
+
+	// An integer representing the resource type, e.g. ResourceTenant.
+	ResourceType int `json:"resourceType"`
+
+	// The resource id. The value * represents an authorization ranging over all instances of a resource.
+	ResourceId string `json:"resourceId,omitempty"`
+
+	// The removal time indicates the date a historic instance authorization is cleaned up.
+	// Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	RemovalTime string `json:"removalTime,omitempty"`
+
+	// The process instance id of the root process instance the historic instance authorization is related to.
+	RootProcessInstanceId string `json:"rootProcessInstanceId,omitempty"`
+
+	// Link to the newly created authorization with method, href and rel.
+	Links []*Link `json:"links,omitempty"`
+}
+
+type AuthorizationCheck struct {
+	// Name of the permission which was checked.
+	PermissionName string `json:"permissionName,omitempty"`
+
+	// The name of the resource for which the permission check was performed.
+	ResourceName string `json:"resourceName,omitempty"`
+
+	// The id of the resource for which the permission check was performed.
+	ResourceId string `json:"resourceId,omitempty"`
+
+	// True if the user is authorized, false otherwise.
+	Authorized bool `json:"authorized"`
+}
+
+type AuthorizationQuery struct {
+	// Filter by the id of the authorization.
+	Id string `json:"id,omitempty"`
+
+	// Filter by authorization type, one of AuthorizationGlobal, AuthorizationGrant or AuthorizationRevoke.
+	Type *int `json:"type,omitempty"`
+
+	// Filter by a list of user ids.
+	UserIdIn []string `json:"userIdIn,omitempty"`
+
+	// Filter by a list of group ids.
+	GroupIdIn []string `json:"groupIdIn,omitempty"`
+
+	// Filter by an integer representation of the resource type, e.g. ResourceTenant.
+	ResourceType *int `json:"resourceType,omitempty"`
+
+	// Filter by resource id.
+	ResourceId string `json:"resourceId,omitempty"`
+
+	// Sort the results lexicographically by a given criterion. Valid values are resourceType and resourceId.
+	SortBy string `json:"sortBy,omitempty"`
+
+	// Sort the results in a given order. Values may be asc for ascending order
+	// or desc for descending order. Must be used in conjunction with the SortBy parameter.
+	SortOrder string `json:"sortOrder,omitempty"`
+}
+
 type Batch struct {
 	// The id of the batch.
 	Id string `json:"id,omitempty"`
@@ -94,163 +260,1377 @@ type Comment struct {
 	// The id of the process instance the comment is related to.
 	ProcessInstanceId string `json:"processInstanceId,omitempty"`
 
-	// The time when the comment was created.
-	Time string `json:"time,omitempty"`
+	// The time when the comment was created.
+	Time string `json:"time,omitempty"`
+
+	// The content of the comment.
+	Message string `json:"message,omitempty"`
+
+	// Link to the newly created task comment with method, href and rel.
+	Links []*Link `json:"links,omitempty"`
+
+	// The time after which the comment should be removed by the History Cleanup
+	// job. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	RemovalTime string `json:"removalTime,omitempty"`
+
+	// The process instance id of the root process instance that initiated the process containing the task.
+	RootProcessInstanceId string `json:"rootProcessInstanceId,omitempty"`
+}
+
+type Count struct {
+	// The number of matching entities.
+	Count int `json:"count"`
+}
+
+type DateCondition struct {
+	// The comparison operator to be used. Valid operator values are lt - lower than and gt - greater than.
+	Operator string `json:"operator,omitempty"`
+
+	// The date value to compare with. By default, the date must have the format yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	Value string `json:"value,omitempty"`
+}
+
+type Deployment struct {
+	// The id of the deployment.
+	Id string `json:"id,omitempty"`
+
+	// The name of the deployment.
+	Name string `json:"name,omitempty"`
+
+	// The source of the deployment.
+	Source string `json:"source,omitempty"`
+
+	// The tenant id of the deployment.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The time when the deployment was created.
+	DeploymentTime string `json:"deploymentTime,omitempty"`
+
+	// An object containing a property for each of the
+	// process definitions, which are successfully deployed with that deployment.
+	DeployedProcessDefinitions map[string]*ProcessDefinition `json:"deployedProcessDefinitions,omitempty"`
+
+	// An object containing a property for each of the case
+	// definitions, which are successfully deployed with that deployment.
+	DeployedCaseDefinitions map[string]*CaseDefinition `json:"deployedCaseDefinitions,omitempty"`
+
+	// An object containing a property for each of the decision
+	// definitions, which are successfully deployed with that deployment.
+	DeployedDecisionDefinitions map[string]*DecisionDefinition `json:"deployedDecisionDefinitions,omitempty"`
+
+	// An object containing a property for each of the decision
+	// requirements definitions, which are successfully deployed with that deployment.
+	DeployedDecisionRequirementsDefinitions map[string]*DecisionRequirementsDefinition `json:"deployedDecisionRequirementsDefinitions,omitempty"`
+
+	// Link to the newly created deployment with method, href and rel.
+	Links []*Link `json:"links,omitempty"`
+}
+
+type DecisionDefinition struct {
+	// The id of the process definition.
+	Id string `json:"id,omitempty"`
+
+	// The key of the process definition.
+	Key string `json:"key,omitempty"`
+
+	// The category of the process definition.
+	Category string `json:"category,omitempty"`
+
+	// The name of the process definition.
+	Name string `json:"name,omitempty"`
+
+	// The version of the process definition that the engine assigned to it.
+	Version int `json:"version,omitempty"`
+
+	// The file name of the process definition.
+	Resource string `json:"resource,omitempty"`
+
+	// The deployment id of the process definition.
+	DeploymentId string `json:"deploymentId,omitempty"`
+
+	// The id of the decision requirements definition this decision definition belongs to.
+	DecisionRequirementsDefinitionId string `json:"decisionRequirementsDefinitionId,omitempty"`
+
+	// The key of the decision requirements definition this decision definition belongs to.
+	DecisionRequirementsDefinitionKey string `json:"decisionRequirementsDefinitionKey,omitempty"`
+
+	// The tenant id of the process definition.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The version tag of the process definition.
+	VersionTag string `json:"versionTag,omitempty"`
+
+	// History time to live value of the process definition.
+	HistoryTimeToLive int `json:"historyTimeToLive,omitempty"`
+}
+
+type DecisionRequirementsDefinition struct {
+	// The id of the process definition.
+	Id string `json:"id,omitempty"`
+
+	// The key of the process definition.
+	Key string `json:"key,omitempty"`
+
+	// The category of the process definition.
+	Category string `json:"category,omitempty"`
+
+	// The name of the process definition.
+	Name string `json:"name,omitempty"`
+
+	// The version of the process definition that the engine assigned to it.
+	Version int `json:"version,omitempty"`
+
+	// The file name of the process definition.
+	Resource string `json:"resource,omitempty"`
+
+	// The deployment id of the process definition.
+	DeploymentId string `json:"deploymentId,omitempty"`
+
+	// The tenant id of the process definition.
+	TenantId string `json:"tenantId,omitempty"`
+}
+
+type Error struct {
+	// The type of exception.
+	Type string `json:"type,omitempty"`
+
+	// The error message.
+	Message string `json:"message,omitempty"`
+}
+
+type ExternalTask struct {
+	// The id of the external task.
+	Id string `json:"id,omitempty"`
+
+	// The id of the activity that this external task belongs to.
+	ActivityId string `json:"activityId,omitempty"`
+
+	// The id of the activity instance that the external task belongs to.
+	ActivityInstanceId string `json:"activityInstanceId,omitempty"`
+
+	// The full error message submitted with the latest reported failure executing this task;
+	// null if no failure was reported previously or if no error message was submitted.
+	ErrorMessage string `json:"errorMessage,omitempty"`
+
+	// The id of the execution that the external task belongs to.
+	ExecutionId string `json:"executionId,omitempty"`
+
+	// The date that the task's most recent lock expires or has expired.
+	LockExpirationTime string `json:"lockExpirationTime,omitempty"`
+
+	// The id of the process definition the external task is defined in.
+	ProcessDefinitionId string `json:"processDefinitionId,omitempty"`
+
+	// The key of the process definition the external task is defined in.
+	ProcessDefinitionKey string `json:"processDefinitionKey,omitempty"`
+
+	// The version tag of the process definition the external task is defined in.
+	ProcessDefinitionVersionTag string `json:"processDefinitionVersionTag,omitempty"`
+
+	// The id of the process instance the external task belongs to.
+	ProcessInstanceId string `json:"processInstanceId,omitempty"`
+
+	// The id of the tenant the external task belongs to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The number of retries the task currently has left.
+	Retries *int `json:"retries,omitempty"`
+
+	// Whether the process instance the external task belongs to is suspended.
+	Suspended bool `json:"suspended,omitempty"`
+
+	// The id of the worker that possesses or possessed the most recent lock.
+	WorkerId string `json:"workerId,omitempty"`
+
+	// The topic name of the external task.
+	TopicName string `json:"topicName,omitempty"`
+
+	// The priority of the external task.
+	Priority int `json:"priority,omitempty"`
+
+	// The business key of the process instance the external task belongs to.
+	BusinessKey string `json:"businessKey,omitempty"`
+}
+
+type ExternalTaskQuery struct {
+	// Filter by an external task's id.
+	ExternalTaskId string `json:"externalTaskId,omitempty"`
+
+	// Filter by a list of external task ids.
+	ExternalTaskIdIn []string `json:"externalTaskIdIn,omitempty"`
+
+	// Filter by an external task topic.
+	TopicName string `json:"topicName,omitempty"`
+
+	// Filter by the id of the worker that the task was most recently locked by.
+	WorkerId string `json:"workerId,omitempty"`
+
+	// Only include external tasks that are currently locked (i.e., they have a lock time and it has not expired).
+	Locked bool `json:"locked,omitempty"`
+
+	// Only include external tasks that are currently not locked (i.e., they have no lock or it has expired).
+	NotLocked bool `json:"notLocked,omitempty"`
+
+	// Only include external tasks that have a positive (> 0) number of retries (or null).
+	WithRetriesLeft bool `json:"withRetriesLeft,omitempty"`
+
+	// Only include external tasks that have 0 retries.
+	NoRetriesLeft bool `json:"noRetriesLeft,omitempty"`
+
+	// Restrict to external tasks that have a lock that expires after a given date.
+	// By default, the date must have the format yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	LockExpirationAfter string `json:"lockExpirationAfter,omitempty"`
+
+	// Restrict to external tasks that have a lock that expires before a given date.
+	// By default, the date must have the format yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	LockExpirationBefore string `json:"lockExpirationBefore,omitempty"`
+
+	// Filter by the id of the activity that an external task is created for.
+	ActivityId string `json:"activityId,omitempty"`
+
+	// Filter by the comma-separated list of ids of the activities that an external task is created for.
+	ActivityIdIn []string `json:"activityIdIn,omitempty"`
+
+	// Filter by the id of the execution that an external task belongs to.
+	ExecutionId string `json:"executionId,omitempty"`
+
+	// Filter by the id of the process instance that an external task belongs to.
+	ProcessInstanceId string `json:"processInstanceId,omitempty"`
+
+	// Filter by a list of process instance ids that an external task may belong to.
+	ProcessInstanceIdIn []string `json:"processInstanceIdIn,omitempty"`
+
+	// Filter by the id of the process definition that an external task belongs to.
+	ProcessDefinitionId string `json:"processDefinitionId,omitempty"`
+
+	// Only include active tasks.
+	Active bool `json:"active,omitempty"`
+
+	// Only include suspended tasks.
+	Suspended bool `json:"suspended,omitempty"`
+
+	// Only include jobs with a priority higher than or equal to the given value.
+	PriorityHigherThanOrEquals *int `json:"priorityHigherThanOrEquals,omitempty"`
+
+	// Only include jobs with a priority lower than or equal to the given value.
+	PriorityLowerThanOrEquals *int `json:"priorityLowerThanOrEquals,omitempty"`
+
+	// Filter by a list of tenant ids. An external task must have one of the given tenant ids.
+	TenantIdIn []string `json:"tenantIdIn,omitempty"`
+
+	// A JSON array of criteria to sort the result by. Each element of the array is
+	// a JSON object that specifies one ordering. The position in the array
+	// identifies the rank of an ordering, i.e., whether it is primary, secondary, etc.
+	Sorting []*Sort `json:"sorting,omitempty"`
+}
+
+type ExternalTaskRetries struct {
+	// The ids of the external tasks to set the number of retries for.
+	ExternalTaskIds []string `json:"externalTaskIds,omitempty"`
+
+	// The ids of process instances containing the external tasks to set the number of retries for.
+	ProcessInstanceIds []string `json:"processInstanceIds,omitempty"`
+
+	// Query for the external tasks to set the number of retries for. See ExternalTaskQuery.
+	ExternalTaskQuery *ExternalTaskQuery `json:"externalTaskQuery,omitempty"`
+
+	// Query for the process instances containing the external tasks to set the number
+	// of retries for. See ProcessInstanceQuery.
+	ProcessInstanceQuery *ProcessInstanceQuery `json:"processInstanceQuery,omitempty"`
+
+	// Mandatory. The number of retries to set for the external task. Must be >= 0. If this
+	// is 0, an incident is created and the task cannot be fetched anymore unless the retries
+	// are increased again.
+	Retries int `json:"retries"`
+}
+
+type Fetch struct {
+	// Mandatory. The id of the worker on which behalf tasks are fetched. The returned tasks are
+	// locked for that worker and can only be completed when providing the same worker id.
+	WorkerId string `json:"workerId,omitempty"`
+
+	// Mandatory. The maximum number of tasks to return.
+	MaxTasks int `json:"maxTasks,omitempty"`
+
+	// A boolean value, which indicates whether the task should be fetched based on its priority or arbitrarily.
+	UsePriority bool `json:"usePriority,omitempty"`
+
+	// The Long Polling timeout in milliseconds.
+	// Note: The value cannot be set larger than 1.800.000 milliseconds (corresponds to 30 minutes).
+	AsyncResponseTimeout int `json:"asyncResponseTimeout,omitempty"`
+
+	// The topics for which external tasks should be fetched.
+	Topics []*Topic `json:"topics,omitempty"`
+}
+
+type Filter struct {
+	// The id of the filter.
+	Id string `json:"id,omitempty"`
+
+	// The resource type of the filter, e.g. Task.
+	ResourceType string `json:"resourceType,omitempty"`
+
+	// The name of the filter.
+	Name string `json:"name,omitempty"`
+
+	// The user id of the owner of the filter.
+	Owner string `json:"owner,omitempty"`
+
+	// The query of the filter. The query must be a JSON object of a query which corresponds
+	// to the resource type of the filter, e.g. a task query for filters of type Task.
+	Query map[string]interface{} `json:"query,omitempty"`
+
+	// The properties of a filter as a JSON object, e.g. color, priority, description or refresh.
+	Properties map[string]interface{} `json:"properties,omitempty"`
+
+	// The number of items matched by the filter itself. Only set if the item count was requested.
+	ItemCount *int `json:"itemCount,omitempty"`
+}
+
+type FilterQuery struct {
+	// Filter by the id of the filter.
+	FilterId string `json:"filterId,omitempty"`
+
+	// Filter by the resource type of the filter, e.g. Task.
+	ResourceType string `json:"resourceType,omitempty"`
+
+	// Filter by the name of the filter.
+	Name string `json:"name,omitempty"`
+
+	// Filter by the name that the parameter is a substring of.
+	NameLike string `json:"nameLike,omitempty"`
+
+	// Filter by the user id of the owner of the filter.
+	Owner string `json:"owner,omitempty"`
+
+	// Sort the results lexicographically by a given criterion. Valid values are
+	// filterId, resourceType, name and owner.
+	SortBy string `json:"sortBy,omitempty"`
+
+	// Sort the results in a given order. Values may be asc for ascending order
+	// or desc for descending order. Must be used in conjunction with the SortBy parameter.
+	SortOrder string `json:"sortOrder,omitempty"`
+}
+
+type FormKey struct {
+	// The form key for the task.
+	Key string `json:"key,omitempty"`
+
+	// The process application's context path the task belongs to. If the task does not belong to
+	// a process application deployment or a process definition at all, this property is not set.
+	ContextPath string `json:"contextPath,omitempty"`
+}
+
+type Group struct {
+	// The id of the group.
+	Id string `json:"id,omitempty"`
+
+	// The name of the group.
+	Name string `json:"name,omitempty"`
+
+	// The type of the group.
+	Type string `json:"type,omitempty"`
+}
+
+type GroupQuery struct {
+	// Filter by the id of the group.
+	Id string `json:"id,omitempty"`
+
+	// Filter by a list of group ids.
+	IdIn []string `json:"idIn,omitempty"`
+
+	// Filter by the name of the group.
+	Name string `json:"name,omitempty"`
+
+	// Filter by the name that the parameter is a substring of.
+	NameLike string `json:"nameLike,omitempty"`
+
+	// Filter by the type of the group.
+	Type string `json:"type,omitempty"`
+
+	// Only retrieve groups which the given user id is a member of.
+	Member string `json:"member,omitempty"`
+
+	// Only retrieve groups which are members of the given tenant.
+	MemberOfTenant string `json:"memberOfTenant,omitempty"`
+
+	// Sort the results lexicographically by a given criterion. Valid values are id, name and type.
+	SortBy string `json:"sortBy,omitempty"`
+
+	// Sort the results in a given order. Values may be asc for ascending order
+	// or desc for descending order. Must be used in conjunction with the SortBy parameter.
+	SortOrder string `json:"sortOrder,omitempty"`
+}
+
+type HistoricActivityInstance struct {
+	// The id of the activity instance.
+	Id string `json:"id,omitempty"`
+
+	// The id of the parent activity instance, for example a sub process instance.
+	ParentActivityInstanceId string `json:"parentActivityInstanceId,omitempty"`
+
+	// The id of the activity that this object is an instance of.
+	ActivityId string `json:"activityId,omitempty"`
+
+	// The name of the activity that this object is an instance of.
+	ActivityName string `json:"activityName,omitempty"`
+
+	// The type of the activity that this object is an instance of.
+	ActivityType string `json:"activityType,omitempty"`
+
+	// The key of the process definition that this activity instance belongs to.
+	ProcessDefinitionKey string `json:"processDefinitionKey,omitempty"`
+
+	// The id of the process definition that this activity instance belongs to.
+	ProcessDefinitionId string `json:"processDefinitionId,omitempty"`
+
+	// The id of the process instance that this activity instance belongs to.
+	ProcessInstanceId string `json:"processInstanceId,omitempty"`
+
+	// The id of the execution that executed this activity instance.
+	ExecutionId string `json:"executionId,omitempty"`
+
+	// The id of the task that is associated to this activity instance. Is only set
+	// if the activity is a user task.
+	TaskId string `json:"taskId,omitempty"`
+
+	// The assignee of the task that is associated to this activity instance. Is only
+	// set if the activity is a user task.
+	Assignee string `json:"assignee,omitempty"`
+
+	// The id of the called process instance. Is only set if the activity is a call activity
+	// and the called instance a process instance.
+	CalledProcessInstanceId string `json:"calledProcessInstanceId,omitempty"`
+
+	// The id of the called case instance. Is only set if the activity is a call activity
+	// and the called instance a case instance.
+	CalledCaseInstanceId string `json:"calledCaseInstanceId,omitempty"`
+
+	// The time the instance was started. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	StartTime string `json:"startTime,omitempty"`
+
+	// The time the instance ended. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	EndTime string `json:"endTime,omitempty"`
+
+	// The time the instance took to finish (in milliseconds).
+	DurationInMillis int `json:"durationInMillis,omitempty"`
+
+	// If true, this activity instance is canceled.
+	Canceled bool `json:"canceled,omitempty"`
+
+	// If true, this activity instance did complete a BPMN 2.0 scope.
+	CompleteScope bool `json:"completeScope,omitempty"`
+
+	// The tenant id of the activity instance.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The time after which the activity instance should be removed by the History Cleanup job.
+	// Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	RemovalTime string `json:"removalTime,omitempty"`
+
+	// The process instance id of the root process instance that initiated the process
+	// containing this activity instance.
+	RootProcessInstanceId string `json:"rootProcessInstanceId,omitempty"`
+}
+
+type HistoricActivityInstanceQuery struct {
+	// Filter by activity instance id.
+	ActivityInstanceId string `json:"activityInstanceId,omitempty"`
+
+	// Filter by process instance id.
+	ProcessInstanceId string `json:"processInstanceId,omitempty"`
+
+	// Filter by process definition id.
+	ProcessDefinitionId string `json:"processDefinitionId,omitempty"`
+
+	// Filter by the id of the execution that executed the activity instance.
+	ExecutionId string `json:"executionId,omitempty"`
+
+	// Filter by the activity id (according to BPMN 2.0 XML).
+	ActivityId string `json:"activityId,omitempty"`
+
+	// Filter by the activity name (according to BPMN 2.0 XML).
+	ActivityName string `json:"activityName,omitempty"`
+
+	// Filter by activity type.
+	ActivityType string `json:"activityType,omitempty"`
+
+	// Only include activity instances that are user tasks and assigned to a given user.
+	TaskAssignee string `json:"taskAssignee,omitempty"`
+
+	// Only include finished activity instances. Value may only be true, as false behaves
+	// the same as when the property is not set.
+	Finished bool `json:"finished,omitempty"`
+
+	// Only include unfinished activity instances. Value may only be true, as false behaves
+	// the same as when the property is not set.
+	Unfinished bool `json:"unfinished,omitempty"`
+
+	// Only include canceled activity instances. Value may only be true, as false behaves
+	// the same as when the property is not set.
+	Canceled bool `json:"canceled,omitempty"`
+
+	// Only include activity instances which completed a scope. Value may only be true, as
+	// false behaves the same as when the property is not set.
+	CompleteScope bool `json:"completeScope,omitempty"`
+
+	// Restrict to instances that were started before the given date.
+	// By default, the date must have the format yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	StartedBefore string `json:"startedBefore,omitempty"`
+
+	// Restrict to instances that were started after the given date.
+	// By default, the date must have the format yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	StartedAfter string `json:"startedAfter,omitempty"`
+
+	// Restrict to instances that were finished before the given date.
+	// By default, the date must have the format yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	FinishedBefore string `json:"finishedBefore,omitempty"`
+
+	// Restrict to instances that were finished after the given date.
+	// By default, the date must have the format yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	FinishedAfter string `json:"finishedAfter,omitempty"`
+
+	// Filter by a list of tenant ids. An activity instance must have one of the given tenant ids.
+	TenantIdIn []string `json:"tenantIdIn,omitempty"`
+
+	// Only include historic activity instances which belong to no tenant.
+	WithoutTenantId bool `json:"withoutTenantId,omitempty"`
+
+	// A JSON array of criteria to sort the result by. Each element of the array is
+	// a JSON object that specifies one ordering. The position in the array
+	// identifies the rank of an ordering, i.e., whether it is primary, secondary, etc.
+	Sorting []*Sort `json:"sorting,omitempty"`
+}
+
+type HistoricDetail struct {
+	// The id of the historic detail.
+	Id string `json:"id,omitempty"`
+
+	// The type of the historic detail. Either formField for a submitted form field value
+	// or variableUpdate for variable updates.
+	Type string `json:"type,omitempty"`
+
+	// The key of the process definition that this historic detail belongs to.
+	ProcessDefinitionKey string `json:"processDefinitionKey,omitempty"`
+
+	// The id of the process definition that this historic detail belongs to.
+	ProcessDefinitionId string `json:"processDefinitionId,omitempty"`
+
+	// The id of the process instance the historic detail belongs to.
+	ProcessInstanceId string `json:"processInstanceId,omitempty"`
+
+	// The id of the activity instance the historic detail belongs to.
+	ActivityInstanceId string `json:"activityInstanceId,omitempty"`
+
+	// The id of the execution the historic detail belongs to.
+	ExecutionId string `json:"executionId,omitempty"`
+
+	// The key of the case definition that this historic detail belongs to.
+	CaseDefinitionKey string `json:"caseDefinitionKey,omitempty"`
+
+	// The id of the case definition that this historic detail belongs to.
+	CaseDefinitionId string `json:"caseDefinitionId,omitempty"`
+
+	// The id of the case instance the historic detail belongs to.
+	CaseInstanceId string `json:"caseInstanceId,omitempty"`
+
+	// The id of the case execution the historic detail belongs to.
+	CaseExecutionId string `json:"caseExecutionId,omitempty"`
+
+	// The id of the task the historic detail belongs to.
+	TaskId string `json:"taskId,omitempty"`
+
+	// The id of the tenant that this historic detail belongs to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The id of user operation which links historic detail with user operation log entries.
+	UserOperationId string `json:"userOperationId,omitempty"`
+
+	// The time when this historic detail occurred. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	Time string `json:"time,omitempty"`
+
+	// The time after which the historic detail should be removed by the History Cleanup job.
+	// Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	RemovalTime string `json:"removalTime,omitempty"`
+
+	// The process instance id of the root process instance that initiated the process
+	// containing this historic detail.
+	RootProcessInstanceId string `json:"rootProcessInstanceId,omitempty"`
+
+	// The id of the form field. Only set for details of type formField.
+	FieldId string `json:"fieldId,omitempty"`
+
+	// The submitted form field value. Only set for details of type formField.
+	FieldValue interface{} `json:"fieldValue,omitempty"`
+
+	// The name of the variable which has been updated. Only set for details of type variableUpdate.
+	VariableName string `json:"variableName,omitempty"`
+
+	// The id of the associated variable instance. Only set for details of type variableUpdate.
+	VariableInstanceId string `json:"variableInstanceId,omitempty"`
+
+	// The value type of the variable. Only set for details of type variableUpdate.
+	VariableType string `json:"variableType,omitempty"`
+
+	// The variable's value. Only set for details of type variableUpdate.
+	Value interface{} `json:"value,omitempty"`
+
+	// An object containing additional, value-type-dependent properties.
+	ValueInfo interface{} `json:"valueInfo,omitempty"`
+
+	// Returns true for variable updates that contain the initial values of the variables.
+	Initial bool `json:"initial,omitempty"`
+
+	// The revision of the historic variable update.
+	Revision int `json:"revision,omitempty"`
+
+	// An error message in case a Java Serialized Object could not be de-serialized.
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+type HistoricDetailQuery struct {
+	// Filter by process instance id.
+	ProcessInstanceId string `json:"processInstanceId,omitempty"`
+
+	// Only include historic details which belong to one of the passed process instance ids.
+	ProcessInstanceIdIn []string `json:"processInstanceIdIn,omitempty"`
+
+	// Filter by execution id.
+	ExecutionId string `json:"executionId,omitempty"`
+
+	// Filter by task id.
+	TaskId string `json:"taskId,omitempty"`
+
+	// Filter by activity instance id.
+	ActivityInstanceId string `json:"activityInstanceId,omitempty"`
+
+	// Filter by case instance id.
+	CaseInstanceId string `json:"caseInstanceId,omitempty"`
+
+	// Filter by case execution id.
+	CaseExecutionId string `json:"caseExecutionId,omitempty"`
+
+	// Filter by variable instance id.
+	VariableInstanceId string `json:"variableInstanceId,omitempty"`
+
+	// Only include historic details where the variable updates belong to one of the passed
+	// list of variable types.
+	VariableTypeIn []string `json:"variableTypeIn,omitempty"`
+
+	// Filter by a list of tenant ids.
+	TenantIdIn []string `json:"tenantIdIn,omitempty"`
+
+	// Only include historic details that belong to no tenant.
+	WithoutTenantId bool `json:"withoutTenantId,omitempty"`
+
+	// Filter by a user operation id.
+	UserOperationId string `json:"userOperationId,omitempty"`
+
+	// Only include form fields.
+	FormFields bool `json:"formFields,omitempty"`
+
+	// Only include variable updates.
+	VariableUpdates bool `json:"variableUpdates,omitempty"`
+
+	// Excludes all task-related historic details, so only items which have no task id set will be selected.
+	ExcludeTaskDetails bool `json:"excludeTaskDetails,omitempty"`
+
+	// Restrict to historic variable updates that contain only initial variable values.
+	InitialValue bool `json:"initialValue,omitempty"`
+
+	// Restrict to historic details that occurred before the given date (including the date).
+	// By default, the date must have the format yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	OccurredBefore string `json:"occurredBefore,omitempty"`
+
+	// Restrict to historic details that occurred after the given date (including the date).
+	// By default, the date must have the format yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	OccurredAfter string `json:"occurredAfter,omitempty"`
+
+	// A JSON array of criteria to sort the result by. Each element of the array is
+	// a JSON object that specifies one ordering. The position in the array
+	// identifies the rank of an ordering, i.e., whether it is primary, secondary, etc.
+	Sorting []*Sort `json:"sorting,omitempty"`
+}
+
+type HistoricIncident struct {
+	// The id of the incident.
+	Id string `json:"id,omitempty"`
+
+	// The key of the process definition this incident is associated with.
+	ProcessDefinitionKey string `json:"processDefinitionKey,omitempty"`
+
+	// The id of the process definition this incident is associated with.
+	ProcessDefinitionId string `json:"processDefinitionId,omitempty"`
+
+	// The key of the process instance this incident is associated with.
+	ProcessInstanceId string `json:"processInstanceId,omitempty"`
+
+	// The id of the execution this incident is associated with.
+	ExecutionId string `json:"executionId,omitempty"`
+
+	// The time this incident happened. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	CreateTime string `json:"createTime,omitempty"`
+
+	// The time this incident has been deleted or resolved. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	EndTime string `json:"endTime,omitempty"`
+
+	// The time after which the incident should be removed by the History Cleanup job.
+	// Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	RemovalTime string `json:"removalTime,omitempty"`
+
+	// The type of incident, for example: failedJobs will be returned in case of an incident
+	// which identified a failed job during the execution of a process instance.
+	IncidentType string `json:"incidentType,omitempty"`
+
+	// The id of the activity this incident is associated with.
+	ActivityId string `json:"activityId,omitempty"`
+
+	// The id of the activity on which the last exception occurred.
+	FailedActivityId string `json:"failedActivityId,omitempty"`
+
+	// The id of the associated cause incident which has been triggered.
+	CauseIncidentId string `json:"causeIncidentId,omitempty"`
+
+	// The id of the associated root cause incident which has been triggered.
+	RootCauseIncidentId string `json:"rootCauseIncidentId,omitempty"`
+
+	// The payload of this incident.
+	Configuration string `json:"configuration,omitempty"`
+
+	// The payload of this incident at the time when it occurred.
+	HistoryConfiguration string `json:"historyConfiguration,omitempty"`
+
+	// The message of this incident.
+	IncidentMessage string `json:"incidentMessage,omitempty"`
+
+	// The tenant id of the incident.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The job definition id the incident is associated with.
+	JobDefinitionId string `json:"jobDefinitionId,omitempty"`
+
+	// If true, this incident is open.
+	Open bool `json:"open,omitempty"`
+
+	// If true, this incident has been deleted.
+	Deleted bool `json:"deleted,omitempty"`
+
+	// If true, this incident has been resolved.
+	Resolved bool `json:"resolved,omitempty"`
+
+	// The annotation of this incident.
+	Annotation string `json:"annotation,omitempty"`
+
+	// The process instance id of the root process instance that initiated the process
+	// containing this incident.
+	RootProcessInstanceId string `json:"rootProcessInstanceId,omitempty"`
+}
+
+type HistoricIncidentQuery struct {
+	// Restricts to incidents that have the given id.
+	IncidentId string `json:"incidentId,omitempty"`
+
+	// Restricts to incidents that belong to the given incident type.
+	IncidentType string `json:"incidentType,omitempty"`
+
+	// Restricts to incidents that have the given incident message.
+	IncidentMessage string `json:"incidentMessage,omitempty"`
+
+	// Restricts to incidents that belong to a process definition with the given id.
+	ProcessDefinitionId string `json:"processDefinitionId,omitempty"`
+
+	// Restricts to incidents that belong to a process definition with the given key.
+	ProcessDefinitionKey string `json:"processDefinitionKey,omitempty"`
+
+	// Restricts to incidents that belong to a process definition with one of the given keys.
+	ProcessDefinitionKeyIn []string `json:"processDefinitionKeyIn,omitempty"`
+
+	// Restricts to incidents that belong to a process instance with the given id.
+	ProcessInstanceId string `json:"processInstanceId,omitempty"`
+
+	// Restricts to incidents that belong to an execution with the given id.
+	ExecutionId string `json:"executionId,omitempty"`
+
+	// Restricts to incidents that belong to an activity with the given id.
+	ActivityId string `json:"activityId,omitempty"`
+
+	// Restricts to incidents that were created due to the failure of an activity with the given id.
+	FailedActivityId string `json:"failedActivityId,omitempty"`
+
+	// Restricts to incidents that have the given incident id as cause incident.
+	CauseIncidentId string `json:"causeIncidentId,omitempty"`
+
+	// Restricts to incidents that have the given incident id as root cause incident.
+	RootCauseIncidentId string `json:"rootCauseIncidentId,omitempty"`
+
+	// Restricts to incidents that have the given parameter set as configuration.
+	Configuration string `json:"configuration,omitempty"`
+
+	// Restricts to incidents that have one of the given job definition ids.
+	JobDefinitionIdIn []string `json:"jobDefinitionIdIn,omitempty"`
+
+	// Restricts to incidents that have a create time before the given date.
+	// By default, the date must have the format yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	CreateTimeBefore string `json:"createTimeBefore,omitempty"`
+
+	// Restricts to incidents that have a create time after the given date.
+	// By default, the date must have the format yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	CreateTimeAfter string `json:"createTimeAfter,omitempty"`
+
+	// Restricts to incidents that have an end time before the given date.
+	// By default, the date must have the format yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	EndTimeBefore string `json:"endTimeBefore,omitempty"`
+
+	// Restricts to incidents that have an end time after the given date.
+	// By default, the date must have the format yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	EndTimeAfter string `json:"endTimeAfter,omitempty"`
+
+	// Restricts to incidents that are open.
+	Open bool `json:"open,omitempty"`
+
+	// Restricts to incidents that are resolved.
+	Resolved bool `json:"resolved,omitempty"`
+
+	// Restricts to incidents that are deleted.
+	Deleted bool `json:"deleted,omitempty"`
+
+	// Restricts to incidents that have one of the given tenant ids.
+	TenantIdIn []string `json:"tenantIdIn,omitempty"`
+
+	// Only include historic incidents that belong to no tenant.
+	WithoutTenantId bool `json:"withoutTenantId,omitempty"`
+
+	// Sort the results lexicographically by a given criterion. Must be used in
+	// conjunction with the SortOrder parameter.
+	SortBy string `json:"sortBy,omitempty"`
+
+	// Sort the results in a given order. Values may be asc for ascending order
+	// or desc for descending order. Must be used in conjunction with the SortBy parameter.
+	SortOrder string `json:"sortOrder,omitempty"`
+}
+
+type HistoricVariableInstance struct {
+	// The id of the variable instance.
+	Id string `json:"id,omitempty"`
+
+	// The name of the variable instance.
+	Name string `json:"name,omitempty"`
+
+	// The value type of the variable.
+	Type string `json:"type,omitempty"`
+
+	// The variable's value.
+	Value interface{} `json:"value,omitempty"`
+
+	// An object containing additional, value-type-dependent properties.
+	ValueInfo interface{} `json:"valueInfo,omitempty"`
+
+	// The key of the process definition the variable instance belongs to.
+	ProcessDefinitionKey string `json:"processDefinitionKey,omitempty"`
+
+	// The id of the process definition the variable instance belongs to.
+	ProcessDefinitionId string `json:"processDefinitionId,omitempty"`
+
+	// The id of the process instance the variable instance belongs to.
+	ProcessInstanceId string `json:"processInstanceId,omitempty"`
+
+	// The id of the execution the variable instance belongs to.
+	ExecutionId string `json:"executionId,omitempty"`
+
+	// The id of the activity instance in which the variable is valid.
+	ActivityInstanceId string `json:"activityInstanceId,omitempty"`
+
+	// The key of the case definition the variable instance belongs to.
+	CaseDefinitionKey string `json:"caseDefinitionKey,omitempty"`
+
+	// The id of the case definition the variable instance belongs to.
+	CaseDefinitionId string `json:"caseDefinitionId,omitempty"`
+
+	// The id of the case instance the variable instance belongs to.
+	CaseInstanceId string `json:"caseInstanceId,omitempty"`
+
+	// The id of the case execution the variable instance belongs to.
+	CaseExecutionId string `json:"caseExecutionId,omitempty"`
+
+	// The id of the task the variable instance belongs to.
+	TaskId string `json:"taskId,omitempty"`
+
+	// The id of the tenant that this variable instance belongs to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// An error message in case a Java Serialized Object could not be de-serialized.
+	ErrorMessage string `json:"errorMessage,omitempty"`
+
+	// The current state of the variable. Can be CREATED or DELETED.
+	State string `json:"state,omitempty"`
+
+	// The time the variable was inserted. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	CreateTime string `json:"createTime,omitempty"`
+
+	// The time after which the variable should be removed by the History Cleanup job.
+	// Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	RemovalTime string `json:"removalTime,omitempty"`
+
+	// The process instance id of the root process instance that initiated the process
+	// containing this variable.
+	RootProcessInstanceId string `json:"rootProcessInstanceId,omitempty"`
+}
+
+type HistoricVariableInstanceQuery struct {
+	// Filter by variable name.
+	VariableName string `json:"variableName,omitempty"`
+
+	// Restrict to variables with a name like the parameter.
+	VariableNameLike string `json:"variableNameLike,omitempty"`
+
+	// Filter by variable value. May be String, Number or Boolean.
+	VariableValue interface{} `json:"variableValue,omitempty"`
+
+	// Match the variable name provided in VariableName and VariableNameLike case-insensitively.
+	VariableNamesIgnoreCase bool `json:"variableNamesIgnoreCase,omitempty"`
+
+	// Match the variable value provided in VariableValue case-insensitively.
+	VariableValuesIgnoreCase bool `json:"variableValuesIgnoreCase,omitempty"`
+
+	// Only include historic variable instances which belong to one of the passed variable types.
+	VariableTypeIn []string `json:"variableTypeIn,omitempty"`
+
+	// Filter by the process instance the variable belongs to.
+	ProcessInstanceId string `json:"processInstanceId,omitempty"`
+
+	// Only include historic variable instances which belong to one of the passed process instance ids.
+	ProcessInstanceIdIn []string `json:"processInstanceIdIn,omitempty"`
+
+	// Filter by the process definition the variable belongs to.
+	ProcessDefinitionId string `json:"processDefinitionId,omitempty"`
+
+	// Filter by the key of the process definition the variable belongs to.
+	ProcessDefinitionKey string `json:"processDefinitionKey,omitempty"`
+
+	// Only include historic variable instances which belong to one of the passed execution ids.
+	ExecutionIdIn []string `json:"executionIdIn,omitempty"`
+
+	// Filter by the case instance the variable belongs to.
+	CaseInstanceId string `json:"caseInstanceId,omitempty"`
+
+	// Only include historic variable instances which belong to one of the passed task ids.
+	TaskIdIn []string `json:"taskIdIn,omitempty"`
+
+	// Only include historic variable instances which belong to one of the passed activity instance ids.
+	ActivityInstanceIdIn []string `json:"activityInstanceIdIn,omitempty"`
+
+	// Only include historic variable instances which belong to one of the passed tenant ids.
+	TenantIdIn []string `json:"tenantIdIn,omitempty"`
+
+	// Only include historic variable instances that belong to no tenant.
+	WithoutTenantId bool `json:"withoutTenantId,omitempty"`
+
+	// Include variables that has already been deleted during the execution.
+	IncludeDeleted bool `json:"includeDeleted,omitempty"`
+
+	// A JSON array of criteria to sort the result by. Each element of the array is
+	// a JSON object that specifies one ordering. The position in the array
+	// identifies the rank of an ordering, i.e., whether it is primary, secondary, etc.
+	Sorting []*Sort `json:"sorting,omitempty"`
+}
+
+type IdentityLink struct {
+	// The id of the user participating in this link. Either UserId or GroupId is set.
+	UserId string `json:"userId,omitempty"`
+
+	// The id of the group participating in this link. Either GroupId or UserId is set.
+	GroupId string `json:"groupId,omitempty"`
+
+	// The type of the identity link. The value can be IdentityLinkAssignee, IdentityLinkOwner,
+	// IdentityLinkCandidate or any custom value.
+	Type string `json:"type,omitempty"`
+}
+
+type Incident struct {
+	// The id of the incident.
+	Id string `json:"id,omitempty"`
+
+	// The id of the process definition this incident is associated with.
+	ProcessDefinitionId string `json:"processDefinitionId,omitempty"`
+
+	// The key of the process instance this incident is associated with.
+	ProcessInstanceId string `json:"processInstanceId,omitempty"`
+
+	// The id of the execution this incident is associated with.
+	ExecutionId string `json:"executionId,omitempty"`
+
+	// The time this incident happened. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	IncidentTimestamp string `json:"incidentTimestamp,omitempty"`
+
+	// The type of incident, for example: failedJob will be returned in case of an incident
+	// which identified a failed job during the execution of a process instance.
+	IncidentType string `json:"incidentType,omitempty"`
+
+	// The id of the activity this incident is associated with.
+	ActivityId string `json:"activityId,omitempty"`
+
+	// The id of the activity on which the last exception occurred.
+	FailedActivityId string `json:"failedActivityId,omitempty"`
+
+	// The id of the associated cause incident which has been triggered.
+	CauseIncidentId string `json:"causeIncidentId,omitempty"`
+
+	// The id of the associated root cause incident which has been triggered.
+	RootCauseIncidentId string `json:"rootCauseIncidentId,omitempty"`
+
+	// The payload of this incident, e.g. the id of the failed job.
+	Configuration string `json:"configuration,omitempty"`
+
+	// The id of the tenant this incident is associated with.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The message of this incident.
+	IncidentMessage string `json:"incidentMessage,omitempty"`
+
+	// The job definition id the incident is associated with.
+	JobDefinitionId string `json:"jobDefinitionId,omitempty"`
+
+	// The annotation of this incident.
+	Annotation string `json:"annotation,omitempty"`
+}
+
+type IncidentCreate struct {
+	// Mandatory. A type of the new incident.
+	IncidentType string `json:"incidentType,omitempty"`
+
+	// A configuration for the new incident.
+	Configuration string `json:"configuration,omitempty"`
+
+	// A message for the new incident.
+	Message string `json:"message,omitempty"`
+}
+
+type IncidentQuery struct {
+	// Restricts to incidents that have the given id.
+	IncidentId string `json:"incidentId,omitempty"`
+
+	// Restricts to incidents that belong to the given incident type.
+	IncidentType string `json:"incidentType,omitempty"`
+
+	// Restricts to incidents that have the given incident message.
+	IncidentMessage string `json:"incidentMessage,omitempty"`
+
+	// Restricts to incidents that have an incident message like the given value.
+	IncidentMessageLike string `json:"incidentMessageLike,omitempty"`
+
+	// Restricts to incidents that belong to a process definition with the given id.
+	ProcessDefinitionId string `json:"processDefinitionId,omitempty"`
+
+	// Restricts to incidents that belong to a process definition with one of the given keys.
+	ProcessDefinitionKeyIn []string `json:"processDefinitionKeyIn,omitempty"`
+
+	// Restricts to incidents that belong to a process instance with the given id.
+	ProcessInstanceId string `json:"processInstanceId,omitempty"`
+
+	// Restricts to incidents that belong to an execution with the given id.
+	ExecutionId string `json:"executionId,omitempty"`
+
+	// Restricts to incidents that have an incident timestamp before the given timestamp.
+	// By default, the date must have the format yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	IncidentTimestampBefore string `json:"incidentTimestampBefore,omitempty"`
+
+	// Restricts to incidents that have an incident timestamp after the given timestamp.
+	// By default, the date must have the format yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	IncidentTimestampAfter string `json:"incidentTimestampAfter,omitempty"`
+
+	// Restricts to incidents that belong to an activity with the given id.
+	ActivityId string `json:"activityId,omitempty"`
+
+	// Restricts to incidents that were created due to the failure of an activity with the given id.
+	FailedActivityId string `json:"failedActivityId,omitempty"`
+
+	// Restricts to incidents that have the given incident id as cause incident.
+	CauseIncidentId string `json:"causeIncidentId,omitempty"`
+
+	// Restricts to incidents that have the given incident id as root cause incident.
+	RootCauseIncidentId string `json:"rootCauseIncidentId,omitempty"`
+
+	// Restricts to incidents that have the given parameter set as configuration.
+	Configuration string `json:"configuration,omitempty"`
+
+	// Restricts to incidents that have one of the given tenant ids.
+	TenantIdIn []string `json:"tenantIdIn,omitempty"`
+
+	// Restricts to incidents that have one of the given job definition ids.
+	JobDefinitionIdIn []string `json:"jobDefinitionIdIn,omitempty"`
+
+	// Sort the results lexicographically by a given criterion. Must be used in
+	// conjunction with the SortOrder parameter.
+	SortBy string `json:"sortBy,omitempty"`
+
+	// Sort the results in a given order. Values may be asc for ascending order
+	// or desc for descending order. Must be used in conjunction with the SortBy parameter.
+	SortOrder string `json:"sortOrder,omitempty"`
+}
+
+type Instruction struct {
+	// Mandatory. One of the following values: startBeforeActivity, startAfterActivity, startTransition.
+	// A startBeforeActivity instruction requests to enter a given activity. A startAfterActivity instruction
+	// requests to execute the single outgoing sequence flow of a given activity. A startTransition
+	// instruction requests to execute a specific sequence flow.
+	Type string `json:"type,omitempty"`
+
+	// Can be used with instructions of types startBeforeActivity and startAfterActivity.
+	// Specifies the activity the instruction targets.
+	ActivityId string `json:"activityId,omitempty"`
+
+	// Can be used with instructions of types startTransition. Specifies the sequence flow to start.
+	TransitionId string `json:"transitionId,omitempty"`
+}
+
+type Job struct {
+	// The id of the job.
+	Id string `json:"id,omitempty"`
+
+	// The id of the associated job definition.
+	JobDefinitionId string `json:"jobDefinitionId,omitempty"`
+
+	// The date on which this job is supposed to be processed. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	DueDate string `json:"dueDate,omitempty"`
+
+	// The id of the process instance which execution created the job.
+	ProcessInstanceId string `json:"processInstanceId,omitempty"`
+
+	// The id of the process definition which this job belongs to.
+	ProcessDefinitionId string `json:"processDefinitionId,omitempty"`
+
+	// The key of the process definition which this job belongs to.
+	ProcessDefinitionKey string `json:"processDefinitionKey,omitempty"`
+
+	// The specific execution id on which the job was created.
+	ExecutionId string `json:"executionId,omitempty"`
+
+	// The message of the exception that occurred, the last time the job was executed.
+	// Is null when no exception occurred.
+	ExceptionMessage string `json:"exceptionMessage,omitempty"`
+
+	// The id of the activity on which the last exception occurred.
+	FailedActivityId string `json:"failedActivityId,omitempty"`
+
+	// The number of retries this job has left.
+	Retries int `json:"retries"`
+
+	// A flag indicating whether the job is suspended or not.
+	Suspended bool `json:"suspended,omitempty"`
+
+	// The job's priority for execution.
+	Priority int `json:"priority,omitempty"`
+
+	// The id of the tenant which this job belongs to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The date on which this job has been created. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	CreateTime string `json:"createTime,omitempty"`
+}
+
+type JobDefinition struct {
+	// The id of the job definition.
+	Id string `json:"id,omitempty"`
+
+	// The id of the process definition this job definition is associated with.
+	ProcessDefinitionId string `json:"processDefinitionId,omitempty"`
+
+	// The key of the process definition this job definition is associated with.
+	ProcessDefinitionKey string `json:"processDefinitionKey,omitempty"`
+
+	// The id of the activity this job definition is associated with.
+	ActivityId string `json:"activityId,omitempty"`
+
+	// The type of the job which is running for this job definition. See the User Guide
+	// for more information about job types.
+	JobType string `json:"jobType,omitempty"`
+
+	// The configuration of a job definition provides details about the jobs which will be created.
+	// For example: for timer jobs it is the timer configuration.
+	JobConfiguration string `json:"jobConfiguration,omitempty"`
+
+	// The execution priority defined for jobs that are created based on this definition.
+	// May be nil when the priority has not been overridden on the job definition level.
+	OverridingJobPriority *int `json:"overridingJobPriority,omitempty"`
+
+	// Indicates whether this job definition is suspended or not.
+	Suspended bool `json:"suspended,omitempty"`
+
+	// The id of the tenant this job definition is associated with.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The id of the deployment this job definition is related to. In a deployment-aware setup,
+	// this leads to all jobs of the same definition being executed on the same node.
+	DeploymentId string `json:"deploymentId,omitempty"`
+}
+
+type JobDefinitionQuery struct {
+	// Filter by job definition id.
+	JobDefinitionId string `json:"jobDefinitionId,omitempty"`
+
+	// Only include job definitions which belong to one of the passed activity ids.
+	ActivityIdIn []string `json:"activityIdIn,omitempty"`
+
+	// Only include job definitions which exist for the given process definition id.
+	ProcessDefinitionId string `json:"processDefinitionId,omitempty"`
+
+	// Only include job definitions which exist for the given process definition key.
+	ProcessDefinitionKey string `json:"processDefinitionKey,omitempty"`
+
+	// Only include job definitions which exist for the given job type.
+	JobType string `json:"jobType,omitempty"`
 
-	// The content of the comment.
-	Message string `json:"message,omitempty"`
+	// Only include job definitions which exist for the given job configuration.
+	JobConfiguration string `json:"jobConfiguration,omitempty"`
 
-	// Link to the newly created task comment with method, href and rel.
-	Links []*Link `json:"links,omitempty"`
+	// Only include active job definitions.
+	Active bool `json:"active,omitempty"`
 
-	// The time after which the comment should be removed by the History Cleanup
-	// job. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
-	RemovalTime string `json:"removalTime,omitempty"`
+	// Only include suspended job definitions.
+	Suspended bool `json:"suspended,omitempty"`
 
-	// The process instance id of the root process instance that initiated the process containing the task.
-	RootProcessInstanceId string `json:"rootProcessInstanceId,omitempty"`
-}
+	// Only include job definitions that have an overriding job priority defined.
+	WithOverridingJobPriority bool `json:"withOverridingJobPriority,omitempty"`
 
-type Deployment struct {
-	// The id of the deployment.
-	Id string `json:"id,omitempty"`
+	// Only include job definitions which belong to one of the passed tenant ids.
+	TenantIdIn []string `json:"tenantIdIn,omitempty"`
 
-	// The name of the deployment.
-	Name string `json:"name,omitempty"`
+	// Only include job definitions which belong to no tenant.
+	WithoutTenantId bool `json:"withoutTenantId,omitempty"`
 
-	// The source of the deployment.
-	Source string `json:"source,omitempty"`
+	// Include job definitions which belong to no tenant. Can be used in combination with TenantIdIn.
+	IncludeJobDefinitionsWithoutTenantId bool `json:"includeJobDefinitionsWithoutTenantId,omitempty"`
 
-	// The tenant id of the deployment.
-	TenantId string `json:"tenantId,omitempty"`
+	// A JSON array of criteria to sort the result by. Each element of the array is
+	// a JSON object that specifies one ordering. The position in the array
+	// identifies the rank of an ordering, i.e., whether it is primary, secondary, etc.
+	Sorting []*Sort `json:"sorting,omitempty"`
+}
 
-	// The time when the deployment was created.
-	DeploymentTime string `json:"deploymentTime,omitempty"`
+type JobQuery struct {
+	// Filter by job id.
+	JobId string `json:"jobId,omitempty"`
 
-	// An object containing a property for each of the
-	// process definitions, which are successfully deployed with that deployment.
-	DeployedProcessDefinitions map[string]*ProcessDefinition `json:"deployedProcessDefinitions,omitempty"`
+	// Filter by a list of job ids.
+	JobIds []string `json:"jobIds,omitempty"`
 
-	// An object containing a property for each of the case
-	// definitions, which are successfully deployed with that deployment.
-	DeployedCaseDefinitions map[string]*CaseDefinition `json:"deployedCaseDefinitions,omitempty"`
+	// Only select jobs which exist for the given job definition.
+	JobDefinitionId string `json:"jobDefinitionId,omitempty"`
 
-	// An object containing a property for each of the decision
-	// definitions, which are successfully deployed with that deployment.
-	DeployedDecisionDefinitions map[string]*DecisionDefinition `json:"deployedDecisionDefinitions,omitempty"`
+	// Only select jobs which exist for the given process instance.
+	ProcessInstanceId string `json:"processInstanceId,omitempty"`
 
-	// An object containing a property for each of the decision
-	// requirements definitions, which are successfully deployed with that deployment.
-	DeployedDecisionRequirementsDefinitions map[string]*DecisionRequirementsDefinition `json:"deployedDecisionRequirementsDefinitions,omitempty"`
+	// Only select jobs which exist for the given list of process instance ids.
+	ProcessInstanceIds []string `json:"processInstanceIds,omitempty"`
 
-	// Link to the newly created deployment with method, href and rel.
-	Links []*Link `json:"links,omitempty"`
-}
+	// Only select jobs which exist for the given execution.
+	ExecutionId string `json:"executionId,omitempty"`
 
-type DecisionDefinition struct {
-	// The id of the process definition.
-	Id string `json:"id,omitempty"`
+	// Filter by the id of the process definition the jobs run on.
+	ProcessDefinitionId string `json:"processDefinitionId,omitempty"`
 
-	// The key of the process definition.
-	Key string `json:"key,omitempty"`
+	// Filter by the key of the process definition the jobs run on.
+	ProcessDefinitionKey string `json:"processDefinitionKey,omitempty"`
 
-	// The category of the process definition.
-	Category string `json:"category,omitempty"`
+	// Only select jobs which exist for an activity with the given id.
+	ActivityId string `json:"activityId,omitempty"`
 
-	// The name of the process definition.
-	Name string `json:"name,omitempty"`
+	// Only select jobs which have retries left.
+	WithRetriesLeft bool `json:"withRetriesLeft,omitempty"`
 
-	// The version of the process definition that the engine assigned to it.
-	Version int `json:"version,omitempty"`
+	// Only select jobs which are executable, i.e., retries > 0 and due date is null or due date is in the past.
+	Executable bool `json:"executable,omitempty"`
 
-	// The file name of the process definition.
-	Resource string `json:"resource,omitempty"`
+	// Only select jobs that are timers. Cannot be used together with Messages.
+	Timers bool `json:"timers,omitempty"`
 
-	// The deployment id of the process definition.
-	DeploymentId string `json:"deploymentId,omitempty"`
+	// Only select jobs that are messages. Cannot be used together with Timers.
+	Messages bool `json:"messages,omitempty"`
 
-	// The id of the decision requirements definition this decision definition belongs to.
-	DecisionRequirementsDefinitionId string `json:"decisionRequirementsDefinitionId,omitempty"`
+	// Only select jobs where the due date is lower or higher than the given date.
+	DueDates []*DateCondition `json:"dueDates,omitempty"`
 
-	// The key of the decision requirements definition this decision definition belongs to.
-	DecisionRequirementsDefinitionKey string `json:"decisionRequirementsDefinitionKey,omitempty"`
+	// Only select jobs created before or after the given date.
+	CreateTimes []*DateCondition `json:"createTimes,omitempty"`
 
-	// The tenant id of the process definition.
-	TenantId string `json:"tenantId,omitempty"`
+	// Only select jobs that failed due to an exception.
+	WithException bool `json:"withException,omitempty"`
 
-	// The version tag of the process definition.
-	VersionTag string `json:"versionTag,omitempty"`
+	// Only select jobs that failed due to an exception with the given message.
+	ExceptionMessage string `json:"exceptionMessage,omitempty"`
 
-	// History time to live value of the process definition.
-	HistoryTimeToLive int `json:"historyTimeToLive,omitempty"`
-}
+	// Only select jobs that failed due to an exception at an activity with the given id.
+	FailedActivityId string `json:"failedActivityId,omitempty"`
 
-type DecisionRequirementsDefinition struct {
-	// The id of the process definition.
-	Id string `json:"id,omitempty"`
+	// Only select jobs which have no retries left.
+	NoRetriesLeft bool `json:"noRetriesLeft,omitempty"`
 
-	// The key of the process definition.
-	Key string `json:"key,omitempty"`
+	// Only include active jobs.
+	Active bool `json:"active,omitempty"`
 
-	// The category of the process definition.
-	Category string `json:"category,omitempty"`
+	// Only include suspended jobs.
+	Suspended bool `json:"suspended,omitempty"`
 
-	// The name of the process definition.
-	Name string `json:"name,omitempty"`
+	// Only include jobs with a priority lower than or equal to the given value.
+	PriorityLowerThanOrEquals *int `json:"priorityLowerThanOrEquals,omitempty"`
 
-	// The version of the process definition that the engine assigned to it.
-	Version int `json:"version,omitempty"`
+	// Only include jobs with a priority higher than or equal to the given value.
+	PriorityHigherThanOrEquals *int `json:"priorityHigherThanOrEquals,omitempty"`
 
-	// The file name of the process definition.
-	Resource string `json:"resource,omitempty"`
+	// Only include jobs which belong to one of the passed tenant ids.
+	TenantIdIn []string `json:"tenantIdIn,omitempty"`
 
-	// The deployment id of the process definition.
-	DeploymentId string `json:"deploymentId,omitempty"`
+	// Only include jobs which belong to no tenant.
+	WithoutTenantId bool `json:"withoutTenantId,omitempty"`
 
-	// The tenant id of the process definition.
-	TenantId string `json:"tenantId,omitempty"`
+	// Include jobs which belong to no tenant. Can be used in combination with TenantIdIn.
+	IncludeJobsWithoutTenantId bool `json:"includeJobsWithoutTenantId,omitempty"`
+
+	// A JSON array of criteria to sort the result by. Each element of the array is
+	// a JSON object that specifies one ordering. The position in the array
+	// identifies the rank of an ordering, i.e., whether it is primary, secondary, etc.
+	Sorting []*Sort `json:"sorting,omitempty"`
 }
 
-type Error struct {
-	// The type of exception.
-	Type string `json:"type,omitempty"`
+type JobRetries struct {
+	// A list of job ids to set retries for.
+	JobIds []string `json:"jobIds,omitempty"`
 
-	// The error message.
-	Message string `json:"message,omitempty"`
-}
+	// A job query, see JobQuery. The jobs matching the query are added to the list of job ids.
+	JobQuery *JobQuery `json:"jobQuery,omitempty"`
 
-type Fetch struct {
-	// Mandatory. The id of the worker on which behalf tasks are fetched. The returned tasks are
-	// locked for that worker and can only be completed when providing the same worker id.
-	WorkerId string `json:"workerId,omitempty"`
+	// Mandatory. The number of retries to set for the jobs. Must be >= 0.
+	Retries int `json:"retries"`
 
-	// Mandatory. The maximum number of tasks to return.
-	MaxTasks int `json:"maxTasks,omitempty"`
+	// The new due date of the jobs. If not set, the due date remains unchanged.
+	// By default, the date must have the format yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	DueDate string `json:"dueDate,omitempty"`
+}
 
-	// A boolean value, which indicates whether the task should be fetched based on its priority or arbitrarily.
-	UsePriority bool `json:"usePriority,omitempty"`
+type JobSuspension struct {
+	// Suspends or activates the jobs of the job definition with the given id.
+	JobDefinitionId string `json:"jobDefinitionId,omitempty"`
 
-	// The Long Polling timeout in milliseconds.
-	// Note: The value cannot be set larger than 1.800.000 milliseconds (corresponds to 30 minutes).
-	AsyncResponseTimeout int `json:"asyncResponseTimeout,omitempty"`
+	// Suspends or activates the jobs of the process definition with the given id.
+	ProcessDefinitionId string `json:"processDefinitionId,omitempty"`
 
-	// The topics for which external tasks should be fetched.
-	Topics []*Topic `json:"topics,omitempty"`
-}
+	// Suspends or activates the jobs of the process instance with the given id.
+	ProcessInstanceId string `json:"processInstanceId,omitempty"`
 
-type Instruction struct {
-	// Mandatory. One of the following values: startBeforeActivity, startAfterActivity, startTransition.
-	// A startBeforeActivity instruction requests to enter a given activity. A startAfterActivity instruction
-	// requests to execute the single outgoing sequence flow of a given activity. A startTransition
-	// instruction requests to execute a specific sequence flow.
-	Type string `json:"type,omitempty"`
+	// Suspends or activates the jobs of the process definitions with the given key.
+	ProcessDefinitionKey string `json:"processDefinitionKey,omitempty"`
 
-	// Can be used with instructions of types startBeforeActivity and startAfterActivity.
-	// Specifies the activity the instruction targets.
-	ActivityId string `json:"activityId,omitempty"`
+	// Only suspends or activates the jobs of the process definitions which belong to the
+	// given tenant. Can only be used in combination with ProcessDefinitionKey.
+	ProcessDefinitionTenantId string `json:"processDefinitionTenantId,omitempty"`
 
-	// Can be used with instructions of types startTransition. Specifies the sequence flow to start.
-	TransitionId string `json:"transitionId,omitempty"`
+	// Only suspends or activates the jobs of the process definitions which belong to no
+	// tenant. Can only be used in combination with ProcessDefinitionKey.
+	ProcessDefinitionWithoutTenantId bool `json:"processDefinitionWithoutTenantId,omitempty"`
+
+	// A boolean value which indicates whether to activate or suspend the jobs. Set by
+	// the ActivateJobs and SuspendJobs methods.
+	Suspended bool `json:"suspended"`
 }
 
 type Link struct {
@@ -264,6 +1644,30 @@ type Link struct {
 	Rel string `json:"rel,omitempty"`
 }
 
+type PasswordPolicy struct {
+	// The rules of the password policy.
+	Rules []*PasswordPolicyRule `json:"rules,omitempty"`
+}
+
+type PasswordPolicyResult struct {
+	// The rules of the password policy, each with the result of the validation.
+	Rules []*PasswordPolicyRule `json:"rules,omitempty"`
+
+	// True if the password is valid regarding all rules of the policy.
+	Valid bool `json:"valid,omitempty"`
+}
+
+type PasswordPolicyRule struct {
+	// A placeholder string that can be used to display an internationalized message to the user.
+	Placeholder string `json:"placeholder,omitempty"`
+
+	// A map of parameters that can be used to display a parameterized message to the user.
+	Parameters map[string]string `json:"parameter,omitempty"`
+
+	// True if the password is valid regarding the rule. Only set on validation results.
+	Valid bool `json:"valid,omitempty"`
+}
+
 type ProcessDefinition struct {
 	// The id of the process definition.
 	Id string `json:"id,omitempty"`
@@ -645,6 +2049,74 @@ type QueryVariable struct {
 	Value interface{} `json:"value,omitempty"`
 }
 
+type RetryFilter struct {
+	// Only select failures of process definitions with one of the given keys.
+	ProcessDefinitionKeys []string `json:"processDefinitionKeys,omitempty"`
+
+	// Only select failures of the activity with the given id.
+	ActivityId string `json:"activityId,omitempty"`
+
+	// Only select failures whose exception message matches the given regular expression.
+	MessagePattern string `json:"messagePattern,omitempty"`
+
+	// Only select failures which happened after the given time.
+	FailedAfter time.Time `json:"failedAfter"`
+
+	// Only select failures which happened before the given time.
+	FailedBefore time.Time `json:"failedBefore"`
+
+	// Only select failures which belong to one of the given tenant ids.
+	TenantIds []string `json:"tenantIds,omitempty"`
+}
+
+type RetryCandidate struct {
+	// The kind of the failed entity, either IncidentFailedJob or IncidentFailedExternalTask.
+	Kind string `json:"kind,omitempty"`
+
+	// The id of the failed job or external task.
+	Id string `json:"id,omitempty"`
+
+	// The id of the incident that was raised for the failure.
+	IncidentId string `json:"incidentId,omitempty"`
+
+	// The id of the process definition the failed entity belongs to.
+	ProcessDefinitionId string `json:"processDefinitionId,omitempty"`
+
+	// The id of the process instance the failed entity belongs to.
+	ProcessInstanceId string `json:"processInstanceId,omitempty"`
+
+	// The id of the activity that failed.
+	ActivityId string `json:"activityId,omitempty"`
+
+	// The exception message of the failure.
+	Message string `json:"message,omitempty"`
+
+	// The time the failure happened. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	Time string `json:"time,omitempty"`
+}
+
+type RetryPlan struct {
+	// The filter the candidates were selected with.
+	Filter *RetryFilter `json:"filter,omitempty"`
+
+	// The failed jobs and external tasks to retry.
+	Candidates []*RetryCandidate `json:"candidates,omitempty"`
+}
+
+type RetrySummary struct {
+	// The number of jobs whose retries were set.
+	Jobs int `json:"jobs"`
+
+	// The number of external tasks whose retries were set.
+	ExternalTasks int `json:"externalTasks"`
+
+	// The number of touched entities per process definition id.
+	Definitions map[string]int `json:"definitions,omitempty"`
+
+	// The batches created by the engine to set the retries.
+	Batches []*Batch `json:"batches,omitempty"`
+}
+
 type Sort struct {
 	// Mandatory. Sorts the results lexicographically by a given
 	// criterion. Valid values are instanceId, definitionId, definitionKey,
@@ -741,6 +2213,45 @@ type Task struct {
 	TenantId string `json:"tenantId,omitempty"`
 }
 
+type TaskUpdate struct {
+	// The id of the task. Only used when creating a task.
+	Id string `json:"id,omitempty"`
+
+	// The task name.
+	Name string `json:"name,omitempty"`
+
+	// The task's description.
+	Description string `json:"description,omitempty"`
+
+	// The user to assign to this task.
+	Assignee string `json:"assignee,omitempty"`
+
+	// The owner of the task.
+	Owner string `json:"owner,omitempty"`
+
+	// The delegation state of the task. Corresponds to the DelegationState enum in the engine.
+	// Possible values are RESOLVED and PENDING.
+	DelegationState string `json:"delegationState,omitempty"`
+
+	// The due date for the task. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	Due string `json:"due,omitempty"`
+
+	// The follow-up date for the task. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	FollowUp string `json:"followUp,omitempty"`
+
+	// The priority of the task.
+	Priority int `json:"priority,omitempty"`
+
+	// The id of the parent task, if this task is a subtask.
+	ParentTaskId string `json:"parentTaskId,omitempty"`
+
+	// The id of the case instance the task belongs to.
+	CaseInstanceId string `json:"caseInstanceId,omitempty"`
+
+	// The id of the tenant the task belongs to.
+	TenantId string `json:"tenantId,omitempty"`
+}
+
 type TaskHistory struct {
 	// The task id.
 	Id string `json:"id,omitempty"`
@@ -831,6 +2342,30 @@ type Tenant struct {
 	Name string `json:"name,omitempty"`
 }
 
+type TimelineEvent struct {
+	// The point in time the event happened.
+	Time time.Time `json:"time"`
+
+	// The kind of the event, one of EventActivityStarted, EventActivityEnded, EventTaskCreated,
+	// EventTaskEnded, EventUserOperation, EventIncidentCreated and EventIncidentEnded.
+	Kind string `json:"kind,omitempty"`
+
+	// The id of the entity the event originates from.
+	Id string `json:"id,omitempty"`
+
+	// The id of the activity the event is related to, if any.
+	ActivityId string `json:"activityId,omitempty"`
+
+	// A human readable name of the entity, e.g. the activity or task name.
+	Name string `json:"name,omitempty"`
+
+	// The id of the user the event is related to, e.g. the task assignee or operator.
+	UserId string `json:"userId,omitempty"`
+
+	// Additional information, e.g. an incident message or the changed property of an operation.
+	Detail string `json:"detail,omitempty"`
+}
+
 type Token struct {
 	// ...
 	Kind string `json:"token_type,omitempty"`
@@ -891,6 +2426,72 @@ type Topic struct {
 	TenantIdIn []string `json:"tenantIdIn,omitempty"`
 }
 
+type User struct {
+	// The id of the user.
+	Id string `json:"id,omitempty"`
+
+	// The first name of the user.
+	FirstName string `json:"firstName,omitempty"`
+
+	// The last name of the user.
+	LastName string `json:"lastName,omitempty"`
+
+	// The email of the user.
+	Email string `json:"email,omitempty"`
+}
+
+type UserCredentials struct {
+	// The user's new password.
+	Password string `json:"password,omitempty"`
+
+	// The password of the authenticated user who changes the password of the user
+	// (i.e., the user with passed id as path parameter).
+	AuthenticatedUserPassword string `json:"authenticatedUserPassword,omitempty"`
+}
+
+type UserQuery struct {
+	// Filter by user id.
+	Id string `json:"id,omitempty"`
+
+	// Filter by a list of user ids.
+	IdIn []string `json:"idIn,omitempty"`
+
+	// Filter by the first name of the user.
+	FirstName string `json:"firstName,omitempty"`
+
+	// Filter by the first name that the parameter is a substring of.
+	FirstNameLike string `json:"firstNameLike,omitempty"`
+
+	// Filter by the last name of the user.
+	LastName string `json:"lastName,omitempty"`
+
+	// Filter by the last name that the parameter is a substring of.
+	LastNameLike string `json:"lastNameLike,omitempty"`
+
+	// Filter by the email of the user.
+	Email string `json:"email,omitempty"`
+
+	// Filter by the email that the parameter is a substring of.
+	EmailLike string `json:"emailLike,omitempty"`
+
+	// Filter for users which are members of the given group.
+	MemberOfGroup string `json:"memberOfGroup,omitempty"`
+
+	// Filter for users which are members of the given tenant.
+	MemberOfTenant string `json:"memberOfTenant,omitempty"`
+
+	// Only select users that are potential starters for the given process definition.
+	PotentialStarter string `json:"potentialStarter,omitempty"`
+
+	// Sort the results lexicographically by a given criterion. Valid values are
+	// userId, firstName, lastName and email.
+	SortBy string `json:"sortBy,omitempty"`
+
+	// Sort the results in a given order. Values may be asc for ascending order
+	// or desc for descending order. Must be used in conjunction with the SortBy parameter.
+	SortOrder string `json:"sortOrder,omitempty"`
+}
+
 type UserOperationLog struct {
 	// The unique identifier of this log entry.
 	Id string `json:"id,omitempty"`
@@ -981,6 +2582,15 @@ type Variable struct {
 	ValueInfo interface{} `json:"valueInfo,omitempty"`
 }
 
+type VariableModification struct {
+	// An object containing variable key-value pairs. Each key is a variable name and each
+	// value a variable which is created or updated.
+	Modifications map[string]*Variable `json:"modifications,omitempty"`
+
+	// An array of names of variables to be deleted.
+	Deletions []string `json:"deletions,omitempty"`
+}
+
 // For serialized variables of type Object, the following properties can be provided:
 type ObjectValueInfo struct {
 	// A string representation of the object's type name.
@@ -990,6 +2600,26 @@ type ObjectValueInfo struct {
 	SerializationDataFormat string `json:"serializationDataFormat,omitempty"`
 }
 
+// Variable returns the value of the variable instance as a Variable.
+func (v *HistoricVariableInstance) Variable() *Variable {
+	return &Variable{Type: v.Type, Value: v.Value, ValueInfo: v.ValueInfo}
+}
+
+// Variable returns the value of a variable update as a Variable. Returns nil for historic
+// details which are not of type variableUpdate.
+func (d *HistoricDetail) Variable() *Variable {
+	if d.Type != "variableUpdate" {
+		return nil
+	}
+
+	return &Variable{Type: d.VariableType, Value: d.Value, ValueInfo: d.ValueInfo}
+}
+
+// Timestamp returns the time when the comment was created, parsed with the configured time layout.
+func (c *Comment) Timestamp() (time.Time, error) {
+	return parseTime(c.Time)
+}
+
 func (e *Error) Error() string {
 	return fmt.Sprintf("type %s, message: %s", e.Type, e.Message)
 }
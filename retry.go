@@ -0,0 +1,195 @@
+package camunda
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"text/tabwriter"
+)
+
+// retryPageSize is the number of incidents fetched per request while planning retries.
+const retryPageSize = 500
+
+// PlanRetries selects the failed jobs and external tasks that match the given filter. A job or
+// external task is considered failed when it has no retries left and an incident was raised for it.
+// The returned plan does not change anything in the engine, it can be printed as a dry-run report
+// and passed to ExecuteRetries afterwards.
+func PlanRetries(ctx context.Context, filter *RetryFilter) (*RetryPlan, error) {
+	var pattern *regexp.Regexp
+	var err error
+
+	if filter == nil {
+		filter = new(RetryFilter)
+	}
+
+	if filter.MessagePattern != "" {
+		if pattern, err = regexp.Compile(filter.MessagePattern); err != nil {
+			return nil, err
+		}
+	}
+
+	plan := &RetryPlan{Filter: filter, Candidates: make([]*RetryCandidate, 0)}
+
+	for _, kind := range []string{IncidentFailedJob, IncidentFailedExternalTask} {
+		query := &IncidentQuery{
+			IncidentType:           kind,
+			ProcessDefinitionKeyIn: filter.ProcessDefinitionKeys,
+			ActivityId:             filter.ActivityId,
+			TenantIdIn:             filter.TenantIds,
+		}
+
+		if !filter.FailedAfter.IsZero() {
+			query.IncidentTimestampAfter = formatTime(filter.FailedAfter)
+		}
+
+		if !filter.FailedBefore.IsZero() {
+			query.IncidentTimestampBefore = formatTime(filter.FailedBefore)
+		}
+
+		for first := 0; ; first += retryPageSize {
+			incidents, err := GetIncidents(ctx, query, first, retryPageSize)
+
+			if err != nil {
+				return nil, err
+			}
+
+			for _, v := range incidents {
+				if pattern != nil && !pattern.MatchString(v.IncidentMessage) {
+					continue
+				}
+
+				candidate := &RetryCandidate{
+					Kind:                kind,
+					Id:                  v.Configuration,
+					IncidentId:          v.Id,
+					ProcessDefinitionId: v.ProcessDefinitionId,
+					ProcessInstanceId:   v.ProcessInstanceId,
+					ActivityId:          v.ActivityId,
+					Message:             v.IncidentMessage,
+					Time:                v.IncidentTimestamp,
+				}
+
+				plan.Candidates = append(plan.Candidates, candidate)
+			}
+
+			if len(incidents) < retryPageSize {
+				break
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// ExecuteRetries sets the retries of all jobs and external tasks of the plan. The retries are set
+// asynchronously by the engine, in batches of at most batchSize entities per request. Candidates
+// listed more than once are retried once. When a batch fails, the returned summary covers the
+// batches created before.
+func ExecuteRetries(ctx context.Context, plan *RetryPlan, retries, batchSize int) (*RetrySummary, error) {
+	if plan == nil {
+		return nil, fmt.Errorf("retry plan is nil")
+	}
+
+	if batchSize <= 0 {
+		return nil, fmt.Errorf("invalid batch size: %d", batchSize)
+	}
+
+	jobs := make([]string, 0)
+	tasks := make([]string, 0)
+
+	// the process definition of each job and external task, also used to skip duplicates.
+	definitions := make(map[string]string)
+
+	summary := &RetrySummary{Definitions: make(map[string]int), Batches: make([]*Batch, 0)}
+
+	for _, v := range plan.Candidates {
+		key := v.Kind + "/" + v.Id
+
+		if _, ok := definitions[key]; ok {
+			continue
+		}
+
+		switch v.Kind {
+		case IncidentFailedJob:
+			jobs = append(jobs, v.Id)
+		case IncidentFailedExternalTask:
+			tasks = append(tasks, v.Id)
+		default:
+			continue
+		}
+
+		definitions[key] = v.ProcessDefinitionId
+	}
+
+	for i := 0; i < len(jobs); i += batchSize {
+		end := i + batchSize
+
+		if end > len(jobs) {
+			end = len(jobs)
+		}
+
+		ids := jobs[i:end]
+
+		batch, err := SetJobRetriesAsync(ctx, &JobRetries{JobIds: ids, Retries: retries})
+
+		if err != nil {
+			return summary, err
+		}
+
+		for _, v := range ids {
+			summary.Definitions[definitions[IncidentFailedJob+"/"+v]]++
+		}
+
+		summary.Jobs += len(ids)
+		summary.Batches = append(summary.Batches, batch)
+	}
+
+	for i := 0; i < len(tasks); i += batchSize {
+		end := i + batchSize
+
+		if end > len(tasks) {
+			end = len(tasks)
+		}
+
+		ids := tasks[i:end]
+
+		batch, err := SetExternalTaskRetriesAsync(ctx, &ExternalTaskRetries{ExternalTaskIds: ids, Retries: retries})
+
+		if err != nil {
+			return summary, err
+		}
+
+		for _, v := range ids {
+			summary.Definitions[definitions[IncidentFailedExternalTask+"/"+v]]++
+		}
+
+		summary.ExternalTasks += len(ids)
+		summary.Batches = append(summary.Batches, batch)
+	}
+
+	return summary, nil
+}
+
+// Print writes a human readable dry-run report of the plan to w.
+func (p *RetryPlan) Print(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	if _, err := fmt.Fprintln(tw, "KIND\tID\tPROCESS INSTANCE\tACTIVITY\tTIME\tMESSAGE"); err != nil {
+		return err
+	}
+
+	for _, v := range p.Candidates {
+		_, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", v.Kind, v.Id, v.ProcessInstanceId, v.ActivityId, v.Time, v.Message)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintf(tw, "\n%d candidates\n", len(p.Candidates)); err != nil {
+		return err
+	}
+
+	return tw.Flush()
+}
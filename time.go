@@ -0,0 +1,49 @@
+package camunda
+
+import (
+	"fmt"
+	"time"
+)
+
+var (
+	layout = TimeLayout
+)
+
+// layouts are fallback formats accepted when parsing timestamps returned by the engine. The engine
+// serializes dates as yyyy-MM-dd'T'HH:mm:ss.SSSZ by default, which has no colon in the zone offset.
+var layouts = []string{
+	TimeLayout,
+	"2006-01-02T15:04:05.000-0700",
+	time.RFC3339Nano,
+}
+
+// ConfigureTimeLayout configures the layout used to parse timestamps returned by the engine and to
+// format timestamps sent to it. The layout must match the date format configured for the REST API
+// of the engine. Defaults to TimeLayout.
+func ConfigureTimeLayout(value string) {
+	layout = value
+}
+
+// parseTime parses a timestamp returned by the engine. The configured layout is tried first,
+// then the fallback layouts.
+func parseTime(value string) (time.Time, error) {
+	var t time.Time
+	var err error
+
+	if t, err = time.Parse(layout, value); err == nil {
+		return t, nil
+	}
+
+	for _, v := range layouts {
+		if t, err = time.Parse(v, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return t, fmt.Errorf("parse time failed, value: %s", value)
+}
+
+// formatTime formats a point in time with the configured layout.
+func formatTime(t time.Time) string {
+	return t.Format(layout)
+}
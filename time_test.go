@@ -0,0 +1,54 @@
+package camunda
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatTime(t *testing.T) {
+	defer ConfigureTimeLayout(TimeLayout)
+
+	value := time.Date(2021, 3, 4, 5, 6, 7, 8000000, time.FixedZone("", 3600))
+
+	require.Equal(t, "2021-03-04T05:06:07.008+01:00", formatTime(value))
+
+	ConfigureTimeLayout("2006-01-02T15:04:05.000-0700")
+
+	require.Equal(t, "2021-03-04T05:06:07.008+0100", formatTime(value))
+
+	parsed, err := parseTime(formatTime(value))
+
+	require.NoError(t, err)
+	require.True(t, value.Equal(parsed))
+}
+
+func TestParseTime(t *testing.T) {
+	defer ConfigureTimeLayout(TimeLayout)
+
+	ConfigureTimeLayout("2006-01-02")
+
+	tests := []struct {
+		value    string
+		expected time.Time
+	}{
+		{"2021-03-04", time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"2021-03-04T05:06:07.008+01:00", time.Date(2021, 3, 4, 5, 6, 7, 8000000, time.FixedZone("", 3600))},
+		{"2021-03-04T05:06:07.008+0100", time.Date(2021, 3, 4, 5, 6, 7, 8000000, time.FixedZone("", 3600))},
+		{"2021-03-04T05:06:07.000000008Z", time.Date(2021, 3, 4, 5, 6, 7, 8, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			result, err := parseTime(tt.value)
+
+			require.NoError(t, err)
+			require.True(t, tt.expected.Equal(result), "expected %s, got %s", tt.expected, result)
+		})
+	}
+
+	_, err := parseTime("yesterday")
+
+	require.EqualError(t, err, "parse time failed, value: yesterday")
+}
@@ -0,0 +1,119 @@
+package camunda
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Timeline reconstructs the path a process instance took. Historic activity instances, historic
+// tasks, user operation log entries and incidents of the instance are merged into a single list
+// of events, ordered chronologically. Events with the same timestamp keep the order in which they
+// were collected.
+func Timeline(ctx context.Context, processInstanceId string) ([]*TimelineEvent, error) {
+	var activities []*HistoricActivityInstance
+	var tasks []*TaskHistory
+	var operations []*UserOperationLog
+	var incidents []*HistoricIncident
+	var err error
+
+	query := &HistoricActivityInstanceQuery{ProcessInstanceId: processInstanceId}
+
+	if activities, err = GetHistoricActivityInstances(ctx, query, 0, 0); err != nil {
+		return nil, err
+	}
+
+	if tasks, err = GetTasksHistory(ctx, processInstanceId); err != nil {
+		return nil, err
+	}
+
+	if operations, err = GetUserOperationsByProcessInstance(ctx, processInstanceId); err != nil {
+		return nil, err
+	}
+
+	if incidents, err = GetHistoricIncidents(ctx, &HistoricIncidentQuery{ProcessInstanceId: processInstanceId}, 0, 0); err != nil {
+		return nil, err
+	}
+
+	result := make([]*TimelineEvent, 0, 2*len(activities)+2*len(tasks)+len(operations)+2*len(incidents))
+
+	for _, v := range activities {
+		if result, err = appendEvent(result, v.StartTime, EventActivityStarted, v.Id, v.ActivityId, v.ActivityName, v.Assignee, v.ActivityType); err != nil {
+			return nil, err
+		}
+
+		detail := v.ActivityType
+
+		if v.Canceled {
+			detail = fmt.Sprintf("%s (canceled)", v.ActivityType)
+		}
+
+		if result, err = appendEvent(result, v.EndTime, EventActivityEnded, v.Id, v.ActivityId, v.ActivityName, v.Assignee, detail); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, v := range tasks {
+		if result, err = appendEvent(result, v.StartTime, EventTaskCreated, v.Id, v.TaskDefinitionKey, v.Name, v.Assignee, ""); err != nil {
+			return nil, err
+		}
+
+		if result, err = appendEvent(result, v.EndTime, EventTaskEnded, v.Id, v.TaskDefinitionKey, v.Name, v.Assignee, v.DeleteReason); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, v := range operations {
+		detail := v.OperationType
+
+		if v.Property != "" {
+			detail = fmt.Sprintf("%s %s: %s -> %s", v.OperationType, v.Property, v.OrgValue, v.NewValue)
+		}
+
+		if result, err = appendEvent(result, v.Timestamp, EventUserOperation, v.Id, "", v.EntityType, v.UserId, detail); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, v := range incidents {
+		if result, err = appendEvent(result, v.CreateTime, EventIncidentCreated, v.Id, v.ActivityId, v.IncidentType, "", v.IncidentMessage); err != nil {
+			return nil, err
+		}
+
+		if result, err = appendEvent(result, v.EndTime, EventIncidentEnded, v.Id, v.ActivityId, v.IncidentType, "", v.IncidentMessage); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Time.Before(result[j].Time)
+	})
+
+	return result, nil
+}
+
+// appendEvent appends an event to the timeline. Events without a timestamp, e.g. the end of a
+// still running activity, are skipped.
+func appendEvent(events []*TimelineEvent, timestamp, kind, id, activityId, name, userId, detail string) ([]*TimelineEvent, error) {
+	if timestamp == "" {
+		return events, nil
+	}
+
+	t, err := parseTime(timestamp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	event := &TimelineEvent{
+		Time:       t,
+		Kind:       kind,
+		Id:         id,
+		ActivityId: activityId,
+		Name:       name,
+		UserId:     userId,
+		Detail:     detail,
+	}
+
+	return append(events, event), nil
+}
@@ -0,0 +1,81 @@
+package camunda
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeline(t *testing.T) {
+	ctx := context.Background()
+
+	requests := record(t, map[string]string{
+		"POST /history/activity-instance": `[
+			{"id":"start:1","activityId":"start","activityName":"Invoice received","activityType":"startEvent",
+			 "startTime":"2021-01-01T10:00:00.000+0100","endTime":"2021-01-01T10:00:00.000+0100","canceled":false},
+			{"id":"review:2","activityId":"review","activityName":"Review invoice","activityType":"userTask",
+			 "assignee":"demo","startTime":"2021-01-01T10:00:00.000+0100","endTime":null,"canceled":false}
+		]`,
+		"GET /history/task": `[
+			{"id":"task-1","taskDefinitionKey":"review","name":"Review invoice","assignee":"demo",
+			 "startTime":"2021-01-01T10:00:00.000+0100","endTime":null,"deleteReason":null}
+		]`,
+		"GET /history/user-operation": `[
+			{"id":"operation-1","userId":"demo","timestamp":"2021-01-01T10:05:00.000+0100","operationType":"Claim",
+			 "entityType":"Task","property":"assignee","orgValue":null,"newValue":"demo"}
+		]`,
+		"GET /history/incident": `[
+			{"id":"incident-1","activityId":"review","incidentType":"failedJob","incidentMessage":"timeout",
+			 "createTime":"2021-01-01T10:02:00.000+0100","endTime":"2021-01-01T10:03:00.000+0100"}
+		]`,
+	})
+
+	events, err := Timeline(ctx, "process-instance-1")
+
+	require.NoError(t, err)
+
+	summary := make([]string, 0, len(events))
+
+	for _, v := range events {
+		summary = append(summary, fmt.Sprintf("%s %s %s", v.Time.UTC().Format("15:04"), v.Kind, v.Id))
+	}
+
+	// the events with the same timestamp keep the order of their sources, the still running user
+	// task has no end events.
+	require.Equal(t, []string{
+		"09:00 activityStarted start:1",
+		"09:00 activityEnded start:1",
+		"09:00 activityStarted review:2",
+		"09:00 taskCreated task-1",
+		"09:02 incidentCreated incident-1",
+		"09:03 incidentEnded incident-1",
+		"09:05 userOperation operation-1",
+	}, summary)
+
+	require.Equal(t, "Review invoice", events[3].Name)
+	require.Equal(t, "demo", events[3].UserId)
+	require.Equal(t, "timeout", events[4].Detail)
+	require.Equal(t, "Claim assignee:  -> demo", events[6].Detail)
+
+	require.Equal(t, []string{
+		`POST /history/activity-instance {"processInstanceId":"process-instance-1"}`,
+		"GET /history/task?processInstanceId=process-instance-1",
+		"GET /history/user-operation?processInstanceId=process-instance-1",
+		"GET /history/incident?processInstanceId=process-instance-1",
+	}, requests())
+}
+
+func TestTimelineInvalidTimestamp(t *testing.T) {
+	ctx := context.Background()
+
+	record(t, map[string]string{
+		"POST /history/activity-instance": `[{"id":"start:1","activityId":"start","startTime":"yesterday"}]`,
+	})
+
+	events, err := Timeline(ctx, "process-instance-1")
+
+	require.EqualError(t, err, "parse time failed, value: yesterday")
+	require.Nil(t, events)
+}
@@ -0,0 +1,110 @@
+package camunda
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// The variable endpoints of process instances, executions and tasks share the same shape. The
+// functions below implement them for a scope, e.g. process-instance/{id}/variables or
+// execution/{id}/localVariables.
+
+func getVariables(ctx context.Context, scope string, deserializeValues bool) (map[string]*Variable, error) {
+	var uri string
+	var err error
+
+	result := make(map[string]*Variable, 0)
+
+	uri = fmt.Sprintf("%s/%s/%s?deserializeValues=%t", url, path, scope, deserializeValues)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+func getVariable(ctx context.Context, scope, name string, deserializeValue bool) (*Variable, error) {
+	var uri string
+	var err error
+
+	result := new(Variable)
+
+	uri = fmt.Sprintf("%s/%s/%s/%s?deserializeValue=%t", url, path, scope, escape(name), deserializeValue)
+	err = client.send(ctx, uri, http.MethodGet, "application/json", nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+func setVariable(ctx context.Context, scope, name string, variable *Variable) error {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(variable)
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/%s/%s", url, path, scope, escape(name))
+	err = client.send(ctx, uri, http.MethodPut, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+func deleteVariable(ctx context.Context, scope, name string) error {
+	var uri string
+	var err error
+
+	uri = fmt.Sprintf("%s/%s/%s/%s", url, path, scope, escape(name))
+	err = client.send(ctx, uri, http.MethodDelete, "application/json", nil, nil)
+
+	return err
+}
+
+func modifyVariables(ctx context.Context, scope string, data *VariableModification) error {
+	var uri string
+	var err error
+
+	payload, err := json.Marshal(data)
+
+	if err != nil {
+		return err
+	}
+
+	uri = fmt.Sprintf("%s/%s/%s", url, path, scope)
+	err = client.send(ctx, uri, http.MethodPost, "application/json", bytes.NewReader(payload), nil)
+
+	return err
+}
+
+func getVariableData(ctx context.Context, scope, name string) (io.ReadCloser, error) {
+	var uri string
+
+	uri = fmt.Sprintf("%s/%s/%s/%s/data", url, path, scope, escape(name))
+
+	return client.stream(ctx, uri, http.MethodGet, "application/octet-stream", nil)
+}
+
+func setVariableData(ctx context.Context, scope, name, valueType string, content io.Reader) error {
+	var uri string
+
+	fields := make(map[string]string)
+
+	if valueType != "" {
+		fields["valueType"] = valueType
+	}
+
+	uri = fmt.Sprintf("%s/%s/%s/%s/data", url, path, scope, escape(name))
+
+	return client.upload(ctx, uri, fields, "data", name, content, nil)
+}
@@ -0,0 +1,138 @@
+package camunda
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// variableScope holds the variable functions of a scope, e.g. of process instances.
+type variableScope struct {
+	id     string
+	uri    string
+	query  string
+	list   func(ctx context.Context, id string) (map[string]*Variable, error)
+	get    func(ctx context.Context, id, name string, deserializeValue bool) (*Variable, error)
+	set    func(ctx context.Context, id, name string, variable *Variable) error
+	delete func(ctx context.Context, id, name string) error
+	modify func(ctx context.Context, id string, data *VariableModification) error
+	data   func(ctx context.Context, id, name string) (io.ReadCloser, error)
+	upload func(ctx context.Context, id, name, valueType string, content io.Reader) error
+}
+
+func TestVariables(t *testing.T) {
+	ctx := context.Background()
+
+	scopes := map[string]variableScope{
+		"process instance": {
+			id:    "process-instance-1",
+			uri:   "/process-instance/process-instance-1/variables",
+			query: "?deserializeValues=false",
+			list: func(ctx context.Context, id string) (map[string]*Variable, error) {
+				return GetProcessInstanceVariables(ctx, id, false)
+			},
+			get:    GetProcessInstanceVariable,
+			set:    SetProcessInstanceVariable,
+			delete: DeleteProcessInstanceVariable,
+			modify: ModifyProcessInstanceVariables,
+			data:   GetProcessInstanceVariableData,
+			upload: SetProcessInstanceVariableData,
+		},
+		"execution": {
+			id:    "execution-1",
+			uri:   "/execution/execution-1/localVariables",
+			query: "?deserializeValues=false",
+			list: func(ctx context.Context, id string) (map[string]*Variable, error) {
+				return GetExecutionLocalVariables(ctx, id, false)
+			},
+			get:    GetExecutionLocalVariable,
+			set:    SetExecutionLocalVariable,
+			delete: DeleteExecutionLocalVariable,
+			modify: ModifyExecutionLocalVariables,
+			data:   GetExecutionLocalVariableData,
+			upload: SetExecutionLocalVariableData,
+		},
+		"task": {
+			id:     "task-1",
+			uri:    "/task/task-1/variables",
+			list:   GetTaskVariables,
+			get:    GetTaskVariable,
+			set:    SetTaskVariable,
+			delete: DeleteTaskVariable,
+			modify: ModifyTaskVariables,
+			data:   GetTaskVariableData,
+			upload: SetTaskVariableData,
+		},
+		"task local": {
+			id:    "task-1",
+			uri:   "/task/task-1/localVariables",
+			query: "?deserializeValues=false",
+			list: func(ctx context.Context, id string) (map[string]*Variable, error) {
+				return GetTaskLocalVariables(ctx, id, false)
+			},
+			get:    GetTaskLocalVariable,
+			set:    SetTaskLocalVariable,
+			delete: DeleteTaskLocalVariable,
+			modify: ModifyTaskLocalVariables,
+			data:   GetTaskLocalVariableData,
+			upload: SetTaskLocalVariableData,
+		},
+	}
+
+	for name, scope := range scopes {
+		t.Run(name, func(t *testing.T) {
+			requests := record(t, map[string]string{
+				"GET " + scope.uri:                        `{"amount":{"type":"Double","value":30.5,"valueInfo":{}}}`,
+				"GET " + scope.uri + "/amount":            `{"type":"Double","value":30.5,"valueInfo":{}}`,
+				"GET " + scope.uri + "/invoice scan/data": "%PDF",
+			})
+
+			amount := &Variable{Type: "Double", Value: 30.5, ValueInfo: map[string]interface{}{}}
+
+			variables, err := scope.list(ctx, scope.id)
+
+			require.NoError(t, err)
+			require.Equal(t, map[string]*Variable{"amount": amount}, variables)
+
+			variable, err := scope.get(ctx, scope.id, "amount", true)
+
+			require.NoError(t, err)
+			require.Equal(t, amount, variable)
+
+			require.NoError(t, scope.set(ctx, scope.id, "amount", &Variable{Type: "Double", Value: 40.5}))
+			require.NoError(t, scope.delete(ctx, scope.id, "amount"))
+			require.NoError(t, scope.modify(ctx, scope.id, &VariableModification{
+				Modifications: map[string]*Variable{"approved": {Type: "Boolean", Value: true}},
+				Deletions:     []string{"amount"},
+			}))
+
+			data, err := scope.data(ctx, scope.id, "invoice scan")
+
+			require.NoError(t, err)
+
+			content, err := ioutil.ReadAll(data)
+
+			require.NoError(t, err)
+			require.NoError(t, data.Close())
+			require.Equal(t, "%PDF", string(content))
+
+			require.NoError(t, scope.upload(ctx, scope.id, "invoice scan", "File", strings.NewReader("%PDF")))
+			require.NoError(t, scope.upload(ctx, scope.id, "invoice scan", "", strings.NewReader("%PDF")))
+
+			require.Equal(t, []string{
+				"GET " + scope.uri + scope.query,
+				"GET " + scope.uri + "/amount?deserializeValue=true",
+				"PUT " + scope.uri + `/amount {"type":"Double","value":40.5}`,
+				"DELETE " + scope.uri + "/amount",
+				"POST " + scope.uri + ` {"modifications":{"approved":{"type":"Boolean","value":true}},"deletions":["amount"]}`,
+				"GET " + scope.uri + "/invoice%20scan/data",
+				"POST " + scope.uri + "/invoice%20scan/data valueType=File data=@invoice scan:%PDF",
+				"POST " + scope.uri + "/invoice%20scan/data data=@invoice scan:%PDF",
+			}, requests())
+		})
+	}
+}
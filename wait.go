@@ -0,0 +1,249 @@
+package camunda
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// describeTimeout bounds the requests that describe the state of a process instance after a wait
+// timed out, as the context of the wait is already done at that point.
+const describeTimeout = 5 * time.Second
+
+// WaitOptions configures how often the wait functions poll the engine. The first poll is sent
+// immediately, the interval between the following polls grows with every poll.
+type WaitOptions struct {
+	// The initial interval between two polls. Defaults to 100 milliseconds.
+	Interval time.Duration
+
+	// The factor the interval is multiplied with after every poll. Defaults to 2.
+	Multiplier float64
+
+	// The upper bound of the interval. Defaults to 5 seconds.
+	MaxInterval time.Duration
+}
+
+// WaitError is returned by the wait functions when ctx is done before the condition was met. It
+// describes where the process instance was waiting at that point.
+type WaitError struct {
+	// The id of the process instance that was waited for.
+	ProcessInstanceId string
+
+	// A description of the condition that was waited for, e.g. task review.
+	Condition string
+
+	// The state of the process instance when the wait timed out, e.g. ACTIVE or COMPLETED. Empty
+	// when the process instance could not be found.
+	State string
+
+	// The ids of the activities the process instance was waiting at when the wait timed out.
+	ActivityIds []string
+
+	// The error that ended the wait, usually context.DeadlineExceeded.
+	Err error
+}
+
+// WaitForTask polls until the process instance has an open task with the given task definition key
+// and returns it. The wait ends with a *WaitError when ctx is done before.
+func WaitForTask(ctx context.Context, processInstanceId, taskDefinitionKey string, options *WaitOptions) (*Task, error) {
+	var result *Task
+
+	condition := fmt.Sprintf("task %s", taskDefinitionKey)
+
+	err := poll(ctx, processInstanceId, condition, options, func() (bool, error) {
+		tasks, err := GetTasks(ctx, processInstanceId)
+
+		if err != nil {
+			return false, err
+		}
+
+		for _, v := range tasks {
+			if v.TaskDefinitionKey == taskDefinitionKey {
+				result = v
+				return true, nil
+			}
+		}
+
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// WaitForActivity polls until the process instance has started the activity with the given id and
+// returns the first instance of the activity. The wait ends with a *WaitError when ctx is done
+// before.
+func WaitForActivity(ctx context.Context, processInstanceId, activityId string, options *WaitOptions) (*HistoricActivityInstance, error) {
+	var result *HistoricActivityInstance
+
+	condition := fmt.Sprintf("activity %s", activityId)
+	query := &HistoricActivityInstanceQuery{ProcessInstanceId: processInstanceId, ActivityId: activityId}
+
+	err := poll(ctx, processInstanceId, condition, options, func() (bool, error) {
+		activities, err := GetHistoricActivityInstances(ctx, query, 0, 1)
+
+		if err != nil || len(activities) == 0 {
+			return false, err
+		}
+
+		result = activities[0]
+
+		return true, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// WaitForProcessEnd polls until the process instance has ended, either completed or terminated,
+// and returns it. The wait ends with a *WaitError when ctx is done before.
+func WaitForProcessEnd(ctx context.Context, processInstanceId string, options *WaitOptions) (*HistoricProcessInstance, error) {
+	var result *HistoricProcessInstance
+
+	err := poll(ctx, processInstanceId, "end", options, func() (bool, error) {
+		instance, err := GetHistoricProcessInstance(ctx, processInstanceId)
+
+		if err != nil {
+			return false, err
+		}
+
+		result = instance
+
+		return instance.EndTime != "", nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// WaitForIncident polls until an incident was raised in the process instance and returns it. The
+// wait ends with a *WaitError when ctx is done before.
+func WaitForIncident(ctx context.Context, processInstanceId string, options *WaitOptions) (*Incident, error) {
+	var result *Incident
+
+	query := &IncidentQuery{ProcessInstanceId: processInstanceId}
+
+	err := poll(ctx, processInstanceId, "incident", options, func() (bool, error) {
+		incidents, err := GetIncidents(ctx, query, 0, 1)
+
+		if err != nil || len(incidents) == 0 {
+			return false, err
+		}
+
+		result = incidents[0]
+
+		return true, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// poll calls check until it reports success, returns an error or ctx is done. The interval between
+// two calls grows exponentially up to the maximum interval of the options.
+func poll(ctx context.Context, processInstanceId, condition string, options *WaitOptions, check func() (bool, error)) error {
+	interval, multiplier, max := 100*time.Millisecond, 2.0, 5*time.Second
+
+	if options != nil && options.Interval > 0 {
+		interval = options.Interval
+	}
+
+	if options != nil && options.Multiplier >= 1 {
+		multiplier = options.Multiplier
+	}
+
+	if options != nil && options.MaxInterval > 0 {
+		max = options.MaxInterval
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return describe(processInstanceId, condition, ctx.Err())
+		case <-timer.C:
+		}
+
+		ok, err := check()
+
+		if err != nil && ctx.Err() != nil {
+			return describe(processInstanceId, condition, ctx.Err())
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			return nil
+		}
+
+		timer.Reset(interval)
+
+		if interval = time.Duration(float64(interval) * multiplier); interval > max {
+			interval = max
+		}
+	}
+}
+
+// describe creates the error of a timed out wait, with the state and the current activities of the
+// process instance.
+func describe(processInstanceId, condition string, cause error) error {
+	result := &WaitError{ProcessInstanceId: processInstanceId, Condition: condition, Err: cause}
+
+	ctx, cancel := context.WithTimeout(context.Background(), describeTimeout)
+	defer cancel()
+
+	instance, err := GetHistoricProcessInstance(ctx, processInstanceId)
+
+	if err != nil {
+		return result
+	}
+
+	result.State = instance.State
+	result.ActivityIds = make([]string, 0)
+
+	query := &HistoricActivityInstanceQuery{ProcessInstanceId: processInstanceId, Unfinished: true}
+
+	activities, err := GetHistoricActivityInstances(ctx, query, 0, 0)
+
+	if err != nil {
+		return result
+	}
+
+	for _, v := range activities {
+		result.ActivityIds = append(result.ActivityIds, v.ActivityId)
+	}
+
+	return result
+}
+
+func (e *WaitError) Error() string {
+	location := "not started"
+
+	if e.State != "" {
+		location = fmt.Sprintf("%s, waiting at %v", e.State, e.ActivityIds)
+	}
+
+	return fmt.Sprintf("waiting for %s of process instance %s: %v (instance is %s)", e.Condition, e.ProcessInstanceId, e.Err, location)
+}
+
+// Unwrap returns the error that ended the wait.
+func (e *WaitError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,102 @@
+package camunda
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitBackoff(t *testing.T) {
+	var mu sync.Mutex
+
+	polls := make([]time.Time, 0)
+
+	serve(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Method != http.MethodGet || r.URL.Path != "/engine-rest/task" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		polls = append(polls, time.Now())
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if len(polls) < 4 {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`[{"id":"task-1","taskDefinitionKey":"approve"}]`))
+	})
+
+	options := &WaitOptions{Interval: 20 * time.Millisecond, Multiplier: 10, MaxInterval: 50 * time.Millisecond}
+
+	task, err := WaitForTask(context.Background(), "process-instance-1", "approve", options)
+
+	require.NoError(t, err)
+	require.Equal(t, "task-1", task.Id)
+	require.Len(t, polls, 4)
+
+	// the interval grows from 20ms to the max of 50ms. Only the lower bounds are checked, a busy
+	// machine may delay any poll.
+	for i, expected := range []time.Duration{20 * time.Millisecond, 50 * time.Millisecond, 50 * time.Millisecond} {
+		require.GreaterOrEqual(t, int64(polls[i+1].Sub(polls[i])), int64(expected))
+	}
+}
+
+func TestWaitTimeout(t *testing.T) {
+	serve(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/engine-rest/task":
+			_, _ = w.Write([]byte(`[]`))
+		case "/engine-rest/history/process-instance/process-instance-1":
+			_, _ = w.Write([]byte(`{"id":"process-instance-1","state":"ACTIVE"}`))
+		case "/engine-rest/history/activity-instance":
+			_, _ = w.Write([]byte(`[{"activityId":"review"},{"activityId":"timer"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"type":"InvalidRequestException","message":"not found"}`))
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	options := &WaitOptions{Interval: 10 * time.Millisecond}
+
+	_, err := WaitForTask(ctx, "process-instance-1", "approve", options)
+
+	var wait *WaitError
+
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+	require.True(t, errors.As(err, &wait))
+	require.Equal(t, &WaitError{
+		ProcessInstanceId: "process-instance-1",
+		Condition:         "task approve",
+		State:             "ACTIVE",
+		ActivityIds:       []string{"review", "timer"},
+		Err:               context.DeadlineExceeded,
+	}, wait)
+	require.EqualError(t, err, "waiting for task approve of process instance process-instance-1: context deadline exceeded (instance is ACTIVE, waiting at [review timer])")
+
+	// an unknown process instance is described as not started.
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = WaitForTask(ctx, "process-instance-2", "approve", options)
+
+	require.True(t, errors.As(err, &wait))
+	require.Empty(t, wait.State)
+	require.Nil(t, wait.ActivityIds)
+	require.EqualError(t, err, "waiting for task approve of process instance process-instance-2: context deadline exceeded (instance is not started)")
+}